@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	_ "time/tzdata" // embed timezones for delivery slots; the runtime image has none
 
 	"yego/internal/adapters/datasources"
 	"yego/internal/adapters/web"
@@ -9,6 +11,7 @@ import (
 	websocketHandler "yego/internal/adapters/web/handlers/websocket"
 	"yego/internal/adapters/web/integrations"
 	"yego/internal/adapters/web/middlewares"
+	"yego/internal/adapters/workers"
 	"yego/internal/platform/appcontext"
 	"yego/internal/platform/config"
 	"yego/internal/platform/database"
@@ -33,6 +36,12 @@ func main() {
 
 	useCases := usecases.CreateUsecases(contextFactory)
 
+	workers.Start(context.Background(), cfg.WorkerInterval,
+		workers.ResumePausedOrders(useCases.Order.ResumeDueUsecase),
+		workers.RunSubscriptions(useCases.Subscription.RunDueUsecase),
+		workers.ExpireCarts(useCases.Cart.ExpireStaleUsecase),
+	)
+
 	gin.SetMode(cfg.GinMode)
 	app := gin.Default()
 
@@ -0,0 +1,144 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const selectColumns = `
+	SELECT id, order_id, kind, storage_key, content_type, size_bytes, checksum_sha256, uploaded_by, created_at
+	FROM order_attachments
+`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// Create stores the metadata of an uploaded attachment. The ID must be set by the
+// caller, since it is part of the storage key written before this row.
+func (r *repository) Create(ctx context.Context, attachment *domain.Attachment) (*domain.Attachment, apperrors.ApplicationError) {
+	if attachment.ID == "" {
+		attachment.ID = uuid.New().String()
+	}
+	attachment.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO order_attachments (id, order_id, kind, storage_key, content_type, size_bytes, checksum_sha256, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		attachment.ID,
+		attachment.OrderID,
+		attachment.Kind,
+		attachment.StorageKey,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.Checksum,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentCreateError, err)
+	}
+
+	return attachment, nil
+}
+
+// GetByID retrieves an attachment by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Attachment, apperrors.ApplicationError) {
+	attachment, err := scanAttachment(r.db.QueryRowContext(ctx, selectColumns+` WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.AttachmentNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.AttachmentGetError, err)
+	}
+	return attachment, nil
+}
+
+// ListByOrderID retrieves the attachments of an order, oldest first
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.Attachment, apperrors.ApplicationError) {
+	grouped, err := r.ListByOrderIDs(ctx, []string{orderID})
+	if err != nil {
+		return nil, err
+	}
+	return grouped[orderID], nil
+}
+
+// ListByOrderIDs retrieves the attachments of several orders grouped by order ID, oldest first
+func (r *repository) ListByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]*domain.Attachment, apperrors.ApplicationError) {
+	grouped := make(map[string][]*domain.Attachment)
+	if len(orderIDs) == 0 {
+		return grouped, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectColumns+` WHERE order_id = ANY($1) ORDER BY created_at ASC`, pq.Array(orderIDs))
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentGetError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.AttachmentGetError, err)
+		}
+		grouped[attachment.OrderID] = append(grouped[attachment.OrderID], attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentGetError, err)
+	}
+
+	return grouped, nil
+}
+
+// Delete removes the metadata of an attachment
+func (r *repository) Delete(ctx context.Context, id string) apperrors.ApplicationError {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM order_attachments WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.AttachmentDeleteError, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.AttachmentDeleteError, err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.AttachmentNotFoundError, nil)
+	}
+	return nil
+}
+
+func scanAttachment(row scanner) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	var uploadedBy sql.NullString
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.OrderID,
+		&attachment.Kind,
+		&attachment.StorageKey,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+		&attachment.Checksum,
+		&uploadedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if uploadedBy.Valid {
+		attachment.UploadedBy = &uploadedBy.String
+	}
+	return &attachment, nil
+}
@@ -0,0 +1,27 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for order attachment metadata
+type Repository interface {
+	Create(ctx context.Context, attachment *domain.Attachment) (*domain.Attachment, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.Attachment, apperrors.ApplicationError)
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.Attachment, apperrors.ApplicationError)
+	ListByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]*domain.Attachment, apperrors.ApplicationError)
+	Delete(ctx context.Context, id string) apperrors.ApplicationError
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new attachment repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
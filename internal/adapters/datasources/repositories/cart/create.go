@@ -0,0 +1,51 @@
+package cart
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// uniqueViolation is the PostgreSQL error code raised by the one-open-cart-per-user index
+const uniqueViolation = "23505"
+
+// Create inserts a new open cart
+func (r *repository) Create(ctx context.Context, cart *domain.Cart) (*domain.Cart, apperrors.ApplicationError) {
+	cart.ID = uuid.New().String()
+	cart.Status = domain.CartOpen
+	cart.CreatedAt = time.Now()
+	cart.UpdatedAt = cart.CreatedAt
+
+	dataJSON, err := cart.DataJSON()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.CartCreateError, err)
+	}
+
+	query := `
+		INSERT INTO carts (id, user_id, data, currency, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		cart.ID,
+		cart.UserID,
+		dataJSON,
+		cart.Currency,
+		cart.Status,
+		cart.CreatedAt,
+		cart.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, apperrors.NewApplicationError(mappings.CartAlreadyOpenError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.CartCreateError, err)
+	}
+
+	return cart, nil
+}
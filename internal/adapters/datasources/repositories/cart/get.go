@@ -0,0 +1,63 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// GetOpenByUserID retrieves the open cart of a user
+func (r *repository) GetOpenByUserID(ctx context.Context, userID string) (*domain.Cart, apperrors.ApplicationError) {
+	query := `
+		SELECT id, user_id, data, currency, status, order_id, created_at, updated_at, submitted_at, expired_at
+		FROM carts
+		WHERE user_id = $1 AND status = $2
+	`
+
+	var cart domain.Cart
+	var dataJSON []byte
+	var orderID sql.NullString
+	var submittedAt sql.NullTime
+	var expiredAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, userID, domain.CartOpen).Scan(
+		&cart.ID,
+		&cart.UserID,
+		&dataJSON,
+		&cart.Currency,
+		&cart.Status,
+		&orderID,
+		&cart.CreatedAt,
+		&cart.UpdatedAt,
+		&submittedAt,
+		&expiredAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.CartNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.CartGetError, err)
+	}
+
+	var data domain.OrderData
+	if err := json.Unmarshal(dataJSON, &data); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.CartGetError, err)
+	}
+	cart.Data = &data
+	if orderID.Valid {
+		cart.OrderID = &orderID.String
+	}
+	if submittedAt.Valid {
+		cart.SubmittedAt = &submittedAt.Time
+	}
+	if expiredAt.Valid {
+		cart.ExpiredAt = &expiredAt.Time
+	}
+
+	return &cart, nil
+}
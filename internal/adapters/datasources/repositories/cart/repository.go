@@ -0,0 +1,28 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for server-side cart operations
+type Repository interface {
+	Create(ctx context.Context, cart *domain.Cart) (*domain.Cart, apperrors.ApplicationError)
+	GetOpenByUserID(ctx context.Context, userID string) (*domain.Cart, apperrors.ApplicationError)
+	Update(ctx context.Context, cart *domain.Cart) (*domain.Cart, apperrors.ApplicationError)
+	Transition(ctx context.Context, cart *domain.Cart, from domain.CartStatus) (bool, apperrors.ApplicationError)
+	ExpireInactive(ctx context.Context, before time.Time, now time.Time) (int, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new cart repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
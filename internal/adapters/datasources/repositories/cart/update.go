@@ -0,0 +1,99 @@
+package cart
+
+import (
+	"context"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Update saves the items of an open cart and counts as activity. Carts submitted or
+// expired meanwhile are left alone and fail with CartNotOpenError.
+func (r *repository) Update(ctx context.Context, cart *domain.Cart) (*domain.Cart, apperrors.ApplicationError) {
+	dataJSON, err := cart.DataJSON()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+	updatedAt := time.Now()
+
+	query := `
+		UPDATE carts
+		SET data = $1, currency = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query, dataJSON, cart.Currency, updatedAt, cart.ID, domain.CartOpen)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.NewApplicationError(mappings.CartNotOpenError, nil)
+	}
+
+	cart.UpdatedAt = updatedAt
+	return cart, nil
+}
+
+// Transition saves the status, order and timestamps of a cart, but only if it is
+// still in status from. It reports whether this caller made the change, so a cart
+// is never submitted twice.
+func (r *repository) Transition(ctx context.Context, cart *domain.Cart, from domain.CartStatus) (bool, apperrors.ApplicationError) {
+	query := `
+		UPDATE carts
+		SET status = $1, order_id = $2, submitted_at = $3, expired_at = $4, updated_at = $5
+		WHERE id = $6 AND status = $7
+	`
+
+	updatedAt := time.Now()
+	result, err := r.db.ExecContext(ctx, query,
+		cart.Status,
+		cart.OrderID,
+		cart.SubmittedAt,
+		cart.ExpiredAt,
+		updatedAt,
+		cart.ID,
+		from,
+	)
+	if err != nil {
+		return false, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	cart.UpdatedAt = updatedAt
+	return true, nil
+}
+
+// ExpireInactive expires every open cart without activity since before and returns
+// how many were expired
+func (r *repository) ExpireInactive(ctx context.Context, before time.Time, now time.Time) (int, apperrors.ApplicationError) {
+	query := `
+		UPDATE carts
+		SET status = $1, expired_at = $2, updated_at = $2
+		WHERE status = $3 AND updated_at < $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, domain.CartExpired, now, domain.CartOpen, before)
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.CartUpdateError, err)
+	}
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,168 @@
+package deliverycode
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/lib/pq"
+)
+
+const selectColumns = `
+	SELECT order_id, code, failed_attempts, total_failed_attempts, locked_until, verified_at,
+		   override_by, override_reason, created_at
+	FROM order_delivery_codes
+`
+
+// Issue stores a code for the order unless it already has one, and returns the stored code.
+// An order that goes back ON_THE_WAY after a pause keeps the code the customer already saw.
+func (r *repository) Issue(ctx context.Context, orderID string, code string) (*domain.DeliveryCode, apperrors.ApplicationError) {
+	query := `
+		INSERT INTO order_delivery_codes (order_id, code, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (order_id) DO NOTHING
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, code, time.Now()); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliveryCodeCreateError, err)
+	}
+
+	return r.GetByOrderID(ctx, orderID)
+}
+
+// GetByOrderID retrieves the delivery code of an order
+func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.DeliveryCode, apperrors.ApplicationError) {
+	code, err := scanCode(r.db.QueryRowContext(ctx, selectColumns+` WHERE order_id = $1`, orderID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.DeliveryCodeNotIssuedError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.DeliveryCodeGetError, err)
+	}
+	return code, nil
+}
+
+// ListByOrderIDs retrieves the delivery codes of several orders, keyed by order ID.
+// Orders without a code are left out.
+func (r *repository) ListByOrderIDs(ctx context.Context, orderIDs []string) (map[string]*domain.DeliveryCode, apperrors.ApplicationError) {
+	codes := make(map[string]*domain.DeliveryCode)
+	if len(orderIDs) == 0 {
+		return codes, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectColumns+` WHERE order_id = ANY($1)`, pq.Array(orderIDs))
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliveryCodeGetError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		code, err := scanCode(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.DeliveryCodeGetError, err)
+		}
+		codes[code.OrderID] = code
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliveryCodeGetError, err)
+	}
+
+	return codes, nil
+}
+
+// RecordFailure counts a wrong code. Reaching DeliveryCodeMaxAttempts locks verification
+// for DeliveryCodeLockout and starts a new round of attempts; the total keeps counting.
+func (r *repository) RecordFailure(ctx context.Context, orderID string, now time.Time) (*domain.DeliveryCode, apperrors.ApplicationError) {
+	query := `
+		UPDATE order_delivery_codes
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END,
+			total_failed_attempts = total_failed_attempts + 1
+		WHERE order_id = $1
+		RETURNING order_id, code, failed_attempts, total_failed_attempts, locked_until, verified_at,
+			override_by, override_reason, created_at
+	`
+
+	code, err := scanCode(r.db.QueryRowContext(ctx, query, orderID, domain.DeliveryCodeMaxAttempts, now.Add(domain.DeliveryCodeLockout)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.DeliveryCodeNotIssuedError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.DeliveryCodeUpdateError, err)
+	}
+	return code, nil
+}
+
+// MarkVerified records that the customer's code was accepted
+func (r *repository) MarkVerified(ctx context.Context, orderID string, now time.Time) apperrors.ApplicationError {
+	query := `UPDATE order_delivery_codes SET verified_at = $2, failed_attempts = 0 WHERE order_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, now); err != nil {
+		return apperrors.NewApplicationError(mappings.DeliveryCodeUpdateError, err)
+	}
+	return nil
+}
+
+// RecordOverride records an admin marking the order delivered without the code.
+// Orders that never got a code are stored with an empty one.
+func (r *repository) RecordOverride(ctx context.Context, orderID string, overrideBy string, reason string, now time.Time) apperrors.ApplicationError {
+	query := `
+		INSERT INTO order_delivery_codes (order_id, code, verified_at, override_by, override_reason, created_at)
+		VALUES ($1, '', $2, $3, $4, $2)
+		ON CONFLICT (order_id) DO UPDATE
+		SET verified_at = EXCLUDED.verified_at, override_by = EXCLUDED.override_by, override_reason = EXCLUDED.override_reason
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, now, overrideBy, reason); err != nil {
+		return apperrors.NewApplicationError(mappings.DeliveryCodeUpdateError, err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCode(row scanner) (*domain.DeliveryCode, error) {
+	var code domain.DeliveryCode
+	var lockedUntil sql.NullTime
+	var verifiedAt sql.NullTime
+	var overrideBy sql.NullString
+	var overrideReason sql.NullString
+
+	err := row.Scan(
+		&code.OrderID,
+		&code.Code,
+		&code.FailedAttempts,
+		&code.TotalFailedAttempts,
+		&lockedUntil,
+		&verifiedAt,
+		&overrideBy,
+		&overrideReason,
+		&code.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lockedUntil.Valid {
+		code.LockedUntil = &lockedUntil.Time
+	}
+	if verifiedAt.Valid {
+		code.VerifiedAt = &verifiedAt.Time
+	}
+	if overrideBy.Valid {
+		code.OverrideBy = &overrideBy.String
+	}
+	if overrideReason.Valid {
+		code.OverrideReason = &overrideReason.String
+	}
+
+	return &code, nil
+}
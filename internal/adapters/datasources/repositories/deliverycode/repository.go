@@ -0,0 +1,29 @@
+package deliverycode
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for proof-of-delivery code operations
+type Repository interface {
+	Issue(ctx context.Context, orderID string, code string) (*domain.DeliveryCode, apperrors.ApplicationError)
+	GetByOrderID(ctx context.Context, orderID string) (*domain.DeliveryCode, apperrors.ApplicationError)
+	ListByOrderIDs(ctx context.Context, orderIDs []string) (map[string]*domain.DeliveryCode, apperrors.ApplicationError)
+	RecordFailure(ctx context.Context, orderID string, now time.Time) (*domain.DeliveryCode, apperrors.ApplicationError)
+	MarkVerified(ctx context.Context, orderID string, now time.Time) apperrors.ApplicationError
+	RecordOverride(ctx context.Context, orderID string, overrideBy string, reason string, now time.Time) apperrors.ApplicationError
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new delivery code repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
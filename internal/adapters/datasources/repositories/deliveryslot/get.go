@@ -0,0 +1,71 @@
+package deliveryslot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// GetByOrderID retrieves the reservation held by an order
+func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.DeliverySlotReservation, apperrors.ApplicationError) {
+	query := `
+		SELECT id, order_id, slot_date::text, start_time, end_time, starts_at, ends_at, created_at
+		FROM delivery_slot_reservations
+		WHERE order_id = $1
+	`
+
+	var reservation domain.DeliverySlotReservation
+	var reservedOrderID sql.NullString
+	err := r.db.QueryRowContext(ctx, query, orderID).Scan(
+		&reservation.ID,
+		&reservedOrderID,
+		&reservation.Date,
+		&reservation.Start,
+		&reservation.End,
+		&reservation.StartsAt,
+		&reservation.EndsAt,
+		&reservation.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotReservationNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotGetError, err)
+	}
+	if reservedOrderID.Valid {
+		reservation.OrderID = &reservedOrderID.String
+	}
+
+	return &reservation, nil
+}
+
+// GetReservedCounts returns how many places are taken in each slot of a date, keyed by UsageKey
+func (r *repository) GetReservedCounts(ctx context.Context, date string) (map[string]int, apperrors.ApplicationError) {
+	query := `SELECT start_time, end_time, reserved FROM delivery_slot_usage WHERE slot_date = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, date)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotGetError, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var start, end string
+		var reserved int
+		if err := rows.Scan(&start, &end, &reserved); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotGetError, err)
+		}
+		counts[UsageKey(start, end)] = reserved
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotGetError, err)
+	}
+
+	return counts, nil
+}
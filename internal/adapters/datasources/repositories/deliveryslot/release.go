@@ -0,0 +1,65 @@
+package deliveryslot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const (
+	deleteByIDQuery      = `DELETE FROM delivery_slot_reservations WHERE id = $1 RETURNING slot_date::text, start_time, end_time`
+	deleteByOrderIDQuery = `DELETE FROM delivery_slot_reservations WHERE order_id = $1 RETURNING slot_date::text, start_time, end_time`
+)
+
+// Release deletes a reservation and gives its place back to the slot
+func (r *repository) Release(ctx context.Context, reservationID string) apperrors.ApplicationError {
+	return r.release(ctx, deleteByIDQuery, reservationID)
+}
+
+// ReleaseByOrderID releases the reservation held by an order, if any
+func (r *repository) ReleaseByOrderID(ctx context.Context, orderID string) apperrors.ApplicationError {
+	return r.release(ctx, deleteByOrderIDQuery, orderID)
+}
+
+func (r *repository) release(ctx context.Context, deleteQuery string, arg string) apperrors.ApplicationError {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotReleaseError, err)
+	}
+	defer tx.Rollback()
+
+	if appErr := deleteAndDecrement(ctx, tx, deleteQuery, arg); appErr != nil {
+		return appErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotReleaseError, err)
+	}
+	return nil
+}
+
+// deleteAndDecrement runs a reservation DELETE ... RETURNING and frees the matching
+// usage counter. Deleting nothing is not an error.
+func deleteAndDecrement(ctx context.Context, tx *sql.Tx, deleteQuery string, arg string) apperrors.ApplicationError {
+	var date, start, end string
+	err := tx.QueryRowContext(ctx, deleteQuery, arg).Scan(&date, &start, &end)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return apperrors.NewApplicationError(mappings.DeliverySlotReleaseError, err)
+	}
+
+	query := `
+		UPDATE delivery_slot_usage
+		SET reserved = reserved - 1
+		WHERE slot_date = $1 AND start_time = $2 AND end_time = $3 AND reserved > 0
+	`
+	if _, err := tx.ExecContext(ctx, query, date, start, end); err != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotReleaseError, err)
+	}
+	return nil
+}
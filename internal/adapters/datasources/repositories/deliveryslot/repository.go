@@ -0,0 +1,33 @@
+package deliveryslot
+
+import (
+	"context"
+	"database/sql"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for delivery slot reservation operations
+type Repository interface {
+	Reserve(ctx context.Context, slot domain.DeliverySlot, orderID *string) (*domain.DeliverySlotReservation, apperrors.ApplicationError)
+	AssignOrder(ctx context.Context, reservationID string, orderID string) apperrors.ApplicationError
+	Release(ctx context.Context, reservationID string) apperrors.ApplicationError
+	ReleaseByOrderID(ctx context.Context, orderID string) apperrors.ApplicationError
+	GetByOrderID(ctx context.Context, orderID string) (*domain.DeliverySlotReservation, apperrors.ApplicationError)
+	GetReservedCounts(ctx context.Context, date string) (map[string]int, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new delivery slot repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// UsageKey identifies a slot within a date in the map returned by GetReservedCounts
+func UsageKey(start, end string) string {
+	return start + "-" + end
+}
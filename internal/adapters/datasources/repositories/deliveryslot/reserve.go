@@ -0,0 +1,122 @@
+package deliveryslot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// uniqueViolation is the PostgreSQL error code raised by the one-reservation-per-order constraint
+const uniqueViolation = "23505"
+
+// Reserve takes one place in the slot. The usage counter only moves while it is below
+// capacity, so concurrent bookings cannot oversell the slot. When orderID already holds
+// a reservation it is swapped for the new one in the same transaction.
+func (r *repository) Reserve(ctx context.Context, slot domain.DeliverySlot, orderID *string) (*domain.DeliverySlotReservation, apperrors.ApplicationError) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotReserveError, err)
+	}
+	defer tx.Rollback()
+
+	if orderID != nil {
+		if appErr := deleteAndDecrement(ctx, tx, deleteByOrderIDQuery, *orderID); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	query := `
+		INSERT INTO delivery_slot_usage (slot_date, start_time, end_time, reserved)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (slot_date, start_time, end_time)
+		DO UPDATE SET reserved = delivery_slot_usage.reserved + 1
+		WHERE delivery_slot_usage.reserved < $4
+		RETURNING reserved
+	`
+
+	var reserved int
+	err = tx.QueryRowContext(ctx, query, slot.Date, slot.Start, slot.End, slot.Capacity).Scan(&reserved)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotFullError,
+				fmt.Errorf("slot %s %s is full", slot.Date, slot.Label()))
+		}
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotReserveError, err)
+	}
+	// A fresh counter row skips the WHERE clause, so guard against a capacity below one
+	if reserved > slot.Capacity {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotFullError,
+			fmt.Errorf("slot %s %s is full", slot.Date, slot.Label()))
+	}
+
+	reservation := &domain.DeliverySlotReservation{
+		ID:        uuid.New().String(),
+		OrderID:   orderID,
+		Date:      slot.Date,
+		Start:     slot.Start,
+		End:       slot.End,
+		StartsAt:  slot.StartsAt,
+		EndsAt:    slot.EndsAt,
+		CreatedAt: time.Now(),
+	}
+
+	insert := `
+		INSERT INTO delivery_slot_reservations (id, order_id, slot_date, start_time, end_time, starts_at, ends_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = tx.ExecContext(ctx, insert,
+		reservation.ID,
+		reservation.OrderID,
+		reservation.Date,
+		reservation.Start,
+		reservation.End,
+		reservation.StartsAt,
+		reservation.EndsAt,
+		reservation.CreatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotAlreadyReservedError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotReserveError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotReserveError, err)
+	}
+
+	return reservation, nil
+}
+
+// AssignOrder links a reservation taken before its order existed
+func (r *repository) AssignOrder(ctx context.Context, reservationID string, orderID string) apperrors.ApplicationError {
+	query := `UPDATE delivery_slot_reservations SET order_id = $1 WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, orderID, reservationID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return apperrors.NewApplicationError(mappings.DeliverySlotAlreadyReservedError, err)
+		}
+		return apperrors.NewApplicationError(mappings.DeliverySlotReserveError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotReserveError, err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.DeliverySlotReservationNotFoundError,
+			fmt.Errorf("reservation %s not found", reservationID))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package modificationrequest
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// uniqueViolation is the PostgreSQL error code raised by the one-pending-request-per-order index
+const uniqueViolation = "23505"
+
+// Create inserts a new pending modification request
+func (r *repository) Create(ctx context.Context, request *domain.ModificationRequest) (*domain.ModificationRequest, apperrors.ApplicationError) {
+	request.ID = uuid.New().String()
+	request.Status = domain.ModificationRequestPending
+	request.CreatedAt = time.Now()
+	request.UpdatedAt = request.CreatedAt
+
+	originalItemsJSON, err := json.Marshal(request.OriginalItems)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestCreateError, err)
+	}
+	itemsJSON, err := json.Marshal(request.Items)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestCreateError, err)
+	}
+
+	query := `
+		INSERT INTO order_modification_requests (id, order_id, user_id, previous_status, original_items, items, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		request.ID,
+		request.OrderID,
+		request.UserID,
+		request.PreviousStatus,
+		originalItemsJSON,
+		itemsJSON,
+		request.Status,
+		request.CreatedAt,
+		request.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, apperrors.NewApplicationError(mappings.ModificationRequestAlreadyPendingError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestCreateError, err)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,109 @@
+package modificationrequest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const selectColumns = `
+	SELECT id, order_id, user_id, previous_status, original_items, items, status, reviewed_by, review_note,
+		   price_difference, created_at, updated_at, resolved_at
+	FROM order_modification_requests
+`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// GetPendingByOrderID retrieves the open modification request of an order
+func (r *repository) GetPendingByOrderID(ctx context.Context, orderID string) (*domain.ModificationRequest, apperrors.ApplicationError) {
+	query := selectColumns + ` WHERE order_id = $1 AND status = $2`
+
+	request, err := scanRequest(r.db.QueryRowContext(ctx, query, orderID, domain.ModificationRequestPending))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.ModificationRequestNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestGetError, err)
+	}
+
+	return request, nil
+}
+
+// ListByOrderID retrieves every modification request of an order, newest first
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.ModificationRequest, apperrors.ApplicationError) {
+	query := selectColumns + ` WHERE order_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestGetError, err)
+	}
+	defer rows.Close()
+
+	var requests []*domain.ModificationRequest
+	for rows.Next() {
+		request, err := scanRequest(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.ModificationRequestGetError, err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestGetError, err)
+	}
+
+	return requests, nil
+}
+
+func scanRequest(row scanner) (*domain.ModificationRequest, error) {
+	var request domain.ModificationRequest
+	var originalItemsJSON []byte
+	var itemsJSON []byte
+	var reviewedBy sql.NullString
+	var reviewNote sql.NullString
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&request.ID,
+		&request.OrderID,
+		&request.UserID,
+		&request.PreviousStatus,
+		&originalItemsJSON,
+		&itemsJSON,
+		&request.Status,
+		&reviewedBy,
+		&reviewNote,
+		&request.PriceDifference,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(originalItemsJSON, &request.OriginalItems); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(itemsJSON, &request.Items); err != nil {
+		return nil, err
+	}
+	if reviewedBy.Valid {
+		request.ReviewedBy = &reviewedBy.String
+	}
+	if reviewNote.Valid {
+		request.ReviewNote = &reviewNote.String
+	}
+	if resolvedAt.Valid {
+		request.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &request, nil
+}
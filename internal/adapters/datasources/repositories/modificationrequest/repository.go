@@ -0,0 +1,26 @@
+package modificationrequest
+
+import (
+	"context"
+	"database/sql"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for order modification request operations
+type Repository interface {
+	Create(ctx context.Context, request *domain.ModificationRequest) (*domain.ModificationRequest, apperrors.ApplicationError)
+	GetPendingByOrderID(ctx context.Context, orderID string) (*domain.ModificationRequest, apperrors.ApplicationError)
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.ModificationRequest, apperrors.ApplicationError)
+	Resolve(ctx context.Context, request *domain.ModificationRequest) (*domain.ModificationRequest, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new modification request repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
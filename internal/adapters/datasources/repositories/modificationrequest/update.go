@@ -0,0 +1,49 @@
+package modificationrequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Resolve closes a pending modification request with its final status and review data
+func (r *repository) Resolve(ctx context.Context, request *domain.ModificationRequest) (*domain.ModificationRequest, apperrors.ApplicationError) {
+	now := time.Now()
+	request.UpdatedAt = now
+	request.ResolvedAt = &now
+
+	query := `
+		UPDATE order_modification_requests
+		SET status = $1, reviewed_by = $2, review_note = $3, price_difference = $4, updated_at = $5, resolved_at = $6
+		WHERE id = $7 AND status = $8
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		request.Status,
+		request.ReviewedBy,
+		request.ReviewNote,
+		request.PriceDifference,
+		request.UpdatedAt,
+		request.ResolvedAt,
+		request.ID,
+		domain.ModificationRequestPending,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestNotFoundError,
+			fmt.Errorf("modification request %s is no longer pending", request.ID))
+	}
+
+	return request, nil
+}
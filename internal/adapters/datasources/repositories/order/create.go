@@ -14,8 +14,12 @@ import (
 func (r *repository) Create(ctx context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError) {
 	order.ID = uuid.New().String()
 	order.Status = domain.StatusCreated
+	order.Version = 1
 	order.CreatedAt = time.Now()
 	order.UpdatedAt = time.Now()
+	if order.Currency == "" {
+		order.Currency = domain.DefaultCurrency
+	}
 
 	dataJSON, err := order.DataJSON()
 	if err != nil {
@@ -23,17 +27,28 @@ func (r *repository) Create(ctx context.Context, order *domain.Order) (*domain.O
 	}
 
 	query := `
-		INSERT INTO orders (id, profile_id, user_id, status, eta, data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO orders (id, profile_id, user_id, status, eta, eta_from, eta_to, data, version, subscription_id, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCreateError, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
 		order.ID,
 		order.ProfileID,
 		order.UserID,
 		order.Status,
 		order.ETA,
+		order.ETAFrom,
+		order.ETATo,
 		dataJSON,
+		order.Version,
+		order.SubscriptionID,
+		order.Currency,
 		order.CreatedAt,
 		order.UpdatedAt,
 	)
@@ -42,5 +57,20 @@ func (r *repository) Create(ctx context.Context, order *domain.Order) (*domain.O
 		return nil, apperrors.NewApplicationError(mappings.OrderCreateError, err)
 	}
 
+	// The history starts with the order itself, so it is complete from the first status
+	event := &domain.OrderStatusEvent{
+		OrderID:     order.ID,
+		NewStatus:   order.Status,
+		ActorUserID: order.UserID,
+		CreatedAt:   order.CreatedAt,
+	}
+	if appErr := insertStatusEvent(ctx, tx, event); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCreateError, err)
+	}
+
 	return order, nil
 }
@@ -4,24 +4,33 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 )
 
-// GetByID retrieves an order by its ID
-func (r *repository) GetByID(ctx context.Context, id string) (*domain.Order, apperrors.ApplicationError) {
-	query := `
-		SELECT id, profile_id, user_id, status, status_message, eta, data, created_at, updated_at
-		FROM orders
-		WHERE id = $1
-	`
+// orderColumns is the column list every order query selects, in scanOrder order
+const orderColumns = `id, profile_id, user_id, status, status_message, eta, data, version,
+		paused_from_status, pause_reason, resume_at, eta_from, eta_to, subscription_id, pricing, currency, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
 
+// scanOrder reads a row selected with orderColumns
+func scanOrder(row scanner) (*domain.Order, error) {
 	var order domain.Order
 	var dataJSON []byte
+	var pricingJSON []byte
 	var statusMessage sql.NullString
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var pausedFromStatus sql.NullString
+	var pauseReason sql.NullString
+	var resumeAt sql.NullTime
+	var etaFrom sql.NullTime
+	var etaTo sql.NullTime
+	err := row.Scan(
 		&order.ID,
 		&order.ProfileID,
 		&order.UserID,
@@ -29,89 +38,112 @@ func (r *repository) GetByID(ctx context.Context, id string) (*domain.Order, app
 		&statusMessage,
 		&order.ETA,
 		&dataJSON,
+		&order.Version,
+		&pausedFromStatus,
+		&pauseReason,
+		&resumeAt,
+		&etaFrom,
+		&etaTo,
+		&order.SubscriptionID,
+		&pricingJSON,
+		&order.Currency,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
-
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, err)
-		}
-		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+		return nil, err
 	}
 
 	if dataJSON != nil {
 		if err := order.SetDataFromJSON(dataJSON); err != nil {
-			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+			return nil, err
+		}
+	}
+	if pricingJSON != nil {
+		if err := order.SetPricingFromJSON(pricingJSON); err != nil {
+			return nil, err
 		}
 	}
-
 	if statusMessage.Valid {
 		order.StatusMessage = &statusMessage.String
 	}
+	if pausedFromStatus.Valid {
+		status := domain.OrderStatus(pausedFromStatus.String)
+		order.PausedFromStatus = &status
+	}
+	if pauseReason.Valid {
+		order.PauseReason = &pauseReason.String
+	}
+	if resumeAt.Valid {
+		order.ResumeAt = &resumeAt.Time
+	}
+	if etaFrom.Valid {
+		order.ETAFrom = &etaFrom.Time
+	}
+	if etaTo.Valid {
+		order.ETATo = &etaTo.Time
+	}
 
 	return &order, nil
 }
 
-// GetAll retrieves all orders
-func (r *repository) GetAll(ctx context.Context) ([]*domain.Order, apperrors.ApplicationError) {
+// GetByID retrieves an order by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Order, apperrors.ApplicationError) {
 	query := `
-		SELECT id, profile_id, user_id, status, status_message, eta, data, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
-		ORDER BY created_at DESC
+		WHERE id = $1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	order, err := scanOrder(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, err)
+		}
 		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
 	}
-	defer rows.Close()
 
-	var orders []*domain.Order
-	for rows.Next() {
-		var order domain.Order
-		var dataJSON []byte
-		var statusMessage sql.NullString
-		err := rows.Scan(
-			&order.ID,
-			&order.ProfileID,
-			&order.UserID,
-			&order.Status,
-			&statusMessage,
-			&order.ETA,
-			&dataJSON,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
-		if err != nil {
-			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
-		}
-		if dataJSON != nil {
-			_ = order.SetDataFromJSON(dataJSON)
-		}
-		if statusMessage.Valid {
-			order.StatusMessage = &statusMessage.String
-		}
-		orders = append(orders, &order)
-	}
+	return order, nil
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
-	}
+// GetAll retrieves all orders
+func (r *repository) GetAll(ctx context.Context) ([]*domain.Order, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		ORDER BY created_at DESC
+	`
 
-	return orders, nil
+	return r.queryOrders(ctx, query)
 }
 
 // GetByUserID retrieves all orders for a specific user
 func (r *repository) GetByUserID(ctx context.Context, userID string) ([]*domain.Order, apperrors.ApplicationError) {
 	query := `
-		SELECT id, profile_id, user_id, status, status_message, eta, data, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
 		WHERE user_id = $1
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	return r.queryOrders(ctx, query, userID)
+}
+
+// ListDueForResume retrieves paused orders whose resume time has passed
+func (r *repository) ListDueForResume(ctx context.Context, now time.Time) ([]*domain.Order, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE status = $1 AND resume_at IS NOT NULL AND resume_at <= $2
+		ORDER BY resume_at ASC
+	`
+
+	return r.queryOrders(ctx, query, domain.StatusPaused, now)
+}
+
+// queryOrders runs a query selecting orderColumns and scans every row
+func (r *repository) queryOrders(ctx context.Context, query string, args ...any) ([]*domain.Order, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
 	}
@@ -119,30 +151,11 @@ func (r *repository) GetByUserID(ctx context.Context, userID string) ([]*domain.
 
 	var orders []*domain.Order
 	for rows.Next() {
-		var order domain.Order
-		var dataJSON []byte
-		var statusMessage sql.NullString
-		err := rows.Scan(
-			&order.ID,
-			&order.ProfileID,
-			&order.UserID,
-			&order.Status,
-			&statusMessage,
-			&order.ETA,
-			&dataJSON,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
+		order, err := scanOrder(rows)
 		if err != nil {
 			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
 		}
-		if dataJSON != nil {
-			_ = order.SetDataFromJSON(dataJSON)
-		}
-		if statusMessage.Valid {
-			order.StatusMessage = &statusMessage.String
-		}
-		orders = append(orders, &order)
+		orders = append(orders, order)
 	}
 
 	if err = rows.Err(); err != nil {
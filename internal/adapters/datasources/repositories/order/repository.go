@@ -3,6 +3,7 @@ package order
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
@@ -14,10 +15,17 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.Order, apperrors.ApplicationError)
 	GetAll(ctx context.Context) ([]*domain.Order, apperrors.ApplicationError)
 	GetByUserID(ctx context.Context, userID string) ([]*domain.Order, apperrors.ApplicationError)
-	UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, apperrors.ApplicationError)
-	Update(ctx context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError)
+	Search(ctx context.Context, filter domain.OrderSearchFilter) (*domain.OrderSearchResult, apperrors.ApplicationError)
+	Stream(ctx context.Context, filter domain.OrderSearchFilter, fn func(order *domain.Order) apperrors.ApplicationError) apperrors.ApplicationError
+	ListDueForResume(ctx context.Context, now time.Time) ([]*domain.Order, apperrors.ApplicationError)
+	UpdateStatus(ctx context.Context, id string, status domain.OrderStatus, expectedVersion *int, actorUserID *string) (*domain.Order, apperrors.ApplicationError)
+	Update(ctx context.Context, order *domain.Order, actorUserID *string) (*domain.Order, apperrors.ApplicationError)
+	UpdateMany(ctx context.Context, orders []*domain.Order, actorUserID *string) ([]*domain.Order, apperrors.ApplicationError)
 	AssignUser(ctx context.Context, orderID string, userID string) apperrors.ApplicationError
 	AssignProfile(ctx context.Context, orderID string, profileID string) apperrors.ApplicationError
+	SetPricing(ctx context.Context, orderID string, pricing *domain.OrderPricing) apperrors.ApplicationError
+	GetStatusEvents(ctx context.Context, orderID string) ([]*domain.OrderStatusEvent, apperrors.ApplicationError)
+	GetStatusEventsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]*domain.OrderStatusEvent, apperrors.ApplicationError)
 }
 
 type repository struct {
@@ -0,0 +1,266 @@
+package order
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// sortSpec is how a sort field is selected, compared and read back for cursors
+type sortSpec struct {
+	expr  string
+	cast  string
+	value func(order *domain.Order) string
+}
+
+// sortSpecs has an entry for each of domain.OrderSortFields
+var sortSpecs = map[string]sortSpec{
+	domain.OrderSortCreatedAt: {
+		expr:  "created_at",
+		cast:  "timestamptz",
+		value: func(o *domain.Order) string { return o.CreatedAt.UTC().Format(time.RFC3339Nano) },
+	},
+	domain.OrderSortUpdatedAt: {
+		expr:  "updated_at",
+		cast:  "timestamptz",
+		value: func(o *domain.Order) string { return o.UpdatedAt.UTC().Format(time.RFC3339Nano) },
+	},
+	domain.OrderSortTotal: {
+		// Orders not priced yet sort as zero
+		expr: "COALESCE((pricing->>'total')::numeric, 0)",
+		cast: "numeric",
+		value: func(o *domain.Order) string {
+			if o.Pricing == nil {
+				return "0"
+			}
+			return o.Pricing.Total.String()
+		},
+	},
+	domain.OrderSortStatus: {
+		expr:  "status",
+		cast:  "text",
+		value: func(o *domain.Order) string { return string(o.Status) },
+	},
+}
+
+// searchCursor is the position after the last order of a page. It records the
+// sort it was taken with, so it can't be replayed against another one.
+type searchCursor struct {
+	Sort      string `json:"s"`
+	Ascending bool   `json:"a"`
+	Value     string `json:"v"`
+	ID        string `json:"id"`
+}
+
+func encodeCursor(cursor searchCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(encoded string) (searchCursor, error) {
+	var cursor searchCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, fmt.Errorf("malformed cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, fmt.Errorf("malformed cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// escapeLike escapes the LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// queryArgs collects the bound parameters of a query as it is built
+type queryArgs []any
+
+// add binds value and returns its placeholder
+func (a *queryArgs) add(value any) string {
+	*a = append(*a, value)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// searchConditions translates the filter into WHERE conditions, binding every value
+func searchConditions(filter domain.OrderSearchFilter, args *queryArgs) []string {
+	var conditions []string
+	arg := args.add
+
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = arg(string(status))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = "+arg(filter.UserID))
+	}
+	if filter.ProfileID != "" {
+		conditions = append(conditions, "profile_id = "+arg(filter.ProfileID))
+	}
+	if filter.Phone != "" {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM profiles WHERE profiles.id = orders.profile_id
+			AND profiles.phone_number LIKE `+arg("%"+escapeLike(filter.Phone)+"%")+`)`)
+	}
+	if filter.ItemCode != "" {
+		contains, _ := json.Marshal(map[string]any{"items": []map[string]string{{"code": filter.ItemCode}}})
+		conditions = append(conditions, "data @> "+arg(string(contains))+"::jsonb")
+	}
+	if filter.ItemName != "" {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM jsonb_array_elements(COALESCE(data->'items', '[]'::jsonb)) AS item
+			WHERE item->>'name' ILIKE `+arg("%"+escapeLike(filter.ItemName)+"%")+`)`)
+	}
+	if filter.MinTotal != nil {
+		conditions = append(conditions, sortSpecs[domain.OrderSortTotal].expr+" >= "+arg(*filter.MinTotal))
+	}
+	if filter.MaxTotal != nil {
+		conditions = append(conditions, sortSpecs[domain.OrderSortTotal].expr+" <= "+arg(*filter.MaxTotal))
+	}
+	return conditions
+}
+
+// whereClause joins conditions into a WHERE clause, empty without conditions
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// Search returns one page of the orders matching the filter. Every value is bound
+// as a parameter; only whitelisted sort expressions are written into the query.
+func (r *repository) Search(ctx context.Context, filter domain.OrderSearchFilter) (*domain.OrderSearchResult, apperrors.ApplicationError) {
+	if filter.Sort == "" {
+		filter.Sort = domain.OrderSortCreatedAt
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = domain.DefaultOrderSearchLimit
+	}
+	spec, ok := sortSpecs[filter.Sort]
+	if !ok {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidSearchError, fmt.Errorf("unknown sort field %q", filter.Sort))
+	}
+
+	var args queryArgs
+	arg := args.add
+	conditions := searchConditions(filter, &args)
+	where := whereClause(conditions)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders "+where, args...).Scan(&total); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	direction, comparison := "DESC", "<"
+	if filter.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidSearchError, err)
+		}
+		if cursor.Sort != filter.Sort || cursor.Ascending != filter.Ascending {
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidSearchError, fmt.Errorf("cursor was taken with another sort"))
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s::%s, %s::uuid)",
+			spec.expr, comparison, arg(cursor.Value), spec.cast, arg(cursor.ID)))
+		where = whereClause(conditions)
+	}
+
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		` + where + `
+		ORDER BY ` + spec.expr + ` ` + direction + `, id ` + direction + `
+		LIMIT ` + arg(filter.Limit+1)
+	if filter.Cursor == "" && filter.Offset > 0 {
+		query += ` OFFSET ` + arg(filter.Offset)
+	}
+
+	orders, appErr := r.queryOrders(ctx, query, args...)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// One extra row tells whether another page follows
+	result := &domain.OrderSearchResult{Orders: orders, Total: total}
+	if len(orders) > filter.Limit {
+		result.Orders = orders[:filter.Limit]
+		last := result.Orders[len(result.Orders)-1]
+		result.NextCursor = encodeCursor(searchCursor{
+			Sort:      filter.Sort,
+			Ascending: filter.Ascending,
+			Value:     spec.value(last),
+			ID:        last.ID,
+		})
+	}
+	if result.Orders == nil {
+		result.Orders = []*domain.Order{}
+	}
+	return result, nil
+}
+
+// Stream calls fn with every order matching the filter, in its sort order, while
+// reading them from the database, so large results are never held in memory.
+// Limit, Offset and Cursor are ignored. An error from fn stops the stream and is returned.
+func (r *repository) Stream(ctx context.Context, filter domain.OrderSearchFilter, fn func(order *domain.Order) apperrors.ApplicationError) apperrors.ApplicationError {
+	if filter.Sort == "" {
+		filter.Sort = domain.OrderSortCreatedAt
+	}
+	spec, ok := sortSpecs[filter.Sort]
+	if !ok {
+		return apperrors.NewApplicationError(mappings.OrderInvalidSearchError, fmt.Errorf("unknown sort field %q", filter.Sort))
+	}
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+
+	var args queryArgs
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		` + whereClause(searchConditions(filter, &args)) + `
+		ORDER BY ` + spec.expr + ` ` + direction + `, id ` + direction
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return apperrors.NewApplicationError(mappings.InternalServerError, err)
+		}
+		if appErr := fn(order); appErr != nil {
+			return appErr
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// GetStatusEvents retrieves the status history of an order, oldest first
+func (r *repository) GetStatusEvents(ctx context.Context, orderID string) ([]*domain.OrderStatusEvent, apperrors.ApplicationError) {
+	events, appErr := r.GetStatusEventsByOrderIDs(ctx, []string{orderID})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return events[orderID], nil
+}
+
+// GetStatusEventsByOrderIDs retrieves the status history of several orders, grouped by order ID
+func (r *repository) GetStatusEventsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]*domain.OrderStatusEvent, apperrors.ApplicationError) {
+	result := make(map[string][]*domain.OrderStatusEvent, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT id, order_id, previous_status, new_status, actor_user_id, message, created_at
+		FROM order_status_events
+		WHERE order_id = ANY($1)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderStatusEventListError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.OrderStatusEvent
+		var previousStatus sql.NullString
+		var actorUserID sql.NullString
+		var message sql.NullString
+		if err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&previousStatus,
+			&event.NewStatus,
+			&actorUserID,
+			&message,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderStatusEventListError, err)
+		}
+		if previousStatus.Valid {
+			status := domain.OrderStatus(previousStatus.String)
+			event.PreviousStatus = &status
+		}
+		if actorUserID.Valid {
+			event.ActorUserID = &actorUserID.String
+		}
+		if message.Valid {
+			event.Message = &message.String
+		}
+		result[event.OrderID] = append(result[event.OrderID], &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderStatusEventListError, err)
+	}
+
+	return result, nil
+}
+
+// insertStatusEvent writes a status change within the caller's transaction
+func insertStatusEvent(ctx context.Context, tx *sql.Tx, event *domain.OrderStatusEvent) apperrors.ApplicationError {
+	event.ID = uuid.New().String()
+
+	query := `
+		INSERT INTO order_status_events (id, order_id, previous_status, new_status, actor_user_id, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		event.ID,
+		event.OrderID,
+		event.PreviousStatus,
+		event.NewStatus,
+		event.ActorUserID,
+		event.Message,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderStatusEventCreateError, err)
+	}
+
+	return nil
+}
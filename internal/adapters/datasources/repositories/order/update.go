@@ -3,6 +3,8 @@ package order
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"sort"
 	"time"
 
 	"yego/internal/domain"
@@ -10,44 +12,137 @@ import (
 	"yego/internal/platform/errors/mappings"
 )
 
-// UpdateStatus updates the status of an order
-func (r *repository) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, apperrors.ApplicationError) {
+// UpdateStatus updates the status of an order and records the change in its history.
+// The write only succeeds if expectedVersion still matches the stored version; nil
+// applies it on top of the latest version, for changes that already happened, such as a payment.
+func (r *repository) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus, expectedVersion *int, actorUserID *string) (*domain.Order, apperrors.ApplicationError) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+	defer tx.Rollback()
+
+	previousStatus, currentVersion, appErr := lockOrder(ctx, tx, id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if expectedVersion != nil && currentVersion != *expectedVersion {
+		return nil, apperrors.NewApplicationError(mappings.OrderVersionConflictError,
+			&domain.OrderVersionConflict{OrderID: id, Expected: *expectedVersion, Found: currentVersion})
+	}
+
+	now := time.Now()
 	query := `
 		UPDATE orders
-		SET status = $1, updated_at = $2
+		SET status = $1, updated_at = $2, version = version + 1
 		WHERE id = $3
 	`
 
-	result, err := r.db.ExecContext(ctx, query, status, time.Now(), id)
+	if _, err := tx.ExecContext(ctx, query, status, now, id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	if previousStatus != status {
+		event := &domain.OrderStatusEvent{
+			OrderID:        id,
+			PreviousStatus: &previousStatus,
+			NewStatus:      status,
+			ActorUserID:    actorUserID,
+			CreatedAt:      now,
+		}
+		if appErr := insertStatusEvent(ctx, tx, event); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// Update updates an order (status, eta, data, etc.) and records status changes in its history.
+// The write only succeeds if order.Version still matches the stored version.
+func (r *repository) Update(ctx context.Context, order *domain.Order, actorUserID *string) (*domain.Order, apperrors.ApplicationError) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	if appErr := updateOrder(ctx, tx, order, actorUserID, time.Now()); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	return r.GetByID(ctx, order.ID)
+}
+
+// UpdateMany updates several orders in a single transaction, with the same checks
+// as Update: either every order is written or none is. Rows are locked in ID order
+// so concurrent bulk updates can't deadlock each other.
+func (r *repository) UpdateMany(ctx context.Context, orders []*domain.Order, actorUserID *string) ([]*domain.Order, apperrors.ApplicationError) {
+	sorted := make([]*domain.Order, len(orders))
+	copy(sorted, orders)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
 	}
+	defer tx.Rollback()
 
-	if rowsAffected == 0 {
-		return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, nil)
+	now := time.Now()
+	for _, order := range sorted {
+		if appErr := updateOrder(ctx, tx, order, actorUserID, now); appErr != nil {
+			return nil, appErr
+		}
 	}
 
-	return r.GetByID(ctx, id)
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	updated := make([]*domain.Order, 0, len(orders))
+	for _, order := range orders {
+		stored, appErr := r.GetByID(ctx, order.ID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		updated = append(updated, stored)
+	}
+	return updated, nil
 }
 
-// Update updates an order (status, eta, data, etc.)
-func (r *repository) Update(ctx context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError) {
-	order.UpdatedAt = time.Now()
+// updateOrder writes an order within tx, provided its version is still current
+func updateOrder(ctx context.Context, tx *sql.Tx, order *domain.Order, actorUserID *string, now time.Time) apperrors.ApplicationError {
+	order.UpdatedAt = now
 
 	dataJSON, err := order.DataJSON()
 	if err != nil {
-		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	previousStatus, currentVersion, appErr := lockOrder(ctx, tx, order.ID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if currentVersion != order.Version {
+		return apperrors.NewApplicationError(mappings.OrderVersionConflictError,
+			&domain.OrderVersionConflict{OrderID: order.ID, Expected: order.Version, Found: currentVersion})
 	}
 
 	query := `
 		UPDATE orders
-		SET status = $1, status_message = $2, eta = $3, data = $4, updated_at = $5
-		WHERE id = $6
+		SET status = $1, status_message = $2, eta = $3, data = $4, updated_at = $5, version = version + 1,
+			paused_from_status = $6, pause_reason = $7, resume_at = $8, eta_from = $9, eta_to = $10, currency = $11
+		WHERE id = $12 AND version = $13
 	`
 
 	var statusMessage sql.NullString
@@ -55,19 +150,66 @@ func (r *repository) Update(ctx context.Context, order *domain.Order) (*domain.O
 		statusMessage = sql.NullString{String: *order.StatusMessage, Valid: true}
 	}
 
-	result, err := r.db.ExecContext(ctx, query, order.Status, statusMessage, order.ETA, dataJSON, order.UpdatedAt, order.ID)
+	if _, err := tx.ExecContext(ctx, query,
+		order.Status, statusMessage, order.ETA, dataJSON, order.UpdatedAt,
+		order.PausedFromStatus, order.PauseReason, order.ResumeAt, order.ETAFrom, order.ETATo, order.Currency,
+		order.ID, order.Version,
+	); err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	if previousStatus != order.Status {
+		event := &domain.OrderStatusEvent{
+			OrderID:        order.ID,
+			PreviousStatus: &previousStatus,
+			NewStatus:      order.Status,
+			ActorUserID:    actorUserID,
+			Message:        order.StatusMessage,
+			CreatedAt:      order.UpdatedAt,
+		}
+		if appErr := insertStatusEvent(ctx, tx, event); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+// lockOrder locks the order row for the rest of the transaction and returns its current status and version
+func lockOrder(ctx context.Context, tx *sql.Tx, id string) (domain.OrderStatus, int, apperrors.ApplicationError) {
+	var status domain.OrderStatus
+	var version int
+	err := tx.QueryRowContext(ctx, `SELECT status, version FROM orders WHERE id = $1 FOR UPDATE`, id).Scan(&status, &version)
 	if err != nil {
-		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, apperrors.NewApplicationError(mappings.OrderNotFoundError, err)
+		}
+		return "", 0, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
 	}
+	return status, version, nil
+}
 
-	rowsAffected, err := result.RowsAffected()
+// SetPricing stores the priced snapshot of an order. The snapshot is derived from
+// the order's items, so it doesn't bump the version editors compare against.
+func (r *repository) SetPricing(ctx context.Context, orderID string, pricing *domain.OrderPricing) apperrors.ApplicationError {
+	order := domain.Order{Pricing: pricing}
+	pricingJSON, err := order.PricingJSON()
 	if err != nil {
-		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
 	}
 
+	result, err := r.db.ExecContext(ctx, `UPDATE orders SET pricing = $1 WHERE id = $2`, pricingJSON, orderID)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
 	if rowsAffected == 0 {
-		return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, nil)
+		return apperrors.NewApplicationError(mappings.OrderNotFoundError, errors.New("order not found"))
 	}
 
-	return r.GetByID(ctx, order.ID)
+	return nil
 }
@@ -0,0 +1,228 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const uniqueViolation = "23505"
+
+// promotionColumns is the column list every promotion query selects, in scanPromotion order
+const promotionColumns = `p.id, p.code, p.description, p.kind, p.percent_off, p.amount_off, p.buy_code,
+		p.buy_quantity, p.get_quantity, p.min_order_amount, p.currency, p.starts_at, p.ends_at,
+		p.max_uses_per_customer, p.active, p.created_at, p.updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// Create inserts a new promotion
+func (r *repository) Create(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, apperrors.ApplicationError) {
+	promotion.ID = uuid.New().String()
+	promotion.CreatedAt = time.Now()
+	promotion.UpdatedAt = promotion.CreatedAt
+
+	query := `
+		INSERT INTO promotions (id, code, description, kind, percent_off, amount_off, buy_code, buy_quantity,
+			get_quantity, min_order_amount, currency, starts_at, ends_at, max_uses_per_customer, active,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		promotion.ID,
+		promotion.Code,
+		promotion.Description,
+		promotion.Kind,
+		promotion.PercentOff,
+		promotion.AmountOff,
+		promotion.BuyCode,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.MinOrderAmount,
+		promotion.Currency,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.MaxUsesPerCustomer,
+		promotion.Active,
+		promotion.CreatedAt,
+		promotion.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, apperrors.NewApplicationError(mappings.PromotionCodeTakenError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.PromotionCreateError, err)
+	}
+
+	return promotion, nil
+}
+
+// GetByID retrieves a promotion by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Promotion, apperrors.ApplicationError) {
+	return r.getOne(ctx, `SELECT `+promotionColumns+` FROM promotions p WHERE p.id = $1`, id)
+}
+
+// GetByCode retrieves a promotion by its normalized coupon code
+func (r *repository) GetByCode(ctx context.Context, code string) (*domain.Promotion, apperrors.ApplicationError) {
+	return r.getOne(ctx, `SELECT `+promotionColumns+` FROM promotions p WHERE p.code = $1`, code)
+}
+
+func (r *repository) getOne(ctx context.Context, query string, arg any) (*domain.Promotion, apperrors.ApplicationError) {
+	promotion, err := scanPromotion(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.PromotionNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.PromotionGetError, err)
+	}
+	return promotion, nil
+}
+
+// List retrieves every promotion, newest first
+func (r *repository) List(ctx context.Context) ([]*domain.Promotion, apperrors.ApplicationError) {
+	return r.queryPromotions(ctx, `SELECT `+promotionColumns+` FROM promotions p ORDER BY p.created_at DESC`)
+}
+
+// Update saves every editable field of a promotion
+func (r *repository) Update(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, apperrors.ApplicationError) {
+	promotion.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE promotions
+		SET code = $1, description = $2, kind = $3, percent_off = $4, amount_off = $5, buy_code = $6,
+			buy_quantity = $7, get_quantity = $8, min_order_amount = $9, currency = $10, starts_at = $11,
+			ends_at = $12, max_uses_per_customer = $13, active = $14, updated_at = $15
+		WHERE id = $16
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		promotion.Code,
+		promotion.Description,
+		promotion.Kind,
+		promotion.PercentOff,
+		promotion.AmountOff,
+		promotion.BuyCode,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.MinOrderAmount,
+		promotion.Currency,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.MaxUsesPerCustomer,
+		promotion.Active,
+		promotion.UpdatedAt,
+		promotion.ID,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return nil, apperrors.NewApplicationError(mappings.PromotionCodeTakenError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.PromotionUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.PromotionUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.NewApplicationError(mappings.PromotionNotFoundError, nil)
+	}
+
+	return promotion, nil
+}
+
+// Delete removes a promotion together with its redemptions
+func (r *repository) Delete(ctx context.Context, id string) apperrors.ApplicationError {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM promotions WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionDeleteError, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionDeleteError, err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.PromotionNotFoundError, nil)
+	}
+	return nil
+}
+
+// queryPromotions runs a query selecting promotionColumns and scans every row
+func (r *repository) queryPromotions(ctx context.Context, query string, args ...any) ([]*domain.Promotion, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.PromotionGetError, err)
+	}
+	defer rows.Close()
+
+	promotions := []*domain.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.PromotionGetError, err)
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.PromotionGetError, err)
+	}
+
+	return promotions, nil
+}
+
+// scanPromotion reads a row selected with promotionColumns
+func scanPromotion(row scanner) (*domain.Promotion, error) {
+	var promotion domain.Promotion
+	var buyCode sql.NullString
+	var startsAt sql.NullTime
+	var endsAt sql.NullTime
+	var maxUses sql.NullInt64
+
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Code,
+		&promotion.Description,
+		&promotion.Kind,
+		&promotion.PercentOff,
+		&promotion.AmountOff,
+		&buyCode,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.MinOrderAmount,
+		&promotion.Currency,
+		&startsAt,
+		&endsAt,
+		&maxUses,
+		&promotion.Active,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if buyCode.Valid {
+		promotion.BuyCode = &buyCode.String
+	}
+	if startsAt.Valid {
+		promotion.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		promotion.EndsAt = &endsAt.Time
+	}
+	if maxUses.Valid {
+		limit := int(maxUses.Int64)
+		promotion.MaxUsesPerCustomer = &limit
+	}
+	return &promotion, nil
+}
@@ -0,0 +1,100 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Redeem records a promotion redeemed on an order. With a per-customer limit the
+// promotion row is locked while the customer's redemptions are counted, so two
+// concurrent redemptions can't both take the last use. Redemptions on cancelled
+// orders don't count.
+func (r *repository) Redeem(ctx context.Context, redemption *domain.PromotionRedemption, maxUsesPerCustomer *int) apperrors.ApplicationError {
+	redemption.ID = uuid.New().String()
+	redemption.CreatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+	}
+	defer tx.Rollback()
+
+	if maxUsesPerCustomer != nil {
+		var locked string
+		if err := tx.QueryRowContext(ctx, `SELECT id FROM promotions WHERE id = $1 FOR UPDATE`, redemption.PromotionID).Scan(&locked); err != nil {
+			return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+		}
+
+		var used int
+		countQuery := `
+			SELECT COUNT(*)
+			FROM promotion_redemptions pr
+			JOIN orders o ON o.id = pr.order_id
+			WHERE pr.promotion_id = $1 AND pr.user_id = $2 AND o.status <> $3
+		`
+		if err := tx.QueryRowContext(ctx, countQuery, redemption.PromotionID, redemption.UserID, domain.StatusCancelled).Scan(&used); err != nil {
+			return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+		}
+		if used >= *maxUsesPerCustomer {
+			return apperrors.NewApplicationError(mappings.PromotionUsageLimitError,
+				fmt.Errorf("user %s already redeemed promotion %s %d times", redemption.UserID, redemption.PromotionID, used))
+		}
+	}
+
+	query := `
+		INSERT INTO promotion_redemptions (id, promotion_id, order_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := tx.ExecContext(ctx, query,
+		redemption.ID,
+		redemption.PromotionID,
+		redemption.OrderID,
+		redemption.UserID,
+		redemption.CreatedAt,
+	); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return apperrors.NewApplicationError(mappings.PromotionAlreadyRedeemedError, err)
+		}
+		return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+	}
+	return nil
+}
+
+// RemoveRedemption takes a redeemed promotion off an order
+func (r *repository) RemoveRedemption(ctx context.Context, promotionID string, orderID string) apperrors.ApplicationError {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM promotion_redemptions WHERE promotion_id = $1 AND order_id = $2`, promotionID, orderID)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionRedeemError, err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.PromotionNotRedeemedError, nil)
+	}
+	return nil
+}
+
+// ListByOrderID retrieves the promotions redeemed on an order, in redemption order
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.Promotion, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + promotionColumns + `
+		FROM promotion_redemptions pr
+		JOIN promotions p ON p.id = pr.promotion_id
+		WHERE pr.order_id = $1
+		ORDER BY pr.created_at ASC
+	`
+	return r.queryPromotions(ctx, query, orderID)
+}
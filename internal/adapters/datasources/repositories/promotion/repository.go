@@ -0,0 +1,31 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for promotion and coupon redemption operations
+type Repository interface {
+	Create(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.Promotion, apperrors.ApplicationError)
+	GetByCode(ctx context.Context, code string) (*domain.Promotion, apperrors.ApplicationError)
+	List(ctx context.Context) ([]*domain.Promotion, apperrors.ApplicationError)
+	Update(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, apperrors.ApplicationError)
+	Delete(ctx context.Context, id string) apperrors.ApplicationError
+	Redeem(ctx context.Context, redemption *domain.PromotionRedemption, maxUsesPerCustomer *int) apperrors.ApplicationError
+	RemoveRedemption(ctx context.Context, promotionID string, orderID string) apperrors.ApplicationError
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.Promotion, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new promotion repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
@@ -2,21 +2,35 @@ package repositories
 
 import (
 	"yego/internal/adapters/datasources"
+	"yego/internal/adapters/datasources/repositories/attachment"
+	"yego/internal/adapters/datasources/repositories/cart"
+	"yego/internal/adapters/datasources/repositories/deliverycode"
+	"yego/internal/adapters/datasources/repositories/deliveryslot"
 	"yego/internal/adapters/datasources/repositories/importrecord"
+	"yego/internal/adapters/datasources/repositories/modificationrequest"
 	"yego/internal/adapters/datasources/repositories/order"
 	"yego/internal/adapters/datasources/repositories/ordertoken"
 	"yego/internal/adapters/datasources/repositories/profile"
+	"yego/internal/adapters/datasources/repositories/promotion"
 	"yego/internal/adapters/datasources/repositories/settings"
+	"yego/internal/adapters/datasources/repositories/subscription"
 	"yego/internal/adapters/datasources/repositories/transaction"
 )
 
 type Repositories struct {
-	ImportRecord importrecord.Repository
-	Order        order.Repository
-	OrderToken   ordertoken.Repository
-	Profile      profile.Repository
-	Settings     settings.Repository
-	Transaction  transaction.Repository
+	Attachment          attachment.Repository
+	Cart                cart.Repository
+	DeliveryCode        deliverycode.Repository
+	DeliverySlot        deliveryslot.Repository
+	ImportRecord        importrecord.Repository
+	ModificationRequest modificationrequest.Repository
+	Order               order.Repository
+	OrderToken          ordertoken.Repository
+	Profile             profile.Repository
+	Promotion           promotion.Repository
+	Settings            settings.Repository
+	Subscription        subscription.Repository
+	Transaction         transaction.Repository
 }
 
 type Factory func() *Repositories
@@ -24,12 +38,19 @@ type Factory func() *Repositories
 func NewFactory(datasources *datasources.Datasources) func() *Repositories {
 	return func() *Repositories {
 		return &Repositories{
-			ImportRecord: importrecord.NewRepository(datasources.DB),
-			Order:        order.NewRepository(datasources.DB),
-			OrderToken:   ordertoken.NewRepository(datasources.DB),
-			Profile:      profile.NewRepository(datasources.DB),
-			Settings:     settings.NewRepository(datasources.DB),
-			Transaction:  transaction.NewRepository(datasources.DB),
+			Attachment:          attachment.NewRepository(datasources.DB),
+			Cart:                cart.NewRepository(datasources.DB),
+			DeliveryCode:        deliverycode.NewRepository(datasources.DB),
+			DeliverySlot:        deliveryslot.NewRepository(datasources.DB),
+			ImportRecord:        importrecord.NewRepository(datasources.DB),
+			ModificationRequest: modificationrequest.NewRepository(datasources.DB),
+			Order:               order.NewRepository(datasources.DB),
+			OrderToken:          ordertoken.NewRepository(datasources.DB),
+			Profile:             profile.NewRepository(datasources.DB),
+			Promotion:           promotion.NewRepository(datasources.DB),
+			Settings:            settings.NewRepository(datasources.DB),
+			Subscription:        subscription.NewRepository(datasources.DB),
+			Transaction:         transaction.NewRepository(datasources.DB),
 		}
 	}
 }
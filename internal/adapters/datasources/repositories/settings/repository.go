@@ -3,6 +3,7 @@ package settings
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"yego/internal/domain"
@@ -33,23 +34,40 @@ func (r *repository) Get(ctx context.Context) (*domain.Settings, apperrors.Appli
 		SELECT id, business_name, business_latitude, business_longitude,
 			   default_map_latitude, default_map_longitude, default_map_zoom,
 			   default_item_weight, delivery_base_price, delivery_price_per_km,
-			   delivery_price_per_kg, manager_collector_id, created_at, updated_at
+			   delivery_price_per_kg, manager_collector_id, timezone, delivery_slots,
+			   default_currency, tax_rates, currency_delivery_prices, created_at, updated_at
 		FROM settings
 		LIMIT 1
 	`
 
 	var s domain.Settings
 	var managerCollectorID sql.NullString
+	var deliverySlotsJSON []byte
+	var taxRatesJSON []byte
+	var currencyDeliveryPricesJSON []byte
 	err := r.db.QueryRowContext(ctx, query).Scan(
 		&s.ID, &s.BusinessName, &s.BusinessLatitude, &s.BusinessLongitude,
 		&s.DefaultMapLatitude, &s.DefaultMapLongitude, &s.DefaultMapZoom,
 		&s.DefaultItemWeight, &s.DeliveryBasePrice, &s.DeliveryPricePerKm,
-		&s.DeliveryPricePerKg, &managerCollectorID, &s.CreatedAt, &s.UpdatedAt,
+		&s.DeliveryPricePerKg, &managerCollectorID, &s.Timezone, &deliverySlotsJSON,
+		&s.DefaultCurrency, &taxRatesJSON, &currencyDeliveryPricesJSON, &s.CreatedAt, &s.UpdatedAt,
 	)
 
 	if err == nil && managerCollectorID.Valid {
 		s.ManagerCollectorID = &managerCollectorID.String
 	}
+	if err == nil {
+		if jsonErr := json.Unmarshal(deliverySlotsJSON, &s.DeliverySlots); jsonErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.SettingsGetError, jsonErr)
+		}
+		s.Tax = domain.DefaultTaxSettings()
+		if jsonErr := json.Unmarshal(taxRatesJSON, &s.Tax); jsonErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.SettingsGetError, jsonErr)
+		}
+		if jsonErr := json.Unmarshal(currencyDeliveryPricesJSON, &s.CurrencyDeliveryPrices); jsonErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.SettingsGetError, jsonErr)
+		}
+	}
 
 	if err == sql.ErrNoRows {
 		// Return default settings if none exist
@@ -62,9 +80,15 @@ func (r *repository) Get(ctx context.Context) (*domain.Settings, apperrors.Appli
 			DefaultMapLongitude: -58.3816,
 			DefaultMapZoom:      13,
 			DefaultItemWeight:   500, // 500g default
-			DeliveryBasePrice:   500,
-			DeliveryPricePerKm:  200,
-			DeliveryPricePerKg:  100,
+			DeliveryBasePrice:   domain.MoneyFromUnits(500),
+			DeliveryPricePerKm:  domain.MoneyFromUnits(200),
+			DeliveryPricePerKg:  domain.MoneyFromUnits(100),
+			Timezone:            domain.DefaultTimezone,
+			DeliverySlots:       []domain.DeliverySlotDefinition{},
+			DefaultCurrency:     domain.DefaultCurrency,
+			Tax:                 domain.DefaultTaxSettings(),
+
+			CurrencyDeliveryPrices: map[string]domain.DeliveryPrices{},
 		}, nil
 	}
 
@@ -82,6 +106,34 @@ func (r *repository) Upsert(ctx context.Context, settings *domain.Settings) (*do
 	// Check if settings exist
 	existing, _ := r.Get(ctx)
 
+	if settings.Timezone == "" {
+		settings.Timezone = domain.DefaultTimezone
+	}
+	if settings.DefaultCurrency == "" {
+		settings.DefaultCurrency = domain.DefaultCurrency
+	}
+	if settings.DeliverySlots == nil {
+		settings.DeliverySlots = []domain.DeliverySlotDefinition{}
+	}
+	deliverySlotsJSON, err := json.Marshal(settings.DeliverySlots)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SettingsUpdateError, err)
+	}
+	if settings.Tax.CategoryRates == nil {
+		settings.Tax.CategoryRates = map[string]float64{}
+	}
+	taxRatesJSON, err := json.Marshal(settings.Tax)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SettingsUpdateError, err)
+	}
+	if settings.CurrencyDeliveryPrices == nil {
+		settings.CurrencyDeliveryPrices = map[string]domain.DeliveryPrices{}
+	}
+	currencyDeliveryPricesJSON, err := json.Marshal(settings.CurrencyDeliveryPrices)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SettingsUpdateError, err)
+	}
+
 	if existing.ID == "" {
 		// Create new settings
 		settings.ID = uuid.New().String()
@@ -93,15 +145,17 @@ func (r *repository) Upsert(ctx context.Context, settings *domain.Settings) (*do
 				id, business_name, business_latitude, business_longitude,
 				default_map_latitude, default_map_longitude, default_map_zoom,
 				default_item_weight, delivery_base_price, delivery_price_per_km,
-				delivery_price_per_kg, manager_collector_id, created_at, updated_at
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+				delivery_price_per_kg, manager_collector_id, timezone, delivery_slots,
+				default_currency, tax_rates, currency_delivery_prices, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		`
 
-		_, err := r.db.ExecContext(ctx, query,
+		_, err = r.db.ExecContext(ctx, query,
 			settings.ID, settings.BusinessName, settings.BusinessLatitude, settings.BusinessLongitude,
 			settings.DefaultMapLatitude, settings.DefaultMapLongitude, settings.DefaultMapZoom,
 			settings.DefaultItemWeight, settings.DeliveryBasePrice, settings.DeliveryPricePerKm,
-			settings.DeliveryPricePerKg, settings.ManagerCollectorID, settings.CreatedAt, settings.UpdatedAt,
+			settings.DeliveryPricePerKg, settings.ManagerCollectorID, settings.Timezone, deliverySlotsJSON,
+			settings.DefaultCurrency, taxRatesJSON, currencyDeliveryPricesJSON, settings.CreatedAt, settings.UpdatedAt,
 		)
 
 		if err != nil {
@@ -118,15 +172,19 @@ func (r *repository) Upsert(ctx context.Context, settings *domain.Settings) (*do
 				business_name = $1, business_latitude = $2, business_longitude = $3,
 				default_map_latitude = $4, default_map_longitude = $5, default_map_zoom = $6,
 				default_item_weight = $7, delivery_base_price = $8, delivery_price_per_km = $9,
-				delivery_price_per_kg = $10, manager_collector_id = $11, updated_at = $12
-			WHERE id = $13
+				delivery_price_per_kg = $10, manager_collector_id = $11, timezone = $12,
+				delivery_slots = $13, default_currency = $14, tax_rates = $15,
+				currency_delivery_prices = $16, updated_at = $17
+			WHERE id = $18
 		`
 
-		_, err := r.db.ExecContext(ctx, query,
+		_, err = r.db.ExecContext(ctx, query,
 			settings.BusinessName, settings.BusinessLatitude, settings.BusinessLongitude,
 			settings.DefaultMapLatitude, settings.DefaultMapLongitude, settings.DefaultMapZoom,
 			settings.DefaultItemWeight, settings.DeliveryBasePrice, settings.DeliveryPricePerKm,
-			settings.DeliveryPricePerKg, settings.ManagerCollectorID, settings.UpdatedAt, settings.ID,
+			settings.DeliveryPricePerKg, settings.ManagerCollectorID, settings.Timezone,
+			deliverySlotsJSON, settings.DefaultCurrency, taxRatesJSON, currencyDeliveryPricesJSON,
+			settings.UpdatedAt, settings.ID,
 		)
 
 		if err != nil {
@@ -0,0 +1,48 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Create inserts a new active subscription
+func (r *repository) Create(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError) {
+	subscription.ID = uuid.New().String()
+	subscription.Status = domain.SubscriptionActive
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = subscription.CreatedAt
+
+	dataJSON, err := subscription.DataJSON()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionCreateError, err)
+	}
+
+	query := `
+		INSERT INTO subscriptions (id, user_id, profile_id, data, eta, frequency, interval_count, status, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.UserID,
+		subscription.ProfileID,
+		dataJSON,
+		subscription.ETA,
+		subscription.Recurrence.Frequency,
+		subscription.Recurrence.Interval,
+		subscription.Status,
+		subscription.NextRunAt,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionCreateError, err)
+	}
+
+	return subscription, nil
+}
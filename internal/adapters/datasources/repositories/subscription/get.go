@@ -0,0 +1,119 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const selectColumns = `
+	SELECT id, user_id, profile_id, data, eta, frequency, interval_count, status, next_run_at,
+		   last_run_at, last_order_id, last_error, created_at, updated_at, cancelled_at
+	FROM subscriptions
+`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// GetByID retrieves a subscription by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Subscription, apperrors.ApplicationError) {
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, selectColumns+` WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionGetError, err)
+	}
+	return subscription, nil
+}
+
+// ListByUserID retrieves the subscriptions of a user, newest first
+func (r *repository) ListByUserID(ctx context.Context, userID string) ([]*domain.Subscription, apperrors.ApplicationError) {
+	return r.list(ctx, selectColumns+` WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+}
+
+// ListDue retrieves the active subscriptions whose next run is at or before now
+func (r *repository) ListDue(ctx context.Context, now time.Time) ([]*domain.Subscription, apperrors.ApplicationError) {
+	return r.list(ctx, selectColumns+` WHERE status = $1 AND next_run_at <= $2 ORDER BY next_run_at`,
+		domain.SubscriptionActive, now)
+}
+
+func (r *repository) list(ctx context.Context, query string, args ...any) ([]*domain.Subscription, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionGetError, err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*domain.Subscription, 0)
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionGetError, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionGetError, err)
+	}
+
+	return subscriptions, nil
+}
+
+func scanSubscription(row scanner) (*domain.Subscription, error) {
+	var subscription domain.Subscription
+	var dataJSON []byte
+	var lastRunAt sql.NullTime
+	var lastOrderID sql.NullString
+	var lastError sql.NullString
+	var cancelledAt sql.NullTime
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.ProfileID,
+		&dataJSON,
+		&subscription.ETA,
+		&subscription.Recurrence.Frequency,
+		&subscription.Recurrence.Interval,
+		&subscription.Status,
+		&subscription.NextRunAt,
+		&lastRunAt,
+		&lastOrderID,
+		&lastError,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+		&cancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var data domain.OrderData
+	if err := json.Unmarshal(dataJSON, &data); err != nil {
+		return nil, err
+	}
+	subscription.Data = &data
+	if lastRunAt.Valid {
+		subscription.LastRunAt = &lastRunAt.Time
+	}
+	if lastOrderID.Valid {
+		subscription.LastOrderID = &lastOrderID.String
+	}
+	if lastError.Valid {
+		subscription.LastError = &lastError.String
+	}
+	if cancelledAt.Valid {
+		subscription.CancelledAt = &cancelledAt.Time
+	}
+
+	return &subscription, nil
+}
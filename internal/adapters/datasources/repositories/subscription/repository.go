@@ -0,0 +1,30 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+)
+
+// Repository defines the interface for recurring subscription operations
+type Repository interface {
+	Create(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.Subscription, apperrors.ApplicationError)
+	ListByUserID(ctx context.Context, userID string) ([]*domain.Subscription, apperrors.ApplicationError)
+	ListDue(ctx context.Context, now time.Time) ([]*domain.Subscription, apperrors.ApplicationError)
+	Update(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError)
+	ClaimRun(ctx context.Context, subscription *domain.Subscription, nextRunAt time.Time, now time.Time) (bool, apperrors.ApplicationError)
+	RecordRun(ctx context.Context, id string, orderID *string, runErr *string) apperrors.ApplicationError
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new subscription repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
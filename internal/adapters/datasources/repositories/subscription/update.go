@@ -0,0 +1,102 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// lastErrorMaxLength matches the last_error column size
+const lastErrorMaxLength = 500
+
+// Update saves the status, schedule and template of a subscription
+func (r *repository) Update(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError) {
+	subscription.UpdatedAt = time.Now()
+
+	dataJSON, err := subscription.DataJSON()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+
+	query := `
+		UPDATE subscriptions
+		SET data = $1, eta = $2, frequency = $3, interval_count = $4, status = $5, next_run_at = $6,
+			cancelled_at = $7, updated_at = $8
+		WHERE id = $9
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		dataJSON,
+		subscription.ETA,
+		subscription.Recurrence.Frequency,
+		subscription.Recurrence.Interval,
+		subscription.Status,
+		subscription.NextRunAt,
+		subscription.CancelledAt,
+		subscription.UpdatedAt,
+		subscription.ID,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionNotFoundError, nil)
+	}
+
+	return subscription, nil
+}
+
+// ClaimRun advances a due subscription to nextRunAt, but only if it is still active and
+// its next run has not moved since it was read. It reports whether this caller won the
+// run, so concurrent schedulers never materialize the same occurrence twice.
+func (r *repository) ClaimRun(ctx context.Context, subscription *domain.Subscription, nextRunAt time.Time, now time.Time) (bool, apperrors.ApplicationError) {
+	query := `
+		UPDATE subscriptions
+		SET next_run_at = $1, last_run_at = $2, updated_at = $2
+		WHERE id = $3 AND status = $4 AND next_run_at = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query, nextRunAt, now, subscription.ID, domain.SubscriptionActive, subscription.NextRunAt)
+	if err != nil {
+		return false, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	subscription.NextRunAt = nextRunAt
+	subscription.LastRunAt = &now
+	return true, nil
+}
+
+// RecordRun stores the outcome of the last run: the order it created and/or the error it hit
+func (r *repository) RecordRun(ctx context.Context, id string, orderID *string, runErr *string) apperrors.ApplicationError {
+	if runErr != nil && len(*runErr) > lastErrorMaxLength {
+		truncated := (*runErr)[:lastErrorMaxLength]
+		runErr = &truncated
+	}
+
+	query := `
+		UPDATE subscriptions
+		SET last_order_id = COALESCE($1, last_order_id), last_error = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, runErr, time.Now(), id); err != nil {
+		return apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+	return nil
+}
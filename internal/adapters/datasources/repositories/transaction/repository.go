@@ -17,9 +17,11 @@ type Repository interface {
 	Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, apperrors.ApplicationError)
 	GetByID(ctx context.Context, id string) (*domain.Transaction, apperrors.ApplicationError)
 	GetByOrderID(ctx context.Context, orderID string) (*domain.Transaction, apperrors.ApplicationError)
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.Transaction, apperrors.ApplicationError)
 	ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError)
 	GetAll(ctx context.Context, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError)
 	Count(ctx context.Context) (int, apperrors.ApplicationError)
+	Stream(ctx context.Context, fn func(transaction *domain.Transaction) apperrors.ApplicationError) apperrors.ApplicationError
 }
 
 type repository struct {
@@ -40,20 +42,24 @@ func (r *repository) Create(ctx context.Context, transaction *domain.Transaction
 		transaction.CreatedAt = time.Now()
 	}
 	transaction.UpdatedAt = time.Now()
+	if transaction.Kind == "" {
+		transaction.Kind = domain.TransactionKindPayment
+	}
 
 	query := `
 		INSERT INTO transactions (
 			id, order_id, user_id, profile_id, amount, currency, status,
 			payment_id, gateway_payment_id, collector_id, description,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			net_amount, tax_amount, kind, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		transaction.ID, transaction.OrderID, transaction.UserID, transaction.ProfileID,
 		transaction.Amount, transaction.Currency, transaction.Status,
 		transaction.PaymentID, transaction.GatewayPaymentID, transaction.CollectorID,
-		transaction.Description, transaction.CreatedAt, transaction.UpdatedAt,
+		transaction.Description, transaction.NetAmount, transaction.TaxAmount,
+		transaction.Kind, transaction.CreatedAt, transaction.UpdatedAt,
 	)
 
 	if err != nil {
@@ -68,7 +74,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*domain.Transactio
 	query := `
 		SELECT id, order_id, user_id, profile_id, amount, currency, status,
 			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
+			   net_amount, tax_amount, kind, created_at, updated_at
 		FROM transactions
 		WHERE id = $1
 	`
@@ -84,7 +90,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*domain.Transactio
 		&t.ID, &t.OrderID, &t.UserID, &profileID,
 		&t.Amount, &t.Currency, &t.Status,
 		&paymentID, &gatewayPaymentID, &collectorID, &description,
-		&t.CreatedAt, &t.UpdatedAt,
+		&t.NetAmount, &t.TaxAmount, &t.Kind, &t.CreatedAt, &t.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -120,7 +126,7 @@ func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.
 	query := `
 		SELECT id, order_id, user_id, profile_id, amount, currency, status,
 			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
+			   net_amount, tax_amount, kind, created_at, updated_at
 		FROM transactions
 		WHERE order_id = $1
 		ORDER BY created_at DESC
@@ -138,7 +144,7 @@ func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.
 		&t.ID, &t.OrderID, &t.UserID, &profileID,
 		&t.Amount, &t.Currency, &t.Status,
 		&paymentID, &gatewayPaymentID, &collectorID, &description,
-		&t.CreatedAt, &t.UpdatedAt,
+		&t.NetAmount, &t.TaxAmount, &t.Kind, &t.CreatedAt, &t.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -169,19 +175,18 @@ func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.
 	return &t, nil
 }
 
-// ListByUserID retrieves transactions for a user
-func (r *repository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError) {
+// ListByOrderID retrieves all transactions of an order, oldest first
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.Transaction, apperrors.ApplicationError) {
 	query := `
 		SELECT id, order_id, user_id, profile_id, amount, currency, status,
 			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
+			   net_amount, tax_amount, kind, created_at, updated_at
 		FROM transactions
-		WHERE user_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		WHERE order_id = $1
+		ORDER BY created_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, orderID)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
 	}
@@ -200,7 +205,7 @@ func (r *repository) ListByUserID(ctx context.Context, userID string, limit, off
 			&t.ID, &t.OrderID, &t.UserID, &profileID,
 			&t.Amount, &t.Currency, &t.Status,
 			&paymentID, &gatewayPaymentID, &collectorID, &description,
-			&t.CreatedAt, &t.UpdatedAt,
+			&t.NetAmount, &t.TaxAmount, &t.Kind, &t.CreatedAt, &t.UpdatedAt,
 		)
 		if err != nil {
 			return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
@@ -233,25 +238,19 @@ func (r *repository) ListByUserID(ctx context.Context, userID string, limit, off
 	return transactions, nil
 }
 
-// GetAll retrieves all transactions
-func (r *repository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError) {
-	if limit <= 0 {
-		limit = 100
-	}
-	if limit > 1000 {
-		limit = 1000
-	}
-
+// ListByUserID retrieves transactions for a user
+func (r *repository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError) {
 	query := `
 		SELECT id, order_id, user_id, profile_id, amount, currency, status,
 			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
+			   net_amount, tax_amount, kind, created_at, updated_at
 		FROM transactions
+		WHERE user_id = $1
 		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
 	}
@@ -270,7 +269,7 @@ func (r *repository) GetAll(ctx context.Context, limit, offset int) ([]*domain.T
 			&t.ID, &t.OrderID, &t.UserID, &profileID,
 			&t.Amount, &t.Currency, &t.Status,
 			&paymentID, &gatewayPaymentID, &collectorID, &description,
-			&t.CreatedAt, &t.UpdatedAt,
+			&t.NetAmount, &t.TaxAmount, &t.Kind, &t.CreatedAt, &t.UpdatedAt,
 		)
 		if err != nil {
 			return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
@@ -303,6 +302,121 @@ func (r *repository) GetAll(ctx context.Context, limit, offset int) ([]*domain.T
 	return transactions, nil
 }
 
+// GetAll retrieves all transactions
+func (r *repository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+		}
+		transactions = append(transactions, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+	}
+
+	return transactions, nil
+}
+
+// Stream calls fn with every transaction, newest first, while reading them from
+// the database, so large results are never held in memory. An error from fn
+// stops the stream and is returned.
+func (r *repository) Stream(ctx context.Context, fn func(transaction *domain.Transaction) apperrors.ApplicationError) apperrors.ApplicationError {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.TransactionListError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return apperrors.NewApplicationError(mappings.TransactionListError, err)
+		}
+		if appErr := fn(t); appErr != nil {
+			return appErr
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return apperrors.NewApplicationError(mappings.TransactionListError, err)
+	}
+
+	return nil
+}
+
+// transactionColumns are the columns scanTransaction reads, in order
+const transactionColumns = `id, order_id, user_id, profile_id, amount, currency, status,
+			   payment_id, gateway_payment_id, collector_id, description,
+			   net_amount, tax_amount, kind, created_at, updated_at`
+
+// scanTransaction reads a row selected with transactionColumns
+func scanTransaction(rows *sql.Rows) (*domain.Transaction, error) {
+	var t domain.Transaction
+	var profileID sql.NullString
+	var paymentID sql.NullInt64
+	var gatewayPaymentID sql.NullString
+	var collectorID sql.NullString
+	var description sql.NullString
+
+	err := rows.Scan(
+		&t.ID, &t.OrderID, &t.UserID, &profileID,
+		&t.Amount, &t.Currency, &t.Status,
+		&paymentID, &gatewayPaymentID, &collectorID, &description,
+		&t.NetAmount, &t.TaxAmount, &t.Kind, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if profileID.Valid {
+		t.ProfileID = &profileID.String
+	}
+	if paymentID.Valid {
+		pid := int(paymentID.Int64)
+		t.PaymentID = &pid
+	}
+	if gatewayPaymentID.Valid {
+		t.GatewayPaymentID = &gatewayPaymentID.String
+	}
+	if collectorID.Valid {
+		t.CollectorID = &collectorID.String
+	}
+	if description.Valid {
+		t.Description = &description.String
+	}
+
+	return &t, nil
+}
+
 // Count returns the total number of transactions
 func (r *repository) Count(ctx context.Context) (int, apperrors.ApplicationError) {
 	query := `SELECT COUNT(*) FROM transactions`
@@ -0,0 +1,30 @@
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Format returns the ETag header value for an order version
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// Parse extracts the order version from an If-Match header value.
+// It accepts strong and weak ETags as well as a bare version number.
+func Parse(header string) (int, error) {
+	value := strings.TrimSpace(header)
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+	if value == "" {
+		return 0, errors.New("empty ETag")
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, errors.New("ETag is not an order version")
+	}
+
+	return version, nil
+}
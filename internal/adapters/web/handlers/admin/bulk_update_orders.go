@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewBulkUpdateOrdersHandler creates a handler for applying a status, ETA or status
+// message to many orders at once. When any order rejects the change nothing is
+// written and the per-order results come back with 422.
+func NewBulkUpdateOrdersHandler(usecase adminUsecase.BulkUpdateOrdersUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input adminUsecase.BulkUpdateOrdersInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		input.UserID, _ = middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, input)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		if !output.Applied {
+			c.JSON(http.StatusUnprocessableEntity, output)
+			return
+		}
+		c.JSON(http.StatusOK, output)
+	}
+}
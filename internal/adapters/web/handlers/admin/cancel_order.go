@@ -0,0 +1,48 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type CancelOrderInput struct {
+	Reason string `json:"reason"`
+}
+
+// NewCancelOrderHandler creates a handler for a manager cancelling an order
+func NewCancelOrderHandler(usecase orderUsecase.CancelUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		var input CancelOrderInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.CancelInput{
+			OrderID:   id,
+			UserID:    userID,
+			Reason:    input.Reason,
+			ByManager: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
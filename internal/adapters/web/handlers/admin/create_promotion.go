@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewCreatePromotionHandler creates a handler for creating a promotion
+func NewCreatePromotionHandler(usecase adminUsecase.CreatePromotionUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input adminUsecase.PromotionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, input)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
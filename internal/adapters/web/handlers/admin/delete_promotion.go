@@ -0,0 +1,23 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewDeletePromotionHandler creates a handler for deleting a promotion
+func NewDeletePromotionHandler(usecase adminUsecase.DeletePromotionUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		if appErr := usecase.Execute(c, id); appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
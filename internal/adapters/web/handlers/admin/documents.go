@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetReceiptHandler creates a handler for downloading the receipt of any paid order
+func NewGetReceiptHandler(usecase orderUsecase.GetDocumentUsecase) gin.HandlerFunc {
+	return newGetOrderDocumentHandler(usecase, orderUsecase.DocumentReceipt)
+}
+
+// NewGetDeliveryNoteHandler creates a handler for printing the delivery note of an order
+func NewGetDeliveryNoteHandler(usecase orderUsecase.GetDocumentUsecase) gin.HandlerFunc {
+	return newGetOrderDocumentHandler(usecase, orderUsecase.DocumentDeliveryNote)
+}
+
+func newGetOrderDocumentHandler(usecase orderUsecase.GetDocumentUsecase, kind string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.GetDocumentInput{
+			OrderID: c.Param("id"),
+			Kind:    kind,
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", output.Filename))
+		c.Data(http.StatusOK, "application/pdf", output.Content)
+	}
+}
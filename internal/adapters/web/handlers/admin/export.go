@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperrors "yego/internal/platform/errors"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewExportOrdersHandler creates a handler for exporting orders as CSV or XLSX. It
+// takes the filters of the order list, plus format=csv|xlsx and rows=order|item.
+func NewExportOrdersHandler(usecase adminUsecase.ExportOrdersUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		input := adminUsecase.ExportOrdersInput{
+			Search: orderSearchQuery(c),
+			Rows:   c.Query("rows"),
+			Format: adminUsecase.ExportFormat(c.DefaultQuery("format", string(adminUsecase.ExportCSV))),
+		}
+
+		streamExport(c, "orders", input.Format, func(w io.Writer) apperrors.ApplicationError {
+			return usecase.Execute(c, input, w)
+		})
+	}
+}
+
+// NewExportTransactionsHandler creates a handler for exporting transactions as CSV or XLSX
+func NewExportTransactionsHandler(usecase adminUsecase.ExportTransactionsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format := adminUsecase.ExportFormat(c.DefaultQuery("format", string(adminUsecase.ExportCSV)))
+
+		streamExport(c, "transactions", format, func(w io.Writer) apperrors.ApplicationError {
+			return usecase.Execute(c, format, w)
+		})
+	}
+}
+
+// streamExport sends the export as a download. Errors before the first byte are
+// answered as JSON; once the body has started only the log can tell.
+func streamExport(c *gin.Context, name string, format adminUsecase.ExportFormat, export func(w io.Writer) apperrors.ApplicationError) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102-150405"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	appErr := export(c.Writer)
+	if appErr == nil {
+		return
+	}
+	appErr.Log(c)
+	if c.Writer.Written() {
+		log.Printf("Export %s aborted after %d bytes", filename, c.Writer.Size())
+		return
+	}
+	c.Writer.Header().Del("Content-Disposition")
+	c.Writer.Header().Del("Content-Type")
+	c.JSON(appErr.StatusCode(), appErr)
+}
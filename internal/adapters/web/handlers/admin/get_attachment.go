@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewGetAttachmentHandler creates a handler for downloading an order attachment.
+// Redirects to a presigned URL when the storage supports it, otherwise streams the file.
+func NewGetAttachmentHandler(usecase adminUsecase.GetAttachmentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, c.Param("id"), c.Param("attachmentId"))
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		if output.RedirectURL != "" {
+			c.Redirect(http.StatusFound, output.RedirectURL)
+			return
+		}
+		defer output.Content.Close()
+
+		c.Header("Content-Type", output.Attachment.ContentType)
+		c.Header("Content-Length", strconv.FormatInt(output.Attachment.SizeBytes, 10))
+		c.Header("Cache-Control", "private, max-age=300")
+		c.Status(http.StatusOK)
+		_, _ = io.Copy(c.Writer, output.Content)
+	}
+}
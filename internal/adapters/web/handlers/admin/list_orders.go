@@ -2,15 +2,36 @@ package admin
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	adminUsecase "yego/internal/usecases/admin"
 )
 
-// NewListOrdersHandler creates a handler for listing all orders
+// NewListOrdersHandler creates a handler for searching orders. Filters are query
+// parameters; status may be repeated or comma-separated.
 func NewListOrdersHandler(usecase adminUsecase.ListOrdersUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		output, appErr := usecase.Execute(c)
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+		if err != nil || limit < 1 {
+			limit = 50
+		}
+		if limit > 1000 {
+			limit = 1000
+		}
+
+		offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		input := orderSearchQuery(c)
+		input.Limit = limit
+		input.Offset = offset
+		input.Cursor = c.Query("cursor")
+
+		output, appErr := usecase.Execute(c, input)
 		if appErr != nil {
 			appErr.Log(c)
 			c.JSON(appErr.StatusCode(), appErr)
@@ -20,3 +41,26 @@ func NewListOrdersHandler(usecase adminUsecase.ListOrdersUsecase) gin.HandlerFun
 		c.JSON(http.StatusOK, output)
 	}
 }
+
+// orderSearchQuery reads the order filters and sort shared by the list and the export
+func orderSearchQuery(c *gin.Context) adminUsecase.ListOrdersInput {
+	var statuses []string
+	for _, value := range c.QueryArray("status") {
+		statuses = append(statuses, strings.Split(value, ",")...)
+	}
+
+	return adminUsecase.ListOrdersInput{
+		Statuses:  statuses,
+		From:      c.Query("from"),
+		To:        c.Query("to"),
+		UserID:    c.Query("user_id"),
+		ProfileID: c.Query("profile_id"),
+		Phone:     c.Query("phone"),
+		ItemCode:  c.Query("item_code"),
+		ItemName:  c.Query("item_name"),
+		MinTotal:  c.Query("min_total"),
+		MaxTotal:  c.Query("max_total"),
+		Sort:      c.Query("sort"),
+		Order:     c.Query("order"),
+	}
+}
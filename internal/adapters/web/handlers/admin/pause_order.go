@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/etag"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type PauseOrderInput struct {
+	Reason   string  `json:"reason"`
+	ResumeAt *string `json:"resume_at,omitempty"` // RFC3339
+}
+
+// NewPauseOrderHandler creates a handler for a manager pausing an order
+func NewPauseOrderHandler(usecase orderUsecase.PauseUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		var input PauseOrderInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
+		var resumeAt *time.Time
+		if input.ResumeAt != nil && *input.ResumeAt != "" {
+			parsed, err := time.Parse(time.RFC3339, *input.ResumeAt)
+			if err != nil {
+				appErr := apperrors.NewApplicationError(mappings.OrderInvalidResumeAtError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+			resumeAt = &parsed
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.PauseInput{
+			OrderID:  id,
+			UserID:   userID,
+			Reason:   input.Reason,
+			ResumeAt: resumeAt,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.Header("ETag", etag.Format(output.Data.Version))
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/etag"
+	"yego/internal/adapters/web/middlewares"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewResumeOrderHandler creates a handler for a manager resuming a paused order
+func NewResumeOrderHandler(usecase orderUsecase.ResumeUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.ResumeInput{
+			OrderID: c.Param("id"),
+			UserID:  userID,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.Header("ETag", etag.Format(output.Data.Version))
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/etag"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+type ReviewModificationInput struct {
+	Note string `json:"note"`
+}
+
+// NewApproveModificationHandler creates a handler for approving an order's pending modification request
+func NewApproveModificationHandler(usecase adminUsecase.ReviewModificationUsecase) gin.HandlerFunc {
+	return newReviewModificationHandler(usecase, true)
+}
+
+// NewRejectModificationHandler creates a handler for rejecting an order's pending modification request
+func NewRejectModificationHandler(usecase adminUsecase.ReviewModificationUsecase) gin.HandlerFunc {
+	return newReviewModificationHandler(usecase, false)
+}
+
+func newReviewModificationHandler(usecase adminUsecase.ReviewModificationUsecase, approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		// The note is optional, so an empty body is accepted
+		var input ReviewModificationInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, adminUsecase.ReviewModificationInput{
+			OrderID: id,
+			UserID:  userID,
+			Approve: approve,
+			Note:    input.Note,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.Header("ETag", etag.Format(output.Order.Version))
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -3,8 +3,11 @@ package admin
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/etag"
+	"yego/internal/adapters/web/middlewares"
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
@@ -15,7 +18,14 @@ type UpdateOrderInput struct {
 	Status        *string           `json:"status,omitempty"`
 	StatusMessage *string           `json:"status_message,omitempty"`
 	ETA           *string           `json:"eta,omitempty"`
+	ETAFrom       *time.Time        `json:"eta_from,omitempty"`
+	ETATo         *time.Time        `json:"eta_to,omitempty"`
 	Data          *domain.OrderData `json:"data,omitempty"`
+	Currency      *string           `json:"currency,omitempty"`
+
+	// Moving to DELIVERED needs the customer's code or an override reason
+	DeliveryCode   string `json:"delivery_code,omitempty"`
+	OverrideReason string `json:"delivery_override_reason,omitempty"`
 }
 
 // NewUpdateOrderHandler creates a handler for updating an order
@@ -31,6 +41,24 @@ func NewUpdateOrderHandler(usecase adminUsecase.UpdateOrderUsecase) gin.HandlerF
 			return
 		}
 
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		// Editors must say which version they edited, so concurrent edits can't overwrite each other
+		ifMatch := c.GetHeader("If-Match")
+		if ifMatch == "" {
+			appErr := apperrors.NewApplicationError(mappings.OrderVersionRequiredError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+		version, err := etag.Parse(ifMatch)
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderInvalidVersionError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		var token string
 		if authHeader != "" {
@@ -38,11 +66,18 @@ func NewUpdateOrderHandler(usecase adminUsecase.UpdateOrderUsecase) gin.HandlerF
 		}
 
 		output, appErr := usecase.Execute(c, id, adminUsecase.UpdateOrderInput{
-			Status:        input.Status,
-			StatusMessage: input.StatusMessage,
-			ETA:           input.ETA,
-			Data:          input.Data,
-			Token:         token,
+			Status:          input.Status,
+			StatusMessage:   input.StatusMessage,
+			ETA:             input.ETA,
+			ETAFrom:         input.ETAFrom,
+			ETATo:           input.ETATo,
+			Data:            input.Data,
+			Currency:        input.Currency,
+			DeliveryCode:    input.DeliveryCode,
+			OverrideReason:  input.OverrideReason,
+			Token:           token,
+			UserID:          userID,
+			ExpectedVersion: version,
 		})
 		if appErr != nil {
 			appErr.Log(c)
@@ -50,6 +85,7 @@ func NewUpdateOrderHandler(usecase adminUsecase.UpdateOrderUsecase) gin.HandlerF
 			return
 		}
 
+		c.Header("ETag", etag.Format(output.Version))
 		c.JSON(http.StatusOK, output)
 	}
 }
@@ -0,0 +1,120 @@
+package cart
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	cartUsecase "yego/internal/usecases/cart"
+)
+
+type AddItemInput struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity" binding:"required"`
+	Weight   *int   `json:"weight"`
+}
+
+type UpdateItemInput struct {
+	Quantity *int `json:"quantity" binding:"required"`
+}
+
+// NewAddItemHandler creates a handler for adding a catalog product to the user's cart
+func NewAddItemHandler(usecase cartUsecase.ManageItemsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var input AddItemInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, cartUsecase.ManageItemsInput{
+			UserID:   userID,
+			Action:   cartUsecase.ActionAdd,
+			Code:     input.Code,
+			Name:     input.Name,
+			Quantity: input.Quantity,
+			Weight:   input.Weight,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewUpdateItemHandler creates a handler for changing the quantity of a cart item
+func NewUpdateItemHandler(usecase cartUsecase.ManageItemsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var input UpdateItemInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, cartUsecase.ManageItemsInput{
+			UserID:   userID,
+			Action:   cartUsecase.ActionUpdate,
+			Key:      c.Param("key"),
+			Quantity: *input.Quantity,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewRemoveItemHandler creates a handler for taking an item out of the user's cart
+func NewRemoveItemHandler(usecase cartUsecase.ManageItemsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, cartUsecase.ManageItemsInput{
+			UserID: userID,
+			Action: cartUsecase.ActionRemove,
+			Key:    c.Param("key"),
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,54 @@
+package cart
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	cartUsecase "yego/internal/usecases/cart"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
+)
+
+type SubmitInput struct {
+	ETA          string                             `json:"eta"`
+	DeliverySlot *deliveryslotUsecase.SlotSelection `json:"delivery_slot,omitempty"`
+}
+
+// NewSubmitHandler creates a handler for turning the user's cart into an order
+func NewSubmitHandler(usecase cartUsecase.SubmitUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		// Every field is optional, so an empty body is accepted
+		var input SubmitInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
+		output, appErr := usecase.Execute(c, cartUsecase.SubmitInput{
+			UserID:       userID,
+			ETA:          input.ETA,
+			DeliverySlot: input.DeliverySlot,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
@@ -0,0 +1,33 @@
+package deliveryslot
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
+)
+
+// NewListAvailableHandler creates a handler listing the delivery slots of ?date=YYYY-MM-DD
+func NewListAvailableHandler(usecase deliveryslotUsecase.ListAvailableUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		date := c.Query("date")
+		if date == "" {
+			appErr := apperrors.NewApplicationError(mappings.DeliverySlotInvalidDateError, errors.New("date query parameter is required"))
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, date)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
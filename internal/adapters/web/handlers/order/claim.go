@@ -7,9 +7,15 @@ import (
 	"yego/internal/adapters/web/middlewares"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
 	orderUsecase "yego/internal/usecases/order"
 )
 
+// ClaimInput is the optional claim body
+type ClaimInput struct {
+	DeliverySlot *deliveryslotUsecase.SlotSelection `json:"delivery_slot,omitempty"`
+}
+
 // NewClaimHandler creates a handler for claiming orders via token
 // This endpoint requires authentication - user_id comes from JWT context
 func NewClaimHandler(usecase orderUsecase.ClaimUsecase) gin.HandlerFunc {
@@ -30,9 +36,20 @@ func NewClaimHandler(usecase orderUsecase.ClaimUsecase) gin.HandlerFunc {
 			return
 		}
 
+		var input ClaimInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
 		output, appErr := usecase.Execute(c, orderUsecase.ClaimInput{
-			Token:  token,
-			UserID: userID,
+			Token:        token,
+			UserID:       userID,
+			DeliverySlot: input.DeliverySlot,
 		})
 		if appErr != nil {
 			appErr.Log(c)
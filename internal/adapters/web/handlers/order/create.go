@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
 	orderUsecase "yego/internal/usecases/order"
 )
 
@@ -14,6 +15,9 @@ type CreateInput struct {
 	ProfileID    string `json:"profile_id" binding:"required"`
 	ETA          string `json:"eta"`
 	SecurityCode string `json:"security_code"`
+	Currency     string `json:"currency"`
+
+	DeliverySlot *deliveryslotUsecase.SlotSelection `json:"delivery_slot,omitempty"`
 }
 
 // NewCreateHandler creates a handler for creating orders
@@ -38,7 +42,9 @@ func NewCreateHandler(usecase orderUsecase.CreateUsecase) gin.HandlerFunc {
 			ProfileID:    input.ProfileID,
 			ETA:          input.ETA,
 			SecurityCode: input.SecurityCode,
+			Currency:     input.Currency,
 			Token:        token,
+			DeliverySlot: input.DeliverySlot,
 		})
 		if appErr != nil {
 			appErr.Log(c)
@@ -6,11 +6,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 	orderUsecase "yego/internal/usecases/order"
 )
 
+type CreatePaymentLinkRequestBody struct {
+	Tip domain.Money `json:"tip"`
+}
+
 // NewCreatePaymentLinkHandler creates a handler for generating a MercadoPago Checkout Pro payment link
 func NewCreatePaymentLinkHandler(usecase orderUsecase.CreatePaymentLinkUsecase, frontendURL string, backendURL string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -30,6 +35,17 @@ func NewCreatePaymentLinkHandler(usecase orderUsecase.CreatePaymentLinkUsecase,
 			return
 		}
 
+		// The tip is optional, so an empty body is accepted
+		var body CreatePaymentLinkRequestBody
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&body); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		var authToken string
 		if authHeader != "" {
@@ -42,6 +58,7 @@ func NewCreatePaymentLinkHandler(usecase orderUsecase.CreatePaymentLinkUsecase,
 			AuthToken:   authToken,
 			FrontendURL: frontendURL,
 			BackendURL:  backendURL,
+			Tip:         body.Tip,
 		})
 		if appErr != nil {
 			appErr.Log(c)
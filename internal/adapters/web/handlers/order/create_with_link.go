@@ -2,7 +2,9 @@ package order
 
 import (
 	"net/http"
+	"time"
 
+	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 	orderUsecase "yego/internal/usecases/order"
@@ -11,11 +13,11 @@ import (
 )
 
 type CreateWithLinkItemInput struct {
-	Code     string  `json:"code,omitempty"`
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
-	Weight   *int    `json:"weight,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Name     string       `json:"name"`
+	Price    domain.Money `json:"price"`
+	Quantity int          `json:"quantity"`
+	Weight   *int         `json:"weight,omitempty"`
 }
 
 type CreateWithLinkDataInput struct {
@@ -25,6 +27,9 @@ type CreateWithLinkDataInput struct {
 type CreateWithLinkInput struct {
 	PhoneNumber string                   `json:"phone_number"`
 	ETA         string                   `json:"eta"`
+	ETAFrom     *time.Time               `json:"eta_from,omitempty"`
+	ETATo       *time.Time               `json:"eta_to,omitempty"`
+	Currency    string                   `json:"currency,omitempty"`
 	Data        *CreateWithLinkDataInput `json:"data,omitempty"`
 }
 
@@ -44,6 +49,9 @@ func NewCreateWithLinkHandler(usecase orderUsecase.CreateWithLinkUsecase, fronte
 		usecaseInput := orderUsecase.CreateWithLinkInput{
 			PhoneNumber: input.PhoneNumber,
 			ETA:         input.ETA,
+			ETAFrom:     input.ETAFrom,
+			ETATo:       input.ETATo,
+			Currency:    input.Currency,
 		}
 
 		if input.Data != nil && len(input.Data.Items) > 0 {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/etag"
 	orderUsecase "yego/internal/usecases/order"
 )
 
@@ -19,6 +20,7 @@ func NewGetHandler(usecase orderUsecase.GetUsecase) gin.HandlerFunc {
 			return
 		}
 
+		c.Header("ETag", etag.Format(output.Data.Version))
 		c.JSON(http.StatusOK, output)
 	}
 }
@@ -0,0 +1,24 @@
+package order
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetHistoryHandler creates a handler for getting an order's status timeline
+func NewGetHistoryHandler(usecase orderUsecase.GetHistoryUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		output, appErr := usecase.Execute(c, id)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
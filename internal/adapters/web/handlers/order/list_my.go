@@ -2,6 +2,7 @@ package order
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"yego/internal/adapters/web/middlewares"
@@ -10,7 +11,8 @@ import (
 	orderUsecase "yego/internal/usecases/order"
 )
 
-// NewListMyHandler creates a handler for listing current user's orders
+// NewListMyHandler creates a handler for listing current user's orders. Pages are
+// requested with limit and continued with cursor; state filters active or finished orders.
 func NewListMyHandler(usecase orderUsecase.ListMyOrdersUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from context (set by auth middleware)
@@ -22,7 +24,26 @@ func NewListMyHandler(usecase orderUsecase.ListMyOrdersUsecase) gin.HandlerFunc
 			return
 		}
 
-		output, appErr := usecase.Execute(c, userID)
+		input := orderUsecase.ListMyOrdersInput{
+			UserID: userID,
+			State:  c.Query("state"),
+			Cursor: c.Query("cursor"),
+		}
+		if limitStr := c.Query("limit"); limitStr != "" {
+			limit, err := strconv.Atoi(limitStr)
+			if err != nil || limit < 1 {
+				appErr := apperrors.NewApplicationError(mappings.OrderInvalidSearchError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+			if limit > 100 {
+				limit = 100
+			}
+			input.Limit = limit
+		}
+
+		output, appErr := usecase.Execute(c, input)
 		if appErr != nil {
 			appErr.Log(c)
 			c.JSON(appErr.StatusCode(), appErr)
@@ -6,13 +6,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 	orderUsecase "yego/internal/usecases/order"
 )
 
 type PayForOrderRequestBody struct {
-	SecurityCode string `json:"security_code" binding:"required"`
+	SecurityCode string       `json:"security_code" binding:"required"`
+	Tip          domain.Money `json:"tip"`
 }
 
 // NewPayForOrderHandler creates a handler for processing payment for an order
@@ -53,6 +55,7 @@ func NewPayForOrderHandler(usecase orderUsecase.PayForOrderUsecase) gin.HandlerF
 			UserID:       userID,
 			AuthToken:    authToken,
 			SecurityCode: body.SecurityCode,
+			Tip:          body.Tip,
 		})
 		if appErr != nil {
 			appErr.Log(c)
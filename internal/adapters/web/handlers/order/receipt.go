@@ -0,0 +1,40 @@
+package order
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetReceiptHandler creates a handler for downloading the PDF receipt of one of
+// the current user's paid orders
+func NewGetReceiptHandler(usecase orderUsecase.GetDocumentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.GetDocumentInput{
+			OrderID: c.Param("id"),
+			Kind:    orderUsecase.DocumentReceipt,
+			UserID:  userID,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", output.Filename))
+		c.Data(http.StatusOK, "application/pdf", output.Content)
+	}
+}
@@ -0,0 +1,59 @@
+package order
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type TipOrderRequestBody struct {
+	Amount       domain.Money `json:"amount" binding:"required"`
+	SecurityCode string       `json:"security_code"`
+}
+
+// NewTipOrderHandler creates a handler for tipping the driver of a delivered order
+func NewTipOrderHandler(usecase orderUsecase.TipOrderUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var body TipOrderRequestBody
+		if err := c.ShouldBindJSON(&body); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var authToken string
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			authToken = strings.TrimPrefix(authHeader, "Bearer ")
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.TipOrderInput{
+			OrderID:      c.Param("id"),
+			UserID:       userID,
+			AuthToken:    authToken,
+			SecurityCode: body.SecurityCode,
+			Amount:       body.Amount,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
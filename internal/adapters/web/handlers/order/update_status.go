@@ -4,13 +4,16 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/etag"
+	"yego/internal/adapters/web/middlewares"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 	orderUsecase "yego/internal/usecases/order"
 )
 
 type UpdateStatusInput struct {
-	Status string `json:"status" binding:"required"`
+	Status       string `json:"status" binding:"required"`
+	DeliveryCode string `json:"delivery_code"` // required to move to DELIVERED
 }
 
 // NewUpdateStatusHandler creates a handler for updating order status
@@ -26,6 +29,20 @@ func NewUpdateStatusHandler(usecase orderUsecase.UpdateStatusUsecase) gin.Handle
 			return
 		}
 
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		var expectedVersion *int
+		if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+			version, err := etag.Parse(ifMatch)
+			if err != nil {
+				appErr := apperrors.NewApplicationError(mappings.OrderInvalidVersionError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+			expectedVersion = &version
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		var token string
 		if authHeader != "" && len(authHeader) > 7 {
@@ -33,8 +50,11 @@ func NewUpdateStatusHandler(usecase orderUsecase.UpdateStatusUsecase) gin.Handle
 		}
 
 		output, appErr := usecase.Execute(c, id, orderUsecase.UpdateStatusInput{
-			Status: input.Status,
-			Token:  token,
+			Status:          input.Status,
+			DeliveryCode:    input.DeliveryCode,
+			Token:           token,
+			UserID:          userID,
+			ExpectedVersion: expectedVersion,
 		})
 		if appErr != nil {
 			appErr.Log(c)
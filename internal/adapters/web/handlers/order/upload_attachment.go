@@ -0,0 +1,52 @@
+package order
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewUploadAttachmentHandler creates a handler for attaching a proof-of-delivery photo or signature.
+// Expects a multipart form with a "file" part and a "kind" field (PHOTO or SIGNATURE).
+func NewUploadAttachmentHandler(usecase orderUsecase.UploadAttachmentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orderID := c.Param("id")
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.AttachmentMissingFileError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.AttachmentMissingFileError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+		defer file.Close()
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.UploadAttachmentInput{
+			OrderID: orderID,
+			UserID:  userID,
+			Kind:    c.PostForm("kind"),
+			File:    file,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
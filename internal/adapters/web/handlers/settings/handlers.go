@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 	settingsUsecase "yego/internal/usecases/settings"
@@ -31,10 +32,17 @@ type UpdateInput struct {
 	DefaultMapLongitude *float64 `json:"default_map_longitude,omitempty"`
 	DefaultMapZoom      *int     `json:"default_map_zoom,omitempty"`
 	DefaultItemWeight   *int     `json:"default_item_weight,omitempty"`
-	DeliveryBasePrice   *float64 `json:"delivery_base_price,omitempty"`
-	DeliveryPricePerKm  *float64 `json:"delivery_price_per_km,omitempty"`
-	DeliveryPricePerKg  *float64 `json:"delivery_price_per_kg,omitempty"`
+	DeliveryBasePrice   *domain.Money `json:"delivery_base_price,omitempty"`
+	DeliveryPricePerKm  *domain.Money `json:"delivery_price_per_km,omitempty"`
+	DeliveryPricePerKg  *domain.Money `json:"delivery_price_per_kg,omitempty"`
 	ManagerCollectorID  *string  `json:"manager_collector_id,omitempty"`
+
+	Timezone      *string                          `json:"timezone,omitempty"`
+	DeliverySlots *[]domain.DeliverySlotDefinition `json:"delivery_slots,omitempty"`
+
+	DefaultCurrency        *string                           `json:"default_currency,omitempty"`
+	CurrencyDeliveryPrices *map[string]domain.DeliveryPrices `json:"currency_delivery_prices,omitempty"`
+	Tax                    *domain.TaxSettings               `json:"tax,omitempty"`
 }
 
 // NewUpdateHandler creates a handler for updating settings
@@ -60,6 +68,12 @@ func NewUpdateHandler(usecase settingsUsecase.UpdateUsecase) gin.HandlerFunc {
 			DeliveryPricePerKm: input.DeliveryPricePerKm,
 			DeliveryPricePerKg: input.DeliveryPricePerKg,
 			ManagerCollectorID: input.ManagerCollectorID,
+			Timezone:           input.Timezone,
+			DeliverySlots:      input.DeliverySlots,
+			DefaultCurrency:    input.DefaultCurrency,
+			Tax:                input.Tax,
+
+			CurrencyDeliveryPrices: input.CurrencyDeliveryPrices,
 		})
 		if appErr != nil {
 			appErr.Log(c)
@@ -80,6 +94,7 @@ type CalculateDeliveryFeeInput struct {
 	UserLatitude  float64                         `json:"user_latitude" binding:"required"`
 	UserLongitude float64                         `json:"user_longitude" binding:"required"`
 	Items         []CalculateDeliveryFeeItemInput `json:"items"`
+	Currency      string                          `json:"currency"`
 }
 
 // NewCalculateDeliveryFeeHandler creates a handler for calculating delivery fee
@@ -97,6 +112,7 @@ func NewCalculateDeliveryFeeHandler(usecase settingsUsecase.CalculateDeliveryFee
 		usecaseInput := settingsUsecase.CalculateDeliveryFeeInput{
 			UserLatitude:  input.UserLatitude,
 			UserLongitude: input.UserLongitude,
+			Currency:      input.Currency,
 		}
 
 		// Convert items
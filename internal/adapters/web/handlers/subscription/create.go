@@ -0,0 +1,58 @@
+package subscription
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	subscriptionUsecase "yego/internal/usecases/subscription"
+)
+
+type CreateInput struct {
+	Items       []domain.OrderItem    `json:"items"`
+	FromOrderID *string               `json:"from_order_id"`
+	ETA         string                `json:"eta"`
+	Recurrence  domain.RecurrenceRule `json:"recurrence" binding:"required"`
+	FirstRunAt  *time.Time            `json:"first_run_at"`
+}
+
+// NewCreateHandler creates a handler for subscribing to a recurring order
+func NewCreateHandler(usecase subscriptionUsecase.CreateUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var input CreateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.CreateInput{
+			UserID:      userID,
+			Items:       input.Items,
+			FromOrderID: input.FromOrderID,
+			ETA:         input.ETA,
+			Recurrence:  input.Recurrence,
+			FirstRunAt:  input.FirstRunAt,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
@@ -0,0 +1,56 @@
+package subscription
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	subscriptionUsecase "yego/internal/usecases/subscription"
+)
+
+// NewPauseHandler creates a handler for pausing a subscription
+func NewPauseHandler(usecase subscriptionUsecase.ManageUsecase) gin.HandlerFunc {
+	return newManageHandler(usecase, subscriptionUsecase.ActionPause)
+}
+
+// NewResumeHandler creates a handler for resuming a paused subscription
+func NewResumeHandler(usecase subscriptionUsecase.ManageUsecase) gin.HandlerFunc {
+	return newManageHandler(usecase, subscriptionUsecase.ActionResume)
+}
+
+// NewSkipNextHandler creates a handler for skipping the next delivery of a subscription
+func NewSkipNextHandler(usecase subscriptionUsecase.ManageUsecase) gin.HandlerFunc {
+	return newManageHandler(usecase, subscriptionUsecase.ActionSkipNext)
+}
+
+// NewCancelHandler creates a handler for cancelling a subscription
+func NewCancelHandler(usecase subscriptionUsecase.ManageUsecase) gin.HandlerFunc {
+	return newManageHandler(usecase, subscriptionUsecase.ActionCancel)
+}
+
+func newManageHandler(usecase subscriptionUsecase.ManageUsecase, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.ManageInput{
+			SubscriptionID: c.Param("id"),
+			UserID:         userID,
+			Action:         action,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -3,6 +3,7 @@ package integrations
 import (
 	"yego/internal/adapters/web/integrations/auth"
 	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/adapters/web/integrations/storage"
 	"yego/internal/adapters/web/integrations/websocket"
 	"yego/internal/platform/config"
 )
@@ -11,6 +12,7 @@ type Integrations struct {
 	WebSocket websocket.Integration
 	Payments  payments.Integration
 	Auth      auth.Integration
+	Storage   storage.Integration
 }
 
 func CreateIntegration(cfg *config.ConfigurationService) *Integrations {
@@ -18,5 +20,6 @@ func CreateIntegration(cfg *config.ConfigurationService) *Integrations {
 		WebSocket: websocket.NewIntegration(cfg),
 		Payments:  payments.NewIntegration(cfg),
 		Auth:      auth.NewIntegration(cfg),
+		Storage:   storage.NewIntegration(cfg),
 	}
 }
@@ -6,14 +6,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"yego/internal/domain"
 	"yego/internal/platform/config"
 )
 
 type Integration interface {
 	HasPaymentMethod(userID string) (bool, error)
 	GetDefaultPaymentMethod(userID string) (*PaymentMethod, error)
-	ProcessPaymentWithSavedMethod(userID string, amount float64, description string, externalReference string, payerEmail string, collectorID string, securityCode string) (*ProcessPaymentResponse, error)
+	ProcessPaymentWithSavedMethod(userID string, amount domain.Money, currencyID string, description string, externalReference string, payerEmail string, collectorID string, securityCode string) (*ProcessPaymentResponse, error)
 	CreatePreference(items []PreferenceItem, payerEmail string, externalReference string, backURLSuccess string, backURLFailure string, backURLPending string, notificationURL string) (*PreferenceResponse, error)
+	Refund(gatewayPaymentID string, amount domain.Money, externalReference string) (*RefundResponse, error)
 }
 
 type ProcessPaymentResponse struct {
@@ -22,11 +24,17 @@ type ProcessPaymentResponse struct {
 	Status           string `json:"status"`
 }
 
+type RefundResponse struct {
+	RefundID        int    `json:"id"`
+	GatewayRefundID string `json:"gateway_refund_id"`
+	Status          string `json:"status"`
+}
+
 type PreferenceItem struct {
-	Title      string  `json:"title"`
-	Quantity   int     `json:"quantity"`
-	UnitPrice  float64 `json:"unit_price"`
-	CurrencyID string  `json:"currency_id"`
+	Title      string       `json:"title"`
+	Quantity   int          `json:"quantity"`
+	UnitPrice  domain.Money `json:"unit_price"`
+	CurrencyID string       `json:"currency_id"`
 }
 
 type PreferenceResponse struct {
@@ -214,7 +222,7 @@ func (i *integration) CreatePreference(items []PreferenceItem, payerEmail string
 	return &prefResp, nil
 }
 
-func (i *integration) ProcessPaymentWithSavedMethod(userID string, amount float64, description string, externalReference string, payerEmail string, collectorID string, securityCode string) (*ProcessPaymentResponse, error) {
+func (i *integration) ProcessPaymentWithSavedMethod(userID string, amount domain.Money, currencyID string, description string, externalReference string, payerEmail string, collectorID string, securityCode string) (*ProcessPaymentResponse, error) {
 	paymentMethod, err := i.GetDefaultPaymentMethod(userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payment method: %w", err)
@@ -227,6 +235,7 @@ func (i *integration) ProcessPaymentWithSavedMethod(userID string, amount float6
 
 	payload := map[string]interface{}{
 		"transaction_amount": amount,
+		"currency_id":        currencyID,
 		"payment_method_id":  paymentMethod.ID,
 		"payer": map[string]string{
 			"email": payerEmail,
@@ -271,3 +280,41 @@ func (i *integration) ProcessPaymentWithSavedMethod(userID string, amount float6
 
 	return &paymentResponse, nil
 }
+
+func (i *integration) Refund(gatewayPaymentID string, amount domain.Money, externalReference string) (*RefundResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/payments/refunds", i.baseURL)
+
+	payload := map[string]interface{}{
+		"gateway_payment_id": gatewayPaymentID,
+		"amount":             amount,
+		"external_reference": externalReference,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := i.newRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("payment service error: %s", string(body))
+	}
+
+	var refundResponse RefundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&refundResponse); err != nil {
+		return nil, err
+	}
+
+	return &refundResponse, nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"yego/internal/platform/config"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("storage: object not found")
+
+// Integration stores binary objects such as order attachments under opaque keys
+type Integration interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a time-limited direct download link, or "" when the backend
+	// cannot issue one and downloads must be streamed through the API
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// NewIntegration returns the backend selected by STORAGE_BACKEND
+func NewIntegration(cfg *config.ConfigurationService) Integration {
+	switch cfg.StorageBackend {
+	case "s3":
+		return newS3Storage(s3Config{
+			Endpoint:       cfg.S3Endpoint,
+			Region:         cfg.S3Region,
+			Bucket:         cfg.S3Bucket,
+			AccessKey:      cfg.S3AccessKey,
+			SecretKey:      cfg.S3SecretKey,
+			ForcePathStyle: cfg.S3ForcePathStyle,
+		})
+	case "local", "":
+		return newLocalStorage(cfg.StorageLocalDir)
+	default:
+		log.Printf("Unknown STORAGE_BACKEND %q, using local storage", cfg.StorageBackend)
+		return newLocalStorage(cfg.StorageLocalDir)
+	}
+}
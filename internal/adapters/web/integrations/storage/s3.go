@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service         = "s3"
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3AmzDateLayout   = "20060102T150405Z"
+	s3ShortDateLayout = "20060102"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3MaxSignedExpiry = 7 * 24 * time.Hour
+)
+
+type s3Config struct {
+	Endpoint       string // e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	ForcePathStyle bool // required by MinIO; AWS accepts virtual-hosted style
+}
+
+// s3Storage talks to S3-compatible object stores with Signature Version 4 requests
+type s3Storage struct {
+	cfg    s3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func newS3Storage(cfg s3Config) Integration {
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &s3Storage{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 60 * time.Second},
+		now:    time.Now,
+	}
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = int64(len(data))
+	s.sign(req, hashHex(data))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("storage: put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error("put", key, resp)
+	}
+	return nil
+}
+
+func (s *s3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, hashHex(nil))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: get %s: %w", key, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error("get", key, resp)
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, hashHex(nil))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("storage: delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error("delete", key, resp)
+	}
+	return nil
+}
+
+// SignedURL returns a presigned GET URL valid for expiry (at most seven days)
+func (s *s3Storage) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if expiry <= 0 || expiry > s3MaxSignedExpiry {
+		expiry = s3MaxSignedExpiry
+	}
+
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+
+	now := s.now().UTC()
+	amzDate := now.Format(s3AmzDateLayout)
+	scope := s.scope(now)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.cfg.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	objectURL.RawQuery = canonicalQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		objectURL.EscapedPath(),
+		objectURL.RawQuery,
+		"host:" + objectURL.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+	objectURL.RawQuery += "&X-Amz-Signature=" + signature
+	return objectURL.String(), nil
+}
+
+func (s *s3Storage) newRequest(ctx context.Context, method string, key string, body []byte) (*http.Request, error) {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	return http.NewRequestWithContext(ctx, method, objectURL.String(), reader)
+}
+
+// objectURL builds the path-style or virtual-hosted-style URL of an object
+func (s *s3Storage) objectURL(key string) (*url.URL, error) {
+	if key == "" {
+		return nil, fmt.Errorf("storage: empty key")
+	}
+	endpoint, err := url.Parse(s.cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("storage: invalid S3 endpoint: %w", err)
+	}
+
+	objectPath := "/" + key
+	if s.cfg.ForcePathStyle {
+		objectPath = "/" + s.cfg.Bucket + objectPath
+	} else {
+		endpoint.Host = s.cfg.Bucket + "." + endpoint.Host
+	}
+	endpoint.Path = objectPath
+	endpoint.RawPath = escapePath(objectPath)
+	return endpoint, nil
+}
+
+// sign adds SigV4 Authorization headers to req
+func (s *s3Storage) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	amzDate := now.Format(s3AmzDateLayout)
+	scope := s.scope(now)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func (s *s3Storage) scope(now time.Time) string {
+	return now.Format(s3ShortDateLayout) + "/" + s.cfg.Region + "/" + s3Service + "/aws4_request"
+}
+
+func (s *s3Storage) signature(now time.Time, amzDate string, scope string, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format(s3ShortDateLayout))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalQuery encodes query parameters sorted by name, as SigV4 requires
+func canonicalQuery(values url.Values) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, value := range values[name] {
+			parts = append(parts, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escapePath encodes each path segment the way SigV4 expects, keeping the slashes
+func escapePath(path string) string {
+	return uriEncode(path, false)
+}
+
+// uriEncode percent-encodes everything except RFC 3986 unreserved characters;
+// slashes are kept unless encodeSlash is set
+func uriEncode(value string, encodeSlash bool) string {
+	var builder strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			builder.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+func s3Error(operation string, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage: %s %s: status %d: %s", operation, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -3,10 +3,13 @@ package web
 import (
 	"github.com/gin-gonic/gin"
 	adminHandler "yego/internal/adapters/web/handlers/admin"
+	cartHandler "yego/internal/adapters/web/handlers/cart"
+	deliverySlotHandler "yego/internal/adapters/web/handlers/deliveryslot"
 	orderHandler "yego/internal/adapters/web/handlers/order"
 	paymentHandler "yego/internal/adapters/web/handlers/payment"
 	profileHandler "yego/internal/adapters/web/handlers/profile"
 	settingsHandler "yego/internal/adapters/web/handlers/settings"
+	subscriptionHandler "yego/internal/adapters/web/handlers/subscription"
 	websocketHandler "yego/internal/adapters/web/handlers/websocket"
 	"yego/internal/adapters/web/middlewares"
 	"yego/internal/platform/config"
@@ -21,6 +24,7 @@ func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *web
 	orders := api.Group("/orders")
 	{
 		orders.GET("/:id", orderHandler.NewGetHandler(useCases.Order.GetUsecase))
+		orders.GET("/:id/history", orderHandler.NewGetHistoryHandler(useCases.Order.GetHistoryUsecase))
 		orders.POST("/create-with-link", orderHandler.NewCreateWithLinkHandler(useCases.Order.CreateWithLinkUsecase, cfg.FrontendURL))
 		orders.GET("/claim/:token/info", orderHandler.NewGetClaimInfoHandler(useCases.Order.GetClaimInfoUsecase))
 		// MercadoPago webhook — called by MP servers, no auth
@@ -37,6 +41,39 @@ func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *web
 		ordersAuth.POST("/:id/pay", orderHandler.NewPayForOrderHandler(useCases.Order.PayForOrderUsecase))
 		ordersAuth.POST("/:id/payment-link", orderHandler.NewCreatePaymentLinkHandler(useCases.Order.CreatePaymentLinkUsecase, cfg.FrontendURL, cfg.BackendURL))
 		ordersAuth.GET("/my", orderHandler.NewListMyHandler(useCases.Order.ListMyOrdersUsecase))
+		ordersAuth.POST("/:id/cancel", orderHandler.NewCancelHandler(useCases.Order.CancelUsecase))
+		ordersAuth.POST("/:id/modifications", orderHandler.NewRequestModificationHandler(useCases.Order.RequestModificationUsecase))
+		ordersAuth.GET("/:id/modifications", orderHandler.NewListModificationsHandler(useCases.Order.ListModificationsUsecase))
+		ordersAuth.GET("/:id/delivery-code", orderHandler.NewGetDeliveryCodeHandler(useCases.Order.GetDeliveryCodeUsecase))
+		ordersAuth.POST("/:id/attachments", orderHandler.NewUploadAttachmentHandler(useCases.Order.UploadAttachmentUsecase))
+		ordersAuth.POST("/:id/coupons", orderHandler.NewApplyCouponHandler(useCases.Order.ApplyCouponUsecase))
+		ordersAuth.DELETE("/:id/coupons/:code", orderHandler.NewRemoveCouponHandler(useCases.Order.RemoveCouponUsecase))
+		ordersAuth.POST("/:id/tip", orderHandler.NewTipOrderHandler(useCases.Order.TipOrderUsecase))
+		ordersAuth.POST("/:id/reorder", orderHandler.NewReorderHandler(useCases.Order.ReorderUsecase))
+		ordersAuth.GET("/:id/receipt.pdf", orderHandler.NewGetReceiptHandler(useCases.Order.GetDocumentUsecase))
+	}
+
+	// Recurring subscription routes (require auth)
+	subscriptions := api.Group("/subscriptions")
+	subscriptions.Use(middlewares.AuthMiddleware())
+	{
+		subscriptions.POST("", subscriptionHandler.NewCreateHandler(useCases.Subscription.CreateUsecase))
+		subscriptions.GET("", subscriptionHandler.NewListHandler(useCases.Subscription.ListUsecase))
+		subscriptions.POST("/:id/pause", subscriptionHandler.NewPauseHandler(useCases.Subscription.ManageUsecase))
+		subscriptions.POST("/:id/resume", subscriptionHandler.NewResumeHandler(useCases.Subscription.ManageUsecase))
+		subscriptions.POST("/:id/skip-next", subscriptionHandler.NewSkipNextHandler(useCases.Subscription.ManageUsecase))
+		subscriptions.POST("/:id/cancel", subscriptionHandler.NewCancelHandler(useCases.Subscription.ManageUsecase))
+	}
+
+	// Server-side cart routes (require auth); items are keyed by product code, or name
+	cart := api.Group("/cart")
+	cart.Use(middlewares.AuthMiddleware())
+	{
+		cart.GET("", cartHandler.NewGetHandler(useCases.Cart.GetUsecase))
+		cart.POST("/items", cartHandler.NewAddItemHandler(useCases.Cart.ManageItemsUsecase))
+		cart.PUT("/items/:key", cartHandler.NewUpdateItemHandler(useCases.Cart.ManageItemsUsecase))
+		cart.DELETE("/items/:key", cartHandler.NewRemoveItemHandler(useCases.Cart.ManageItemsUsecase))
+		cart.POST("/submit", cartHandler.NewSubmitHandler(useCases.Cart.SubmitUsecase))
 	}
 
 	// Public profile routes (token-based access)
@@ -65,6 +102,9 @@ func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *web
 		settings.POST("/calculate-delivery", settingsHandler.NewCalculateDeliveryFeeHandler(useCases.Settings.CalculateDeliveryFeeUsecase))
 	}
 
+	// Delivery slot availability (public, customers pick a slot before ordering)
+	api.GET("/delivery-slots", deliverySlotHandler.NewListAvailableHandler(useCases.DeliverySlot.ListAvailableUsecase))
+
 	// Admin routes (require auth)
 	admin := api.Group("/admin")
 	admin.Use(middlewares.AuthMiddleware())
@@ -72,13 +112,29 @@ func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *web
 		admin.GET("/profiles", adminHandler.NewListProfilesHandler(useCases.Admin.ListProfilesUsecase))
 		admin.GET("/orders", adminHandler.NewListOrdersHandler(useCases.Admin.ListOrdersUsecase))
 		admin.GET("/transactions", adminHandler.NewListTransactionsHandler(useCases.Admin.ListTransactionsUsecase))
+		admin.GET("/orders/export", adminHandler.NewExportOrdersHandler(useCases.Admin.ExportOrders))
+		admin.GET("/transactions/export", adminHandler.NewExportTransactionsHandler(useCases.Admin.ExportTransactions))
 		admin.PUT("/orders/:id", adminHandler.NewUpdateOrderHandler(useCases.Admin.UpdateOrderUsecase))
+		admin.POST("/orders/bulk", adminHandler.NewBulkUpdateOrdersHandler(useCases.Admin.BulkUpdateOrdersUsecase))
+		admin.POST("/orders/:id/cancel", adminHandler.NewCancelOrderHandler(useCases.Order.CancelUsecase))
+		admin.POST("/orders/:id/pause", adminHandler.NewPauseOrderHandler(useCases.Order.PauseUsecase))
+		admin.POST("/orders/:id/resume", adminHandler.NewResumeOrderHandler(useCases.Order.ResumeUsecase))
+		admin.GET("/orders/:id/modification", adminHandler.NewGetModificationHandler(useCases.Admin.GetModificationUsecase))
+		admin.POST("/orders/:id/modification/approve", adminHandler.NewApproveModificationHandler(useCases.Admin.ReviewModificationUsecase))
+		admin.POST("/orders/:id/modification/reject", adminHandler.NewRejectModificationHandler(useCases.Admin.ReviewModificationUsecase))
+		admin.GET("/orders/:id/attachments/:attachmentId", adminHandler.NewGetAttachmentHandler(useCases.Admin.GetAttachmentUsecase))
+		admin.GET("/orders/:id/receipt.pdf", adminHandler.NewGetReceiptHandler(useCases.Order.GetDocumentUsecase))
+		admin.GET("/orders/:id/delivery-note.pdf", adminHandler.NewGetDeliveryNoteHandler(useCases.Order.GetDocumentUsecase))
 		admin.POST("/import", adminHandler.NewUploadImportHandler(useCases.Admin.UploadImport))
 		admin.GET("/imports", adminHandler.NewListImportsHandler(useCases.Admin.ListImports))
 		admin.POST("/imports", adminHandler.NewCreateImportHandler(useCases.Admin.CreateImport))
 		admin.PUT("/imports/:id", adminHandler.NewUpdateImportHandler(useCases.Admin.UpdateImport))
 		admin.DELETE("/imports/:id", adminHandler.NewDeleteImportHandler(useCases.Admin.DeleteImport))
 		admin.DELETE("/imports", adminHandler.NewClearImportsHandler(useCases.Admin.ClearImports))
+		admin.GET("/promotions", adminHandler.NewListPromotionsHandler(useCases.Admin.ListPromotions))
+		admin.POST("/promotions", adminHandler.NewCreatePromotionHandler(useCases.Admin.CreatePromotion))
+		admin.PUT("/promotions/:id", adminHandler.NewUpdatePromotionHandler(useCases.Admin.UpdatePromotion))
+		admin.DELETE("/promotions/:id", adminHandler.NewDeletePromotionHandler(useCases.Admin.DeletePromotion))
 	}
 
 	// Payment routes (require auth)
@@ -6,13 +6,15 @@ import (
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"yego/internal/domain"
 )
 
 type NotificationType string
 
 const (
-	OrderClaimedNotification NotificationType = "order_claimed"
-	OrderUpdatedNotification NotificationType = "order_updated"
+	OrderClaimedNotification   NotificationType = "order_claimed"
+	OrderUpdatedNotification   NotificationType = "order_updated"
+	OrderCancelledNotification NotificationType = "order_cancelled"
 )
 
 type Notification struct {
@@ -29,6 +31,29 @@ type OrderClaimedPayload struct {
 	ClaimedAt string `json:"claimed_at"`
 }
 
+type OrderCancelledPayload struct {
+	OrderID        string       `json:"order_id"`
+	UserID         string       `json:"user_id,omitempty"`
+	CancelledBy    string       `json:"cancelled_by"`
+	Reason         string       `json:"reason,omitempty"`
+	PreviousStatus string       `json:"previous_status"`
+	RefundStatus   string       `json:"refund_status"`
+	RefundAmount   domain.Money `json:"refund_amount"`
+	CancelledAt    string       `json:"cancelled_at"`
+}
+
+type OrderUpdatedPayload struct {
+	OrderID        string  `json:"order_id"`
+	UserID         string  `json:"user_id,omitempty"`
+	Status         string  `json:"status"`
+	PreviousStatus string  `json:"previous_status"`
+	StatusMessage  string  `json:"status_message,omitempty"`
+	PauseReason    string  `json:"pause_reason,omitempty"`
+	ResumeAt       *string `json:"resume_at,omitempty"`
+	Version        int     `json:"version"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
 type Client struct {
 	Hub       *Hub
 	Conn      *websocket.Conn
@@ -105,6 +130,22 @@ func (h *Hub) NotifyOrderClaimed(payload OrderClaimedPayload) error {
 	return h.BroadcastNotification(notification)
 }
 
+func (h *Hub) NotifyOrderCancelled(payload OrderCancelledPayload) error {
+	notification := Notification{
+		Type:    OrderCancelledNotification,
+		Payload: payload,
+	}
+	return h.BroadcastNotification(notification)
+}
+
+func (h *Hub) NotifyOrderUpdated(payload OrderUpdatedPayload) error {
+	notification := Notification{
+		Type:    OrderUpdatedNotification,
+		Payload: payload,
+	}
+	return h.BroadcastNotification(notification)
+}
+
 func (h *Hub) GetClientCount() int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
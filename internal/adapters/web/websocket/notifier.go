@@ -25,4 +25,35 @@ func (n *Notifier) NotifyOrderClaimed(payload notification.OrderClaimedPayload)
 	return n.hub.NotifyOrderClaimed(wsPayload)
 }
 
+func (n *Notifier) NotifyOrderCancelled(payload notification.OrderCancelledPayload) error {
+	wsPayload := OrderCancelledPayload{
+		OrderID:        payload.OrderID,
+		UserID:         payload.UserID,
+		CancelledBy:    payload.CancelledBy,
+		Reason:         payload.Reason,
+		PreviousStatus: payload.PreviousStatus,
+		RefundStatus:   payload.RefundStatus,
+		RefundAmount:   payload.RefundAmount,
+		CancelledAt:    payload.CancelledAt,
+	}
+
+	return n.hub.NotifyOrderCancelled(wsPayload)
+}
+
+func (n *Notifier) NotifyOrderUpdated(payload notification.OrderUpdatedPayload) error {
+	wsPayload := OrderUpdatedPayload{
+		OrderID:        payload.OrderID,
+		UserID:         payload.UserID,
+		Status:         payload.Status,
+		PreviousStatus: payload.PreviousStatus,
+		StatusMessage:  payload.StatusMessage,
+		PauseReason:    payload.PauseReason,
+		ResumeAt:       payload.ResumeAt,
+		Version:        payload.Version,
+		UpdatedAt:      payload.UpdatedAt,
+	}
+
+	return n.hub.NotifyOrderUpdated(wsPayload)
+}
+
 var _ notification.Service = (*Notifier)(nil)
@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"context"
+	"log"
+
+	cartUsecase "yego/internal/usecases/cart"
+	orderUsecase "yego/internal/usecases/order"
+	subscriptionUsecase "yego/internal/usecases/subscription"
+)
+
+// ResumePausedOrders restores paused orders whose resume time has passed
+func ResumePausedOrders(usecase orderUsecase.ResumeDueUsecase) Job {
+	return Job{
+		Name: "resume-paused-orders",
+		Run: func(ctx context.Context) error {
+			resumed, err := usecase.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if resumed > 0 {
+				log.Printf("Resumed %d paused orders", resumed)
+			}
+			return nil
+		},
+	}
+}
+
+// RunSubscriptions places the orders of subscriptions whose next run has passed
+func RunSubscriptions(usecase subscriptionUsecase.RunDueUsecase) Job {
+	return Job{
+		Name: "run-subscriptions",
+		Run: func(ctx context.Context) error {
+			created, err := usecase.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if created > 0 {
+				log.Printf("Created %d subscription orders", created)
+			}
+			return nil
+		},
+	}
+}
+
+// ExpireCarts expires the carts abandoned for longer than the cart TTL
+func ExpireCarts(usecase cartUsecase.ExpireStaleUsecase) Job {
+	return Job{
+		Name: "expire-carts",
+		Run: func(ctx context.Context) error {
+			expired, err := usecase.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if expired > 0 {
+				log.Printf("Expired %d inactive carts", expired)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of background work run periodically
+type Job struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Start runs each job once per interval in its own goroutine until ctx is cancelled.
+// A failing run is logged and retried on the next tick.
+func Start(ctx context.Context, interval time.Duration, jobs ...Job) {
+	for _, job := range jobs {
+		go run(ctx, interval, job)
+	}
+}
+
+func run(ctx context.Context, interval time.Duration, job Job) {
+	log.Printf("Worker %s started (every %s)", job.Name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Worker %s stopped", job.Name)
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil {
+				log.Printf("Worker %s failed: %v", job.Name, err)
+			}
+		}
+	}
+}
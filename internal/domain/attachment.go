@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// AttachmentKind tells what an order attachment proves
+type AttachmentKind string
+
+const (
+	AttachmentPhoto     AttachmentKind = "PHOTO"     // doorstep photo
+	AttachmentSignature AttachmentKind = "SIGNATURE" // signature captured on the driver's device
+)
+
+// IsValidAttachmentKind checks if a kind string is valid
+func IsValidAttachmentKind(kind string) bool {
+	return kind == string(AttachmentPhoto) || kind == string(AttachmentSignature)
+}
+
+// AttachmentContentTypes maps the accepted image types to their file extension
+var AttachmentContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Attachment is a proof-of-delivery file stored outside the database
+type Attachment struct {
+	ID          string         `json:"id"`
+	OrderID     string         `json:"order_id"`
+	Kind        AttachmentKind `json:"kind"`
+	StorageKey  string         `json:"-"`
+	ContentType string         `json:"content_type"`
+	SizeBytes   int64          `json:"size_bytes"`
+	Checksum    string         `json:"checksum_sha256"`
+	UploadedBy  *string        `json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
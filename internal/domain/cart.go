@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// CartStatus represents the lifecycle state of a cart
+type CartStatus string
+
+const (
+	CartOpen      CartStatus = "OPEN"
+	CartSubmitted CartStatus = "SUBMITTED" // turned into OrderID
+	CartExpired   CartStatus = "EXPIRED"   // abandoned past the inactivity limit
+)
+
+// Cart is a customer's draft order, kept on the server until it is submitted
+type Cart struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Data        *OrderData `json:"data"`
+	Currency    string     `json:"currency"`
+	Status      CartStatus `json:"status"`
+	OrderID     *string    `json:"order_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"` // last activity
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ExpiredAt   *time.Time `json:"expired_at,omitempty"`
+}
+
+// DataJSON returns the cart items as JSON bytes for database storage
+func (c *Cart) DataJSON() ([]byte, error) {
+	if c.Data == nil {
+		return json.Marshal(OrderData{Items: []OrderItem{}})
+	}
+	return json.Marshal(c.Data)
+}
+
+// Items returns the items in the cart
+func (c *Cart) Items() []OrderItem {
+	if c.Data == nil {
+		return nil
+	}
+	return c.Data.Items
+}
+
+// CartItemKey identifies an item in a cart: its product code, or its name for
+// products without one
+func CartItemKey(item OrderItem) string {
+	if item.Code != "" {
+		return item.Code
+	}
+	return item.Name
+}
+
+// FindItem returns the index of the item with key, case-insensitive, or -1
+func (c *Cart) FindItem(key string) int {
+	key = strings.TrimSpace(key)
+	for i, item := range c.Items() {
+		if strings.EqualFold(CartItemKey(item), key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetItem replaces the item with the same key, or adds it
+func (c *Cart) SetItem(item OrderItem) {
+	if c.Data == nil {
+		c.Data = &OrderData{Items: []OrderItem{}}
+	}
+	if i := c.FindItem(CartItemKey(item)); i >= 0 {
+		c.Data.Items[i] = item
+		return
+	}
+	c.Data.Items = append(c.Data.Items, item)
+}
+
+// RemoveItem takes the item with key out of the cart, reporting whether it was there
+func (c *Cart) RemoveItem(key string) bool {
+	i := c.FindItem(key)
+	if i < 0 {
+		return false
+	}
+	c.Data.Items = append(c.Data.Items[:i], c.Data.Items[i+1:]...)
+	return true
+}
+
+// ExpiresAt returns when an open cart expires without further activity
+func (c *Cart) ExpiresAt(ttl time.Duration) time.Time {
+	return c.UpdatedAt.Add(ttl)
+}
+
+// IsExpiredAt reports whether an open cart has been inactive for longer than ttl at t
+func (c *Cart) IsExpiredAt(t time.Time, ttl time.Duration) bool {
+	return c.Status == CartOpen && !t.Before(c.ExpiresAt(ttl))
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Currency codes (ISO 4217) accepted for orders and payments
+const (
+	CurrencyARS = "ARS"
+	CurrencyUSD = "USD"
+)
+
+// DefaultCurrency is used when the settings carry no currency
+const DefaultCurrency = CurrencyARS
+
+// ErrCurrencyMismatch is returned when amounts in different currencies would be combined
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// SupportedCurrencies lists the currencies orders can be priced and paid in
+var SupportedCurrencies = []string{CurrencyARS, CurrencyUSD}
+
+// NormalizeCurrency upper-cases and trims a currency code
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidCurrency checks if a currency code is supported
+func IsValidCurrency(code string) bool {
+	for _, c := range SupportedCurrencies {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// DeliveryCodeLength is the number of digits in a delivery code
+	DeliveryCodeLength = 6
+	// DeliveryCodeMaxAttempts is how many wrong codes lock verification
+	DeliveryCodeMaxAttempts = 5
+	// DeliveryCodeLockout is how long verification stays locked after too many wrong codes
+	DeliveryCodeLockout = 15 * time.Minute
+)
+
+// DeliveryCode is the proof-of-delivery code the customer gives the courier
+type DeliveryCode struct {
+	OrderID             string     `json:"order_id"`
+	Code                string     `json:"code"`
+	FailedAttempts      int        `json:"failed_attempts"`       // since the last lockout
+	TotalFailedAttempts int        `json:"total_failed_attempts"` // over the life of the code
+	LockedUntil         *time.Time `json:"locked_until,omitempty"`
+	VerifiedAt          *time.Time `json:"verified_at,omitempty"`
+	OverrideBy          *string    `json:"override_by,omitempty"`
+	OverrideReason      *string    `json:"override_reason,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// NewDeliveryCode returns a random numeric code of DeliveryCodeLength digits
+func NewDeliveryCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < DeliveryCodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", DeliveryCodeLength, n.Int64()), nil
+}
+
+// IsLocked reports whether verification is locked at now
+func (c *DeliveryCode) IsLocked(now time.Time) bool {
+	return c.LockedUntil != nil && now.Before(*c.LockedUntil)
+}
+
+// Matches compares a candidate code in constant time. A code recorded only for an
+// admin override is empty and never matches.
+func (c *DeliveryCode) Matches(candidate string) bool {
+	if c.Code == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.Code), []byte(candidate)) == 1
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTimezone is used when the settings carry no timezone
+const DefaultTimezone = "America/Argentina/Buenos_Aires"
+
+// slotTimeLayout is the wall-clock format of slot start and end times
+const slotTimeLayout = "15:04"
+
+// DeliverySlotDefinition describes a recurring weekly delivery window
+type DeliverySlotDefinition struct {
+	Weekday       int    `json:"weekday"`        // 0 = Sunday ... 6 = Saturday
+	Start         string `json:"start"`          // HH:MM, local time
+	End           string `json:"end"`            // HH:MM, local time
+	Capacity      int    `json:"capacity"`       // max orders in the window
+	CutoffMinutes int    `json:"cutoff_minutes"` // booking closes this long before Start
+}
+
+// Validate checks the definition is well formed
+func (d DeliverySlotDefinition) Validate() error {
+	if d.Weekday < 0 || d.Weekday > 6 {
+		return fmt.Errorf("weekday %d must be between 0 (Sunday) and 6 (Saturday)", d.Weekday)
+	}
+	start, err := time.Parse(slotTimeLayout, d.Start)
+	if err != nil {
+		return fmt.Errorf("start %q must be HH:MM", d.Start)
+	}
+	end, err := time.Parse(slotTimeLayout, d.End)
+	if err != nil {
+		return fmt.Errorf("end %q must be HH:MM", d.End)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("slot %s-%s must end after it starts", d.Start, d.End)
+	}
+	if d.Capacity <= 0 {
+		return errors.New("capacity must be positive")
+	}
+	if d.CutoffMinutes < 0 {
+		return errors.New("cutoff_minutes cannot be negative")
+	}
+	return nil
+}
+
+// ValidateDeliverySlots checks every definition and rejects overlapping slots on the same weekday
+func ValidateDeliverySlots(definitions []DeliverySlotDefinition) error {
+	for i, d := range definitions {
+		if err := d.Validate(); err != nil {
+			return fmt.Errorf("slot %d: %w", i, err)
+		}
+		for _, other := range definitions[:i] {
+			// HH:MM strings compare in time order
+			if other.Weekday == d.Weekday && d.Start < other.End && other.Start < d.End {
+				return fmt.Errorf("slot %d: %s-%s overlaps %s-%s", i, d.Start, d.End, other.Start, other.End)
+			}
+		}
+	}
+	return nil
+}
+
+// DeliverySlot is a concrete occurrence of a slot definition on a given date
+type DeliverySlot struct {
+	Date     string    `json:"date"` // YYYY-MM-DD
+	Start    string    `json:"start"`
+	End      string    `json:"end"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	CutoffAt time.Time `json:"cutoff_at"`
+	Capacity int       `json:"capacity"`
+}
+
+// Label returns the window as shown to customers, e.g. "09:00 - 12:00"
+func (s DeliverySlot) Label() string {
+	return s.Start + " - " + s.End
+}
+
+// DeliverySlotsForDate expands the definitions that apply to the weekday of date,
+// which must be midnight in loc
+func DeliverySlotsForDate(definitions []DeliverySlotDefinition, date time.Time, loc *time.Location) []DeliverySlot {
+	slots := make([]DeliverySlot, 0)
+	for _, d := range definitions {
+		if time.Weekday(d.Weekday) != date.Weekday() {
+			continue
+		}
+		start, startErr := time.Parse(slotTimeLayout, d.Start)
+		end, endErr := time.Parse(slotTimeLayout, d.End)
+		if startErr != nil || endErr != nil {
+			continue
+		}
+		startsAt := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+		endsAt := time.Date(date.Year(), date.Month(), date.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+		slots = append(slots, DeliverySlot{
+			Date:     date.Format("2006-01-02"),
+			Start:    d.Start,
+			End:      d.End,
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+			CutoffAt: startsAt.Add(-time.Duration(d.CutoffMinutes) * time.Minute),
+			Capacity: d.Capacity,
+		})
+	}
+	return slots
+}
+
+// DeliverySlotReservation holds one order's place in a delivery slot
+type DeliverySlotReservation struct {
+	ID        string    `json:"id"`
+	OrderID   *string   `json:"order_id,omitempty"`
+	Date      string    `json:"date"`
+	Start     string    `json:"start"`
+	End       string    `json:"end"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ModificationRequestStatus represents the review state of a modification request
+type ModificationRequestStatus string
+
+const (
+	ModificationRequestPending  ModificationRequestStatus = "PENDING"
+	ModificationRequestApproved ModificationRequestStatus = "APPROVED"
+	ModificationRequestRejected ModificationRequestStatus = "REJECTED"
+)
+
+// ModificationRequest is a customer's proposal to change the items of an order.
+// Items holds the full proposed item list and OriginalItems the order items at the
+// time of the request; the live order data is only replaced on approval.
+type ModificationRequest struct {
+	ID              string                    `json:"id"`
+	OrderID         string                    `json:"order_id"`
+	UserID          string                    `json:"user_id"`
+	PreviousStatus  OrderStatus               `json:"previous_status"`
+	OriginalItems   []OrderItem               `json:"original_items"`
+	Items           []OrderItem               `json:"items"`
+	Status          ModificationRequestStatus `json:"status"`
+	ReviewedBy      *string                   `json:"reviewed_by,omitempty"`
+	ReviewNote      *string                   `json:"review_note,omitempty"`
+	PriceDifference *Money                    `json:"price_difference,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+	ResolvedAt      *time.Time                `json:"resolved_at,omitempty"`
+}
+
+// AllowedOrderStatuses returns the statuses the order may move to while this request
+// is pending: back to the status it had when the request was made, or cancelled
+func (r *ModificationRequest) AllowedOrderStatuses() []OrderStatus {
+	return []OrderStatus{r.PreviousStatus, StatusCancelled}
+}
+
+// Changes returns the diff between the original and the proposed items
+func (r *ModificationRequest) Changes() []OrderItemChange {
+	return DiffOrderItems(r.OriginalItems, r.Items)
+}
+
+// OrderItemChangeType describes how an item differs between two item lists
+type OrderItemChangeType string
+
+const (
+	OrderItemAdded           OrderItemChangeType = "added"
+	OrderItemRemoved         OrderItemChangeType = "removed"
+	OrderItemQuantityChanged OrderItemChangeType = "quantity_changed"
+)
+
+// OrderItemChange is a single line of the diff between two item lists
+type OrderItemChange struct {
+	Change           OrderItemChangeType `json:"change"`
+	Code             string              `json:"code,omitempty"`
+	Name             string              `json:"name"`
+	Price            Money               `json:"price"`
+	PreviousQuantity int                 `json:"previous_quantity"`
+	NewQuantity      int                 `json:"new_quantity"`
+}
+
+// Key identifies an item within an order: its product code, or its name when there is no code
+func (i OrderItem) Key() string {
+	if i.Code != "" {
+		return "code:" + strings.ToLower(strings.TrimSpace(i.Code))
+	}
+	return "name:" + strings.ToLower(strings.TrimSpace(i.Name))
+}
+
+// DiffOrderItems lists the items added, removed or with a different quantity in after compared to before
+func DiffOrderItems(before, after []OrderItem) []OrderItemChange {
+	previous := make(map[string]OrderItem, len(before))
+	for _, item := range before {
+		previous[item.Key()] = item
+	}
+
+	changes := make([]OrderItemChange, 0)
+	seen := make(map[string]bool, len(after))
+	for _, item := range after {
+		key := item.Key()
+		seen[key] = true
+		old, existed := previous[key]
+		switch {
+		case !existed:
+			changes = append(changes, OrderItemChange{
+				Change:      OrderItemAdded,
+				Code:        item.Code,
+				Name:        item.Name,
+				Price:       item.Price,
+				NewQuantity: item.Quantity,
+			})
+		case old.Quantity != item.Quantity:
+			changes = append(changes, OrderItemChange{
+				Change:           OrderItemQuantityChanged,
+				Code:             item.Code,
+				Name:             item.Name,
+				Price:            item.Price,
+				PreviousQuantity: old.Quantity,
+				NewQuantity:      item.Quantity,
+			})
+		}
+	}
+
+	for _, item := range before {
+		if !seen[item.Key()] {
+			changes = append(changes, OrderItemChange{
+				Change:           OrderItemRemoved,
+				Code:             item.Code,
+				Name:             item.Name,
+				Price:            item.Price,
+				PreviousQuantity: item.Quantity,
+			})
+		}
+	}
+
+	return changes
+}
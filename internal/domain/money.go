@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor units (cents). It is stored as NUMERIC(12,2) and
+// encoded in JSON as a plain decimal number, e.g. 1234.50, so the wire format
+// of amounts didn't change when they stopped being float64.
+type Money int64
+
+// MoneyFromUnits converts a whole amount in major units, e.g. 500 pesos
+func MoneyFromUnits(units int64) Money {
+	return Money(units * 100)
+}
+
+// MoneyFromFloat converts a float amount, rounding half away from zero to the cent.
+// Only for values that are inherently inexact, like a rate times a distance.
+func MoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// ParseMoney parses a decimal amount such as "1234.5", "-3" or "10.005" exactly.
+// Digits past the cents round half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	for _, part := range []string{whole, fraction} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return 0, fmt.Errorf("invalid amount %q", s)
+			}
+		}
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, fmt.Errorf("amount %q out of range", s)
+	}
+
+	cents := int64(0)
+	for i := 0; i < 2; i++ {
+		cents *= 10
+		if i < len(fraction) {
+			cents += int64(fraction[i] - '0')
+		}
+	}
+	if len(fraction) > 2 && fraction[2] >= '5' {
+		cents++
+	}
+
+	m := Money(units*100 + cents)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// Float64 returns the amount in major units, for APIs that only take floats
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount with two decimals, e.g. "-12.30"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Mul multiplies the amount by a quantity
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// MulFloat multiplies the amount by a factor, rounding to the cent
+func (m Money) MulFloat(factor float64) Money {
+	return Money(math.Round(float64(m) * factor))
+}
+
+// MinMoney returns the smaller of two amounts
+func MinMoney(a, b Money) Money {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// MarshalJSON encodes the amount as a decimal number
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a decimal number or a quoted decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	if strings.ContainsAny(text, "eE") {
+		// Exponent notation isn't exact decimal text, go through float
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("invalid amount %s", data)
+		}
+		*m = MoneyFromFloat(f)
+		return nil
+	}
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as decimal text for NUMERIC columns
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a NUMERIC (text), float or integer column
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		parsed, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case string:
+		parsed, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	case int64:
+		*m = MoneyFromUnits(v)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+}
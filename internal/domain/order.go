@@ -2,6 +2,7 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -41,13 +42,61 @@ func IsValidStatus(s string) bool {
 	return false
 }
 
+// statusTransitions lists, for each status, the statuses an order may move to next.
+// PAUSED and MODIFICATION_REQUESTED return to one of the active states they were entered from.
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	StatusCreated:               {StatusConfirmed, StatusCancelled, StatusModificationRequested},
+	StatusConfirmed:             {StatusPreparing, StatusPaused, StatusCancelled, StatusModificationRequested},
+	StatusPreparing:             {StatusOnTheWay, StatusPaused, StatusCancelled},
+	StatusOnTheWay:              {StatusDelivered, StatusPaused},
+	StatusDelivered:             {},
+	StatusCancelled:             {},
+	StatusPaused:                {StatusConfirmed, StatusPreparing, StatusOnTheWay, StatusCancelled},
+	StatusModificationRequested: {StatusCreated, StatusConfirmed, StatusCancelled},
+}
+
+// NextStatuses returns the statuses reachable from the given status
+func NextStatuses(from OrderStatus) []OrderStatus {
+	return statusTransitions[from]
+}
+
+// CanTransition checks if an order may move from one status to another
+func CanTransition(from, to OrderStatus) bool {
+	for _, s := range statusTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinishedStatus reports whether an order in this status is over: it can't
+// move to any other status
+func IsFinishedStatus(s OrderStatus) bool {
+	return len(statusTransitions[s]) == 0
+}
+
+// StatusesByFinished returns the valid statuses that are finished, or the ones
+// that are still active
+func StatusesByFinished(finished bool) []OrderStatus {
+	var statuses []OrderStatus
+	for _, s := range ValidStatuses {
+		if IsFinishedStatus(s) == finished {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
 // OrderItem represents a single item in an order
 type OrderItem struct {
-	Code     string  `json:"code,omitempty"` // product code, optional
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
-	Weight   *int    `json:"weight,omitempty"` // weight in grams, optional
+	Code     string   `json:"code,omitempty"` // product code, optional
+	Name     string   `json:"name"`
+	Price    Money    `json:"price"`
+	Quantity int      `json:"quantity"`
+	Weight   *int     `json:"weight,omitempty"`   // weight in grams, optional
+	Category string   `json:"category,omitempty"` // catalog category, picks the IVA rate
+	TaxRate  *float64 `json:"tax_rate,omitempty"` // IVA percent from the catalog, overrides the category
 }
 
 // OrderData represents the data/items in an order
@@ -64,8 +113,27 @@ type Order struct {
 	StatusMessage *string     `json:"status_message,omitempty"`
 	ETA           string      `json:"eta"`
 	Data          *OrderData  `json:"data,omitempty"`
+	Version       int         `json:"version"`
 	CreatedAt     time.Time   `json:"created_at"`
 	UpdatedAt     time.Time   `json:"updated_at"`
+
+	// Structured delivery window; ETA above remains the display label
+	ETAFrom *time.Time `json:"eta_from,omitempty"`
+	ETATo   *time.Time `json:"eta_to,omitempty"`
+
+	// Set while the order is paused
+	PausedFromStatus *OrderStatus `json:"paused_from_status,omitempty"`
+	PauseReason      *string      `json:"pause_reason,omitempty"`
+	ResumeAt         *time.Time   `json:"resume_at,omitempty"`
+
+	// Set on orders materialized from a recurring subscription
+	SubscriptionID *string `json:"subscription_id,omitempty"`
+
+	// Priced snapshot, set once the order is claimed or confirmed
+	Pricing *OrderPricing `json:"pricing,omitempty"`
+
+	// Currency the order is priced and paid in
+	Currency string `json:"currency"`
 }
 
 // DataJSON returns the Data field as JSON bytes for database storage
@@ -102,6 +170,84 @@ type OrderToken struct {
 	CreatedAt       time.Time  `json:"created_at"`
 }
 
+// AllowedNextStatuses returns the statuses this order may move to next. A paused
+// order only resumes to the status it was paused from, or is cancelled.
+func (o *Order) AllowedNextStatuses() []OrderStatus {
+	if o.Status == StatusPaused {
+		return []OrderStatus{o.ResumeStatus(), StatusCancelled}
+	}
+	return NextStatuses(o.Status)
+}
+
+// CanTransitionTo checks if the order may move to the given status
+func (o *Order) CanTransitionTo(next OrderStatus) bool {
+	for _, s := range o.AllowedNextStatuses() {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Pause moves the order to PAUSED, remembering the status to restore on resume.
+// Pausing an already paused order only updates the reason and resume time.
+func (o *Order) Pause(reason *string, resumeAt *time.Time) {
+	if o.Status != StatusPaused {
+		previous := o.Status
+		o.PausedFromStatus = &previous
+	}
+	o.Status = StatusPaused
+	o.PauseReason = reason
+	o.ResumeAt = resumeAt
+}
+
+// ResumeStatus returns the status a paused order goes back to. Orders paused
+// before the previous status was recorded fall back to CONFIRMED.
+func (o *Order) ResumeStatus() OrderStatus {
+	if o.PausedFromStatus != nil {
+		return *o.PausedFromStatus
+	}
+	return StatusConfirmed
+}
+
+// ClearPause drops the pause data once the order leaves PAUSED
+func (o *Order) ClearPause() {
+	o.PausedFromStatus = nil
+	o.PauseReason = nil
+	o.ResumeAt = nil
+}
+
+// IsLate reports whether an open order is past the end of its delivery window
+func (o *Order) IsLate(now time.Time) bool {
+	if o.ETATo == nil || o.Status == StatusDelivered || o.Status == StatusCancelled {
+		return false
+	}
+	return now.After(*o.ETATo)
+}
+
+// OrderVersionConflict reports that an order changed since it was read, so a write
+// based on the version read is refused
+type OrderVersionConflict struct {
+	OrderID  string
+	Expected int
+	Found    int
+}
+
+func (e *OrderVersionConflict) Error() string {
+	return fmt.Sprintf("order %s: expected version %d, found %d", e.OrderID, e.Expected, e.Found)
+}
+
+// OrderStatusEvent records a single status change of an order
+type OrderStatusEvent struct {
+	ID             string       `json:"id"`
+	OrderID        string       `json:"order_id"`
+	PreviousStatus *OrderStatus `json:"previous_status,omitempty"`
+	NewStatus      OrderStatus  `json:"new_status"`
+	ActorUserID    *string      `json:"actor_user_id,omitempty"`
+	Message        *string      `json:"message,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
 // StatusIndex returns the position of the current status in the workflow
 func (o *Order) StatusIndex() int {
 	for i, s := range ValidStatuses {
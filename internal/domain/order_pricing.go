@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DeliveryFeeBreakdown is the delivery fee as quoted from the settings at pricing time
+type DeliveryFeeBreakdown struct {
+	DistanceKm    float64 `json:"distance_km"`
+	TotalWeightG  int     `json:"total_weight_g"`
+	TotalWeightKg float64 `json:"total_weight_kg"`
+	BasePrice     Money   `json:"base_price"`
+	DistancePrice Money   `json:"distance_price"`
+	WeightPrice   Money   `json:"weight_price"`
+	TotalPrice    Money   `json:"total_price"`
+	Currency      string  `json:"currency,omitempty"`
+}
+
+// PricingLineKind groups the lines of a priced order
+type PricingLineKind string
+
+const (
+	PricingLineProduct   PricingLineKind = "PRODUCT"
+	PricingLineDelivery  PricingLineKind = "DELIVERY"
+	PricingLineSurcharge PricingLineKind = "SURCHARGE"
+	PricingLineDiscount  PricingLineKind = "DISCOUNT"
+)
+
+// PricingLine is one line of a priced order. Amount is Quantity x UnitPrice,
+// negative for discounts.
+type PricingLine struct {
+	Kind        PricingLineKind `json:"kind"`
+	Code        string          `json:"code,omitempty"`
+	Description string          `json:"description"`
+	Quantity    int             `json:"quantity"`
+	UnitPrice   Money           `json:"unit_price"`
+	Amount      Money           `json:"amount"`
+	TaxRate     float64         `json:"tax_rate"`
+	TaxAmount   Money           `json:"tax_amount"` // IVA included in Amount
+}
+
+// OrderDiscount is a single discount applied to an order
+type OrderDiscount struct {
+	Code        string `json:"code,omitempty"`
+	Description string `json:"description"`
+	Amount      Money  `json:"amount"` // positive, subtracted from the total
+}
+
+// OrderPricing is the priced snapshot of an order. Once stored it is what the
+// customer is charged, regardless of later settings changes.
+type OrderPricing struct {
+	Lines          []PricingLine         `json:"lines"`
+	Subtotal       Money                 `json:"subtotal"`
+	Delivery       *DeliveryFeeBreakdown `json:"delivery,omitempty"` // nil when no location was known
+	SurchargeTotal Money                 `json:"surcharge_total"`
+	Discounts      []OrderDiscount       `json:"discounts"`
+	DiscountTotal  Money                 `json:"discount_total"`
+	Total          Money                 `json:"total"`
+	Currency       string                `json:"currency,omitempty"` // empty on snapshots taken before currencies
+	Tax            *TaxBreakdown         `json:"tax,omitempty"`      // nil on snapshots taken before taxes
+	PricedAt       time.Time             `json:"priced_at"`
+}
+
+// DeliveryFee returns the delivery fee of the snapshot, zero without delivery
+func (p *OrderPricing) DeliveryFee() Money {
+	if p.Delivery == nil {
+		return 0
+	}
+	return p.Delivery.TotalPrice
+}
+
+// LinesOfKind returns the lines of one kind, in order
+func (p *OrderPricing) LinesOfKind(kind PricingLineKind) []PricingLine {
+	var lines []PricingLine
+	for _, line := range p.Lines {
+		if line.Kind == kind {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// PricingJSON returns the Pricing field as JSON bytes for database storage
+func (o *Order) PricingJSON() ([]byte, error) {
+	if o.Pricing == nil {
+		return nil, nil
+	}
+	return json.Marshal(o.Pricing)
+}
+
+// SetPricingFromJSON sets the Pricing field from JSON bytes
+func (o *Order) SetPricingFromJSON(data []byte) error {
+	if data == nil {
+		o.Pricing = nil
+		return nil
+	}
+	var pricing OrderPricing
+	if err := json.Unmarshal(data, &pricing); err != nil {
+		return err
+	}
+	o.Pricing = &pricing
+	return nil
+}
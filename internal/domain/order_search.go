@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Fields orders can be sorted by
+const (
+	OrderSortCreatedAt = "created_at"
+	OrderSortUpdatedAt = "updated_at"
+	OrderSortTotal     = "total"
+	OrderSortStatus    = "status"
+)
+
+// OrderSortFields lists the fields orders can be sorted by
+var OrderSortFields = []string{OrderSortCreatedAt, OrderSortUpdatedAt, OrderSortTotal, OrderSortStatus}
+
+// DefaultOrderSearchLimit is the page size when none is given
+const DefaultOrderSearchLimit = 50
+
+// OrderSearchFilter narrows and orders a search over orders. Zero values don't filter.
+// Pages are either Offset based or, when Cursor is set, continue after the last
+// order of the previous page, which stays stable while new orders arrive.
+type OrderSearchFilter struct {
+	Statuses    []OrderStatus
+	CreatedFrom *time.Time // inclusive
+	CreatedTo   *time.Time // exclusive
+	UserID      string
+	ProfileID   string // must be a UUID
+	Phone       string // part of the profile phone number
+	ItemCode    string // exact product code of any item
+	ItemName    string // part of the name of any item, case-insensitive
+	MinTotal    *Money
+	MaxTotal    *Money
+	Sort        string // one of the OrderSort fields, OrderSortCreatedAt by default
+	Ascending   bool
+	Limit       int
+	Offset      int
+	Cursor      string // NextCursor of the previous page
+}
+
+// OrderSearchResult is one page of orders
+type OrderSearchResult struct {
+	Orders     []*Order
+	Total      int    // orders matching the filter, across all pages
+	NextCursor string // empty on the last page
+}
+
+// IsValidOrderSort reports whether orders can be sorted by field
+func IsValidOrderSort(field string) bool {
+	for _, f := range OrderSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PromotionKind is how a promotion discounts an order
+type PromotionKind string
+
+const (
+	PromotionPercentage   PromotionKind = "PERCENTAGE"    // PercentOff of the products
+	PromotionFixed        PromotionKind = "FIXED"         // AmountOff the order
+	PromotionFreeDelivery PromotionKind = "FREE_DELIVERY" // the delivery fee
+	PromotionBuyXGetY     PromotionKind = "BUY_X_GET_Y"   // GetQuantity free per BuyQuantity of BuyCode
+)
+
+// Promotion is a discount rule customers redeem with its coupon code
+type Promotion struct {
+	ID                 string        `json:"id"`
+	Code               string        `json:"code"`
+	Description        string        `json:"description"`
+	Kind               PromotionKind `json:"kind"`
+	PercentOff         float64       `json:"percent_off"`
+	AmountOff          Money         `json:"amount_off"`
+	BuyCode            *string       `json:"buy_code,omitempty"`
+	BuyQuantity        int           `json:"buy_quantity"`
+	GetQuantity        int           `json:"get_quantity"`
+	MinOrderAmount     Money         `json:"min_order_amount"` // of the products, zero for no minimum
+	Currency           string        `json:"currency"`         // of AmountOff and MinOrderAmount
+	StartsAt           *time.Time    `json:"starts_at,omitempty"`
+	EndsAt             *time.Time    `json:"ends_at,omitempty"`
+	MaxUsesPerCustomer *int          `json:"max_uses_per_customer,omitempty"` // nil for unlimited
+	Active             bool          `json:"active"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+}
+
+// NormalizeCouponCode upper-cases and trims a coupon code, so codes match
+// regardless of how customers type them
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the promotion is well formed for its kind
+func (p *Promotion) Validate() error {
+	if p.Code == "" {
+		return fmt.Errorf("code is required")
+	}
+	switch p.Kind {
+	case PromotionPercentage:
+		if p.PercentOff <= 0 || p.PercentOff > 100 {
+			return fmt.Errorf("percent_off must be between 0 and 100")
+		}
+	case PromotionFixed:
+		if p.AmountOff <= 0 {
+			return fmt.Errorf("amount_off must be positive")
+		}
+	case PromotionFreeDelivery:
+	case PromotionBuyXGetY:
+		if p.BuyCode == nil || *p.BuyCode == "" {
+			return fmt.Errorf("buy_code is required")
+		}
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return fmt.Errorf("buy_quantity and get_quantity must be positive")
+		}
+	default:
+		return fmt.Errorf("kind %q must be PERCENTAGE, FIXED, FREE_DELIVERY or BUY_X_GET_Y", p.Kind)
+	}
+	if p.MinOrderAmount < 0 {
+		return fmt.Errorf("min_order_amount can't be negative")
+	}
+	if !IsValidCurrency(p.Currency) {
+		return fmt.Errorf("currency %q is not supported", p.Currency)
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+	if p.MaxUsesPerCustomer != nil && *p.MaxUsesPerCustomer <= 0 {
+		return fmt.Errorf("max_uses_per_customer must be positive")
+	}
+	return nil
+}
+
+// IsAvailableAt reports whether the promotion can be redeemed at t
+func (p *Promotion) IsAvailableAt(t time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !t.Before(*p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// PromotionRedemption records a promotion redeemed on an order
+type PromotionRedemption struct {
+	ID          string    `json:"id"`
+	PromotionID string    `json:"promotion_id"`
+	OrderID     string    `json:"order_id"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
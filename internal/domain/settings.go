@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Settings represents the application configuration
 type Settings struct {
@@ -12,10 +15,78 @@ type Settings struct {
 	DefaultMapLongitude float64   `json:"default_map_longitude"`
 	DefaultMapZoom      int       `json:"default_map_zoom"`
 	DefaultItemWeight   int       `json:"default_item_weight"` // in grams
-	DeliveryBasePrice   float64   `json:"delivery_base_price"`
-	DeliveryPricePerKm  float64   `json:"delivery_price_per_km"`
-	DeliveryPricePerKg  float64   `json:"delivery_price_per_kg"`
+	DeliveryBasePrice   Money     `json:"delivery_base_price"`
+	DeliveryPricePerKm  Money     `json:"delivery_price_per_km"`
+	DeliveryPricePerKg  Money     `json:"delivery_price_per_kg"`
 	ManagerCollectorID  *string   `json:"manager_collector_id,omitempty"` // MercadoPago collector ID for manager account
 	CreatedAt           time.Time `json:"created_at"`
 	UpdatedAt           time.Time `json:"updated_at"`
+
+	Timezone      string                   `json:"timezone"` // IANA name, used for delivery slot times
+	DeliverySlots []DeliverySlotDefinition `json:"delivery_slots"`
+
+	// Currency of the delivery prices and of orders that don't pick one
+	DefaultCurrency string `json:"default_currency"`
+
+	// Delivery prices of orders in other currencies, by currency code
+	CurrencyDeliveryPrices map[string]DeliveryPrices `json:"currency_delivery_prices"`
+
+	// IVA rates orders are broken down with
+	Tax TaxSettings `json:"tax"`
+}
+
+// Currency returns the business default currency, falling back to DefaultCurrency
+func (s *Settings) Currency() string {
+	if s.DefaultCurrency == "" {
+		return DefaultCurrency
+	}
+	return s.DefaultCurrency
+}
+
+// DeliveryPrices are the delivery fee parameters in one currency
+type DeliveryPrices struct {
+	BasePrice  Money `json:"base_price"`
+	PricePerKm Money `json:"price_per_km"`
+	PricePerKg Money `json:"price_per_kg"`
+}
+
+// DeliveryPricesIn returns the delivery prices of orders in currency: the delivery_*
+// prices for the default currency, else the ones set for it in CurrencyDeliveryPrices.
+// It reports false when the currency has no delivery prices.
+func (s *Settings) DeliveryPricesIn(currency string) (DeliveryPrices, bool) {
+	if currency == "" || currency == s.Currency() {
+		return DeliveryPrices{
+			BasePrice:  s.DeliveryBasePrice,
+			PricePerKm: s.DeliveryPricePerKm,
+			PricePerKg: s.DeliveryPricePerKg,
+		}, true
+	}
+	prices, ok := s.CurrencyDeliveryPrices[currency]
+	return prices, ok
+}
+
+// ValidateCurrencyDeliveryPrices checks every currency is supported and no price is negative
+func ValidateCurrencyDeliveryPrices(prices map[string]DeliveryPrices) error {
+	for currency, p := range prices {
+		if !IsValidCurrency(currency) {
+			return fmt.Errorf("unsupported currency %q", currency)
+		}
+		if p.BasePrice < 0 || p.PricePerKm < 0 || p.PricePerKg < 0 {
+			return fmt.Errorf("delivery prices in %s can't be negative", currency)
+		}
+	}
+	return nil
+}
+
+// Location returns the business timezone, falling back to DefaultTimezone
+func (s *Settings) Location() *time.Location {
+	name := s.Timezone
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
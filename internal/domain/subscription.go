@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SubscriptionStatus represents the lifecycle state of a recurring subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive    SubscriptionStatus = "ACTIVE"
+	SubscriptionPaused    SubscriptionStatus = "PAUSED"
+	SubscriptionCancelled SubscriptionStatus = "CANCELLED"
+)
+
+// RecurrenceFrequency is the unit a subscription repeats in
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// RecurrenceRule repeats every Interval units of Frequency, e.g. every 2 WEEKLY
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency `json:"frequency"`
+	Interval  int                 `json:"interval"`
+}
+
+// Validate checks the rule is well formed
+func (r RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	default:
+		return fmt.Errorf("frequency %q must be DAILY, WEEKLY or MONTHLY", r.Frequency)
+	}
+	if r.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	return nil
+}
+
+// Next returns the occurrence following t
+func (r RecurrenceRule) Next(t time.Time) time.Time {
+	switch r.Frequency {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, r.Interval)
+	case RecurrenceMonthly:
+		return t.AddDate(0, r.Interval, 0)
+	default:
+		return t.AddDate(0, 0, 7*r.Interval)
+	}
+}
+
+// NextAfter returns the first occurrence after now, starting from t.
+// Runs missed while the scheduler was down are skipped, not replayed.
+func (r RecurrenceRule) NextAfter(t time.Time, now time.Time) time.Time {
+	next := r.Next(t)
+	for !next.After(now) {
+		next = r.Next(next)
+	}
+	return next
+}
+
+// Subscription reorders a template basket for a profile on a recurring schedule
+type Subscription struct {
+	ID          string             `json:"id"`
+	UserID      string             `json:"user_id"`
+	ProfileID   string             `json:"profile_id"`
+	Data        *OrderData         `json:"data"`
+	ETA         string             `json:"eta"`
+	Recurrence  RecurrenceRule     `json:"recurrence"`
+	Status      SubscriptionStatus `json:"status"`
+	NextRunAt   time.Time          `json:"next_run_at"`
+	LastRunAt   *time.Time         `json:"last_run_at,omitempty"`
+	LastOrderID *string            `json:"last_order_id,omitempty"`
+	LastError   *string            `json:"last_error,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	CancelledAt *time.Time         `json:"cancelled_at,omitempty"`
+}
+
+// DataJSON returns the template data as JSON bytes for database storage
+func (s *Subscription) DataJSON() ([]byte, error) {
+	if s.Data == nil {
+		return json.Marshal(OrderData{Items: []OrderItem{}})
+	}
+	return json.Marshal(s.Data)
+}
+
+// SkipNext moves the next run one occurrence forward
+func (s *Subscription) SkipNext() {
+	s.NextRunAt = s.Recurrence.Next(s.NextRunAt)
+}
+
+// Resume reactivates a paused subscription. A next run that passed while paused
+// moves to the first occurrence after now.
+func (s *Subscription) Resume(now time.Time) {
+	s.Status = SubscriptionActive
+	if !s.NextRunAt.After(now) {
+		s.NextRunAt = s.Recurrence.NextAfter(s.NextRunAt, now)
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// IVA rates in percent
+const (
+	TaxRateGeneral = 21.0
+	TaxRateReduced = 10.5
+)
+
+// TaxSettings holds the IVA rates. Prices are tax-included, so the rates split each
+// amount into net and tax rather than adding to it.
+type TaxSettings struct {
+	DefaultRate   float64            `json:"default_rate"`   // products without a category rate, and surcharges
+	DeliveryRate  float64            `json:"delivery_rate"`  // the delivery fee
+	CategoryRates map[string]float64 `json:"category_rates"` // by catalog category, case-insensitive
+}
+
+// DefaultTaxSettings charges the general rate on everything
+func DefaultTaxSettings() TaxSettings {
+	return TaxSettings{
+		DefaultRate:   TaxRateGeneral,
+		DeliveryRate:  TaxRateGeneral,
+		CategoryRates: map[string]float64{},
+	}
+}
+
+// Validate checks every rate is a percentage
+func (t TaxSettings) Validate() error {
+	if !validTaxRate(t.DefaultRate) {
+		return fmt.Errorf("default_rate %v must be between 0 and 100", t.DefaultRate)
+	}
+	if !validTaxRate(t.DeliveryRate) {
+		return fmt.Errorf("delivery_rate %v must be between 0 and 100", t.DeliveryRate)
+	}
+	for category, rate := range t.CategoryRates {
+		if strings.TrimSpace(category) == "" {
+			return fmt.Errorf("category names can't be empty")
+		}
+		if !validTaxRate(rate) {
+			return fmt.Errorf("rate %v of category %q must be between 0 and 100", rate, category)
+		}
+	}
+	return nil
+}
+
+func validTaxRate(rate float64) bool {
+	return rate >= 0 && rate <= 100
+}
+
+// RateFor returns the IVA rate of an item: its own rate from the catalog, else the
+// rate of its category, else the default rate
+func (t TaxSettings) RateFor(item OrderItem) float64 {
+	if item.TaxRate != nil {
+		return *item.TaxRate
+	}
+	if item.Category != "" {
+		wanted := strings.ToLower(strings.TrimSpace(item.Category))
+		for category, rate := range t.CategoryRates {
+			if strings.ToLower(strings.TrimSpace(category)) == wanted {
+				return rate
+			}
+		}
+	}
+	return t.DefaultRate
+}
+
+// IncludedTax returns the tax contained in a tax-included amount at rate
+func IncludedTax(gross Money, rate float64) Money {
+	net := Money(math.Round(float64(gross) * 100 / (100 + rate)))
+	return gross - net
+}
+
+// TaxRateBreakdown is the part of an order taxed at one rate
+type TaxRateBreakdown struct {
+	Rate  float64 `json:"rate"`
+	Net   Money   `json:"net"`
+	Tax   Money   `json:"tax"`
+	Gross Money   `json:"gross"`
+}
+
+// TaxBreakdown is the fiscal breakdown of a priced order. Gross equals the order
+// total; Net plus Tax equals Gross.
+type TaxBreakdown struct {
+	Net   Money              `json:"net"`
+	Tax   Money              `json:"tax"`
+	Gross Money              `json:"gross"`
+	Rates []TaxRateBreakdown `json:"rates"` // highest rate first
+}
+
+// NewTaxBreakdown builds the breakdown from the gross amount taxed at each rate
+func NewTaxBreakdown(grossByRate map[float64]Money) *TaxBreakdown {
+	rates := make([]float64, 0, len(grossByRate))
+	for rate := range grossByRate {
+		rates = append(rates, rate)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(rates)))
+
+	breakdown := &TaxBreakdown{Rates: []TaxRateBreakdown{}}
+	for _, rate := range rates {
+		gross := grossByRate[rate]
+		if gross == 0 {
+			continue
+		}
+		tax := IncludedTax(gross, rate)
+		breakdown.Rates = append(breakdown.Rates, TaxRateBreakdown{
+			Rate:  rate,
+			Net:   gross - tax,
+			Tax:   tax,
+			Gross: gross,
+		})
+		breakdown.Net += gross - tax
+		breakdown.Tax += tax
+		breakdown.Gross += gross
+	}
+	return breakdown
+}
+
+// Split divides part of the order's gross, such as a payment or a refund, into net
+// and tax in the same proportion as the whole order
+func (b *TaxBreakdown) Split(amount Money) (net Money, tax Money) {
+	if b == nil || b.Gross == 0 {
+		return amount, 0
+	}
+	tax = Money(math.Round(float64(amount) * float64(b.Tax) / float64(b.Gross)))
+	return amount - tax, tax
+}
@@ -2,19 +2,36 @@ package domain
 
 import "time"
 
+// Transaction statuses recorded by the application. Payment statuses are
+// otherwise taken verbatim from the payment gateway.
+const (
+	TransactionStatusApproved = "approved"
+	TransactionStatusRefunded = "refunded"
+)
+
+// Transaction kinds. Tips are charged apart from the order so they can be paid out
+// to drivers, and never count towards what the order is paid.
+const (
+	TransactionKindPayment = "payment"
+	TransactionKindTip     = "tip"
+)
+
 // Transaction represents a payment transaction in the system
 type Transaction struct {
 	ID                string    `json:"id"`
 	OrderID           string    `json:"order_id"`
 	UserID            string    `json:"user_id"`
 	ProfileID         *string   `json:"profile_id,omitempty"`
-	Amount            float64   `json:"amount"`
+	Amount            Money     `json:"amount"`
 	Currency          string    `json:"currency"`
 	Status            string    `json:"status"`
+	Kind              string    `json:"kind"` // TransactionKindPayment or TransactionKindTip
 	PaymentID         *int      `json:"payment_id,omitempty"`
 	GatewayPaymentID  *string   `json:"gateway_payment_id,omitempty"`
 	CollectorID       *string   `json:"collector_id,omitempty"`
 	Description       *string   `json:"description,omitempty"`
+	NetAmount         *Money    `json:"net_amount,omitempty"` // Amount without IVA, nil before taxes were recorded
+	TaxAmount         *Money    `json:"tax_amount,omitempty"` // IVA contained in Amount
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
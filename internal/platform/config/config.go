@@ -1,7 +1,11 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -19,6 +23,24 @@ type ConfigurationService struct {
 	AuthAPIURL              string
 	MPAccessToken            string
 	MPCheckoutProAccessToken string
+	WorkerInterval           time.Duration
+	CartTTL                  time.Duration // open carts expire after this long without activity
+
+	// Users allowed to act beyond the admin panel's defaults, e.g. deliver an order
+	// without its code. There are no roles yet; see IsAdmin.
+	AdminUserIDs []string
+
+	// Attachment storage: "local" keeps files under StorageLocalDir, "s3" uses an
+	// S3-compatible bucket (AWS S3 or MinIO)
+	StorageBackend     string
+	StorageLocalDir    string
+	S3Endpoint         string
+	S3Region           string
+	S3Bucket           string
+	S3AccessKey        string
+	S3SecretKey        string
+	S3ForcePathStyle   bool
+	AttachmentMaxBytes int64
 }
 
 var instance *ConfigurationService
@@ -40,14 +62,77 @@ func GetInstance() *ConfigurationService {
 			AuthAPIURL:               getEnvOrDefault("AUTH_API_URL", "http://localhost:8082"),
 			MPAccessToken:            getEnvOrDefault("MP_ACCESS_TOKEN", ""),
 			MPCheckoutProAccessToken: getEnvOrDefault("MP_CHECKOUT_PRO_ACCESS_TOKEN", ""),
+			WorkerInterval:           getDurationOrDefault("WORKER_INTERVAL", time.Minute),
+			CartTTL:                  getDurationOrDefault("CART_TTL", 72*time.Hour),
+			AdminUserIDs:             getListOrDefault("ADMIN_USER_IDS"),
+
+			StorageBackend:     getEnvOrDefault("STORAGE_BACKEND", "local"),
+			StorageLocalDir:    getEnvOrDefault("STORAGE_LOCAL_DIR", "./data/attachments"),
+			S3Endpoint:         getEnvOrDefault("S3_ENDPOINT", "http://localhost:9000"),
+			S3Region:           getEnvOrDefault("S3_REGION", "us-east-1"),
+			S3Bucket:           getEnvOrDefault("S3_BUCKET", "yego-attachments"),
+			S3AccessKey:        getEnvOrDefault("S3_ACCESS_KEY", ""),
+			S3SecretKey:        getEnvOrDefault("S3_SECRET_KEY", ""),
+			S3ForcePathStyle:   getEnvOrDefault("S3_FORCE_PATH_STYLE", "true") == "true",
+			AttachmentMaxBytes: getInt64OrDefault("ATTACHMENT_MAX_BYTES", 10<<20),
 		}
 	}
 	return instance
 }
 
+// IsAdmin reports whether the user is listed in ADMIN_USER_IDS
+func (c *ConfigurationService) IsAdmin(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	for _, id := range c.AdminUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getListOrDefault splits a comma-separated variable, dropping empty entries
+func getListOrDefault(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getInt64OrDefault(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
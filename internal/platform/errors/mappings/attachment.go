@@ -0,0 +1,72 @@
+package mappings
+
+import "net/http"
+
+// Attachment-related error mappings
+var (
+	AttachmentCreateError = ErrorDetails{
+		Code:       "attachment:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to store attachment",
+	}
+
+	AttachmentGetError = ErrorDetails{
+		Code:       "attachment:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get attachment",
+	}
+
+	AttachmentDeleteError = ErrorDetails{
+		Code:       "attachment:delete-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to delete attachment",
+	}
+
+	AttachmentUploadForbiddenError = ErrorDetails{
+		Code:       "attachment:upload-forbidden",
+		StatusCode: http.StatusForbidden,
+		Message:    "only an admin may attach proof of delivery",
+	}
+
+	AttachmentNotAllowedError = ErrorDetails{
+		Code:       "attachment:not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "proof of delivery can only be attached once the order is on the way",
+	}
+
+	AttachmentNotFoundError = ErrorDetails{
+		Code:       "attachment:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "attachment not found",
+	}
+
+	AttachmentInvalidIDError = ErrorDetails{
+		Code:       "attachment:invalid-id",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid attachment ID",
+	}
+
+	AttachmentInvalidKindError = ErrorDetails{
+		Code:       "attachment:invalid-kind",
+		StatusCode: http.StatusBadRequest,
+		Message:    "kind must be PHOTO or SIGNATURE",
+	}
+
+	AttachmentMissingFileError = ErrorDetails{
+		Code:       "attachment:missing-file",
+		StatusCode: http.StatusBadRequest,
+		Message:    "a file is required",
+	}
+
+	AttachmentTooLargeError = ErrorDetails{
+		Code:       "attachment:too-large",
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Message:    "attachment exceeds the maximum size",
+	}
+
+	AttachmentUnsupportedTypeError = ErrorDetails{
+		Code:       "attachment:unsupported-type",
+		StatusCode: http.StatusUnsupportedMediaType,
+		Message:    "attachment must be a JPEG, PNG or WebP image",
+	}
+)
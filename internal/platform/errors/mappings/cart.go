@@ -0,0 +1,66 @@
+package mappings
+
+import "net/http"
+
+// Cart-related error mappings
+var (
+	CartCreateError = ErrorDetails{
+		Code:       "cart:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create cart",
+	}
+
+	CartGetError = ErrorDetails{
+		Code:       "cart:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get cart",
+	}
+
+	CartUpdateError = ErrorDetails{
+		Code:       "cart:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update cart",
+	}
+
+	CartAlreadyOpenError = ErrorDetails{
+		Code:       "cart:already-open",
+		StatusCode: http.StatusConflict,
+		Message:    "user already has an open cart",
+	}
+
+	CartNotFoundError = ErrorDetails{
+		Code:       "cart:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "no open cart",
+	}
+
+	CartNotOpenError = ErrorDetails{
+		Code:       "cart:not-open",
+		StatusCode: http.StatusConflict,
+		Message:    "cart was already submitted or expired",
+	}
+
+	CartItemNotFoundError = ErrorDetails{
+		Code:       "cart:item-not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "item is not in the cart",
+	}
+
+	CartProductNotFoundError = ErrorDetails{
+		Code:       "cart:product-not-found",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "product is not in the catalog",
+	}
+
+	CartInvalidItemError = ErrorDetails{
+		Code:       "cart:invalid-item",
+		StatusCode: http.StatusBadRequest,
+		Message:    "items need a code or name and a positive quantity",
+	}
+
+	CartEmptyError = ErrorDetails{
+		Code:       "cart:empty",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "cart has no items",
+	}
+)
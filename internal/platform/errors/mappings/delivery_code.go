@@ -0,0 +1,60 @@
+package mappings
+
+import "net/http"
+
+// Delivery code error mappings
+var (
+	DeliveryCodeCreateError = ErrorDetails{
+		Code:       "delivery-code:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to issue delivery code",
+	}
+
+	DeliveryCodeGetError = ErrorDetails{
+		Code:       "delivery-code:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get delivery code",
+	}
+
+	DeliveryCodeUpdateError = ErrorDetails{
+		Code:       "delivery-code:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update delivery code",
+	}
+
+	DeliveryCodeNotIssuedError = ErrorDetails{
+		Code:       "delivery-code:not-issued",
+		StatusCode: http.StatusConflict,
+		Message:    "order has no delivery code; an admin override is required",
+	}
+
+	DeliveryCodeRequiredError = ErrorDetails{
+		Code:       "delivery-code:required",
+		StatusCode: http.StatusBadRequest,
+		Message:    "delivery code is required to mark the order delivered",
+	}
+
+	DeliveryCodeOverrideForbiddenError = ErrorDetails{
+		Code:       "delivery-code:override-forbidden",
+		StatusCode: http.StatusForbidden,
+		Message:    "only an admin may deliver an order without its code",
+	}
+
+	DeliveryCodeInvalidError = ErrorDetails{
+		Code:       "delivery-code:invalid",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "delivery code is incorrect",
+	}
+
+	DeliveryCodeLockedError = ErrorDetails{
+		Code:       "delivery-code:locked",
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "too many wrong delivery codes, try again later",
+	}
+
+	DeliveryCodeNotAvailableError = ErrorDetails{
+		Code:       "delivery-code:not-available",
+		StatusCode: http.StatusNotFound,
+		Message:    "delivery code is shown once the order is on the way",
+	}
+)
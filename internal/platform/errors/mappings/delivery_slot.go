@@ -0,0 +1,60 @@
+package mappings
+
+import "net/http"
+
+// Delivery slot error mappings
+var (
+	DeliverySlotGetError = ErrorDetails{
+		Code:       "delivery-slot:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get delivery slots",
+	}
+
+	DeliverySlotReserveError = ErrorDetails{
+		Code:       "delivery-slot:reserve-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to reserve delivery slot",
+	}
+
+	DeliverySlotReleaseError = ErrorDetails{
+		Code:       "delivery-slot:release-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to release delivery slot",
+	}
+
+	DeliverySlotInvalidDateError = ErrorDetails{
+		Code:       "delivery-slot:invalid-date",
+		StatusCode: http.StatusBadRequest,
+		Message:    "date must be formatted as YYYY-MM-DD",
+	}
+
+	DeliverySlotNotFoundError = ErrorDetails{
+		Code:       "delivery-slot:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "no delivery slot starts at that time on that date",
+	}
+
+	DeliverySlotReservationNotFoundError = ErrorDetails{
+		Code:       "delivery-slot:reservation-not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "delivery slot reservation not found",
+	}
+
+	DeliverySlotFullError = ErrorDetails{
+		Code:       "delivery-slot:full",
+		StatusCode: http.StatusConflict,
+		Message:    "delivery slot is fully booked",
+	}
+
+	DeliverySlotCutoffPassedError = ErrorDetails{
+		Code:       "delivery-slot:cutoff-passed",
+		StatusCode: http.StatusConflict,
+		Message:    "booking for this delivery slot has closed",
+	}
+
+	DeliverySlotAlreadyReservedError = ErrorDetails{
+		Code:       "delivery-slot:already-reserved",
+		StatusCode: http.StatusConflict,
+		Message:    "order already holds a delivery slot",
+	}
+)
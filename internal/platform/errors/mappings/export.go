@@ -0,0 +1,24 @@
+package mappings
+
+import "net/http"
+
+// Export-related error mappings
+var (
+	ExportInvalidFormatError = ErrorDetails{
+		Code:       "export:invalid-format",
+		StatusCode: http.StatusBadRequest,
+		Message:    "export format must be csv or xlsx",
+	}
+
+	ExportInvalidLayoutError = ErrorDetails{
+		Code:       "export:invalid-layout",
+		StatusCode: http.StatusBadRequest,
+		Message:    "export rows must be order or item",
+	}
+
+	ExportWriteError = ErrorDetails{
+		Code:       "export:write-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to write the export",
+	}
+)
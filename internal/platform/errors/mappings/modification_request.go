@@ -0,0 +1,59 @@
+package mappings
+
+import "net/http"
+
+var (
+	ModificationRequestCreateError = ErrorDetails{
+		Code:       "modification-request:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create modification request",
+	}
+
+	ModificationRequestGetError = ErrorDetails{
+		Code:       "modification-request:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get modification request",
+	}
+
+	ModificationRequestUpdateError = ErrorDetails{
+		Code:       "modification-request:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update modification request",
+	}
+
+	ModificationRequestNotFoundError = ErrorDetails{
+		Code:       "modification-request:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "no pending modification request for this order",
+	}
+
+	ModificationRequestAlreadyPendingError = ErrorDetails{
+		Code:       "modification-request:already-pending",
+		StatusCode: http.StatusConflict,
+		Message:    "order already has a pending modification request",
+	}
+
+	ModificationRequestNotAllowedError = ErrorDetails{
+		Code:       "modification-request:not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "order can no longer be modified",
+	}
+
+	ModificationRequestInvalidError = ErrorDetails{
+		Code:       "modification-request:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid modification request",
+	}
+
+	ModificationRequestNoChangesError = ErrorDetails{
+		Code:       "modification-request:no-changes",
+		StatusCode: http.StatusBadRequest,
+		Message:    "modification request does not change the order",
+	}
+
+	ModificationRequestEmptyOrderError = ErrorDetails{
+		Code:       "modification-request:empty-order",
+		StatusCode: http.StatusBadRequest,
+		Message:    "modification would leave the order without items, cancel it instead",
+	}
+)
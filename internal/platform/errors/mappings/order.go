@@ -1,6 +1,10 @@
 package mappings
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 // Order-related error mappings
 var (
@@ -28,12 +32,55 @@ var (
 		Message:    "invalid order status",
 	}
 
+	OrderInvalidTransitionError = ErrorDetails{
+		Code:       "order:invalid-transition",
+		StatusCode: http.StatusConflict,
+		Message:    "invalid order status transition",
+	}
+
+	OrderVersionConflictError = ErrorDetails{
+		Code:       "order:version-conflict",
+		StatusCode: http.StatusConflict,
+		Message:    "order was modified by someone else, reload it and try again",
+	}
+
+	OrderCancelEndpointRequiredError = ErrorDetails{
+		Code:       "order:cancel-endpoint-required",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "orders are cancelled through POST /orders/:id/cancel, which also refunds them",
+	}
+
+	OrderVersionRequiredError = ErrorDetails{
+		Code:       "order:version-required",
+		StatusCode: http.StatusPreconditionRequired,
+		Message:    "If-Match header with the order ETag is required",
+	}
+
+	OrderInvalidVersionError = ErrorDetails{
+		Code:       "order:invalid-version",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid If-Match header, expected the order ETag",
+	}
+
 	OrderInvalidIDError = ErrorDetails{
 		Code:       "order:invalid-id",
 		StatusCode: http.StatusBadRequest,
 		Message:    "invalid order ID format",
 	}
 
+	// Order status history errors
+	OrderStatusEventCreateError = ErrorDetails{
+		Code:       "order:status-event:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to record order status change",
+	}
+
+	OrderStatusEventListError = ErrorDetails{
+		Code:       "order:status-event:list-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get order status history",
+	}
+
 	// Order Token errors
 	OrderTokenCreateError = ErrorDetails{
 		Code:       "order:token:create-error",
@@ -65,9 +112,123 @@ var (
 		Message:    "order is already assigned to a user",
 	}
 
+	OrderCancelNotAllowedError = ErrorDetails{
+		Code:       "order:cancel-not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "order can no longer be cancelled by the customer",
+	}
+
+	OrderAlreadyCancelledError = ErrorDetails{
+		Code:       "order:already-cancelled",
+		StatusCode: http.StatusConflict,
+		Message:    "order is already cancelled",
+	}
+
+	OrderManagerCancelForbiddenError = ErrorDetails{
+		Code:       "order:manager-cancel-forbidden",
+		StatusCode: http.StatusForbidden,
+		Message:    "only admins can cancel orders on behalf of a customer",
+	}
+
+	OrderNotPausedError = ErrorDetails{
+		Code:       "order:not-paused",
+		StatusCode: http.StatusConflict,
+		Message:    "order is not paused",
+	}
+
+	OrderInvalidResumeAtError = ErrorDetails{
+		Code:       "order:invalid-resume-at",
+		StatusCode: http.StatusBadRequest,
+		Message:    "resume_at must be an RFC3339 timestamp in the future",
+	}
+
+	OrderInvalidETAWindowError = ErrorDetails{
+		Code:       "order:invalid-eta-window",
+		StatusCode: http.StatusBadRequest,
+		Message:    "eta_to must not be before eta_from",
+	}
+
 	OrderPaymentFailedError = ErrorDetails{
 		Code:       "order:payment-failed",
 		StatusCode: http.StatusPaymentRequired,
 		Message:    "payment processing failed",
 	}
+
+	OrderInvalidCurrencyError = ErrorDetails{
+		Code:       "order:invalid-currency",
+		StatusCode: http.StatusBadRequest,
+		Message:    "currency must be ARS or USD",
+	}
+
+	OrderCurrencyMismatchError = ErrorDetails{
+		Code:       "order:currency-mismatch",
+		StatusCode: http.StatusConflict,
+		Message:    "amounts in different currencies cannot be combined",
+	}
+
+	OrderCurrencyLockedError = ErrorDetails{
+		Code:       "order:currency-locked",
+		StatusCode: http.StatusConflict,
+		Message:    "the currency of an order with payments cannot change",
+	}
+
+	OrderInvalidTipError = ErrorDetails{
+		Code:       "order:invalid-tip",
+		StatusCode: http.StatusBadRequest,
+		Message:    "tip must be a positive amount",
+	}
+
+	OrderTipNotAllowedError = ErrorDetails{
+		Code:       "order:tip-not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "tips can only be added after the order is delivered",
+	}
+
+	OrderTipFailedError = ErrorDetails{
+		Code:       "order:tip-failed",
+		StatusCode: http.StatusPaymentRequired,
+		Message:    "failed to charge the tip",
+	}
+
+	OrderInvalidSearchError = ErrorDetails{
+		Code:       "order:invalid-search",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid order search parameters",
+	}
+
+	OrderReorderEmptyError = ErrorDetails{
+		Code:       "order:reorder-empty",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "none of the order's products are available anymore",
+	}
+
+	OrderInvalidBulkUpdateError = ErrorDetails{
+		Code:       "order:invalid-bulk-update",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid bulk order update",
+	}
+
+	OrderReceiptNotAvailableError = ErrorDetails{
+		Code:       "order:receipt-not-available",
+		StatusCode: http.StatusConflict,
+		Message:    "the order has no approved payment yet",
+	}
+
+	OrderDocumentError = ErrorDetails{
+		Code:       "order:document-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to generate the order document",
+	}
 )
+
+// NewOrderInvalidTransitionError builds an OrderInvalidTransitionError whose message
+// lists the statuses the order may move to from its current status
+func NewOrderInvalidTransitionError(from string, to string, allowed []string) ErrorDetails {
+	details := OrderInvalidTransitionError
+	next := "none"
+	if len(allowed) > 0 {
+		next = strings.Join(allowed, ", ")
+	}
+	details.Message = fmt.Sprintf("cannot move order from %s to %s; allowed next statuses: %s", from, to, next)
+	return details
+}
@@ -0,0 +1,96 @@
+package mappings
+
+import "net/http"
+
+// Promotion-related error mappings
+var (
+	PromotionCreateError = ErrorDetails{
+		Code:       "promotion:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create promotion",
+	}
+
+	PromotionGetError = ErrorDetails{
+		Code:       "promotion:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get promotion",
+	}
+
+	PromotionUpdateError = ErrorDetails{
+		Code:       "promotion:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update promotion",
+	}
+
+	PromotionDeleteError = ErrorDetails{
+		Code:       "promotion:delete-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to delete promotion",
+	}
+
+	PromotionNotFoundError = ErrorDetails{
+		Code:       "promotion:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "promotion not found",
+	}
+
+	PromotionInvalidIDError = ErrorDetails{
+		Code:       "promotion:invalid-id",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid promotion ID",
+	}
+
+	PromotionInvalidError = ErrorDetails{
+		Code:       "promotion:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid promotion",
+	}
+
+	PromotionCodeTakenError = ErrorDetails{
+		Code:       "promotion:code-taken",
+		StatusCode: http.StatusConflict,
+		Message:    "a promotion with this code already exists",
+	}
+
+	PromotionNotAvailableError = ErrorDetails{
+		Code:       "promotion:not-available",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "coupon is not active",
+	}
+
+	PromotionNotApplicableError = ErrorDetails{
+		Code:       "promotion:not-applicable",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "coupon does not apply to this order",
+	}
+
+	PromotionUsageLimitError = ErrorDetails{
+		Code:       "promotion:usage-limit",
+		StatusCode: http.StatusConflict,
+		Message:    "coupon usage limit reached",
+	}
+
+	PromotionAlreadyRedeemedError = ErrorDetails{
+		Code:       "promotion:already-redeemed",
+		StatusCode: http.StatusConflict,
+		Message:    "coupon already redeemed on this order",
+	}
+
+	PromotionNotRedeemedError = ErrorDetails{
+		Code:       "promotion:not-redeemed",
+		StatusCode: http.StatusNotFound,
+		Message:    "coupon is not redeemed on this order",
+	}
+
+	PromotionRedeemError = ErrorDetails{
+		Code:       "promotion:redeem-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to redeem coupon",
+	}
+
+	PromotionOrderLockedError = ErrorDetails{
+		Code:       "promotion:order-locked",
+		StatusCode: http.StatusConflict,
+		Message:    "coupons can only change before the order is paid",
+	}
+)
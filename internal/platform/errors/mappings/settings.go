@@ -21,4 +21,40 @@ var (
 		StatusCode: http.StatusInternalServerError,
 		Message:    "failed to update settings",
 	}
+
+	SettingsInvalidTimezoneError = ErrorDetails{
+		Code:       "settings:invalid-timezone",
+		StatusCode: http.StatusBadRequest,
+		Message:    "timezone must be a valid IANA zone name",
+	}
+
+	SettingsInvalidDeliverySlotsError = ErrorDetails{
+		Code:       "settings:invalid-delivery-slots",
+		StatusCode: http.StatusBadRequest,
+		Message:    "delivery slots are invalid",
+	}
+
+	SettingsInvalidCurrencyError = ErrorDetails{
+		Code:       "settings:invalid-currency",
+		StatusCode: http.StatusBadRequest,
+		Message:    "default_currency must be ARS or USD",
+	}
+
+	SettingsInvalidTaxRatesError = ErrorDetails{
+		Code:       "settings:invalid-tax-rates",
+		StatusCode: http.StatusBadRequest,
+		Message:    "tax rates must be percentages between 0 and 100",
+	}
+
+	SettingsInvalidDeliveryPricesError = ErrorDetails{
+		Code:       "settings:invalid-delivery-prices",
+		StatusCode: http.StatusBadRequest,
+		Message:    "currency delivery prices must be in ARS or USD and can't be negative",
+	}
+
+	SettingsDeliveryPricesMissingError = ErrorDetails{
+		Code:       "settings:delivery-prices-missing",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "no delivery prices are set for this currency",
+	}
 )
@@ -0,0 +1,72 @@
+package mappings
+
+import "net/http"
+
+// Subscription-related error mappings
+var (
+	SubscriptionCreateError = ErrorDetails{
+		Code:       "subscription:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create subscription",
+	}
+
+	SubscriptionGetError = ErrorDetails{
+		Code:       "subscription:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get subscription",
+	}
+
+	SubscriptionUpdateError = ErrorDetails{
+		Code:       "subscription:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update subscription",
+	}
+
+	SubscriptionNotFoundError = ErrorDetails{
+		Code:       "subscription:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "subscription not found",
+	}
+
+	SubscriptionInvalidIDError = ErrorDetails{
+		Code:       "subscription:invalid-id",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid subscription ID",
+	}
+
+	SubscriptionInvalidRecurrenceError = ErrorDetails{
+		Code:       "subscription:invalid-recurrence",
+		StatusCode: http.StatusBadRequest,
+		Message:    "recurrence must be DAILY, WEEKLY or MONTHLY with a positive interval",
+	}
+
+	SubscriptionInvalidStartError = ErrorDetails{
+		Code:       "subscription:invalid-start",
+		StatusCode: http.StatusBadRequest,
+		Message:    "first run must be in the future",
+	}
+
+	SubscriptionEmptyItemsError = ErrorDetails{
+		Code:       "subscription:empty-items",
+		StatusCode: http.StatusBadRequest,
+		Message:    "subscription must contain at least one item",
+	}
+
+	SubscriptionNotActiveError = ErrorDetails{
+		Code:       "subscription:not-active",
+		StatusCode: http.StatusConflict,
+		Message:    "subscription is not active",
+	}
+
+	SubscriptionNotPausedError = ErrorDetails{
+		Code:       "subscription:not-paused",
+		StatusCode: http.StatusConflict,
+		Message:    "subscription is not paused",
+	}
+
+	SubscriptionAlreadyCancelledError = ErrorDetails{
+		Code:       "subscription:already-cancelled",
+		StatusCode: http.StatusConflict,
+		Message:    "subscription is already cancelled",
+	}
+)
@@ -0,0 +1,267 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+	orderUsecase "yego/internal/usecases/order"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkOrders caps the orders a bulk update may touch
+const MaxBulkOrders = 200
+
+// Outcomes of each order in a bulk update
+const (
+	BulkOutcomeUpdated   = "updated"
+	BulkOutcomeUnchanged = "unchanged"   // already had the requested values
+	BulkOutcomeFailed    = "failed"      // see error
+	BulkOutcomeSkipped   = "not_applied" // valid, but another order failed
+)
+
+// BulkUpdateOrdersInput represents the changes applied to every listed order
+type BulkUpdateOrdersInput struct {
+	OrderIDs      []string   `json:"order_ids"`
+	Status        *string    `json:"status,omitempty"`
+	StatusMessage *string    `json:"status_message,omitempty"`
+	ETA           *string    `json:"eta,omitempty"`
+	ETAFrom       *time.Time `json:"eta_from,omitempty"`
+	ETATo         *time.Time `json:"eta_to,omitempty"`
+	UserID        string     `json:"-"`
+}
+
+// BulkOrderResult is the outcome of a bulk update for one order
+type BulkOrderResult struct {
+	OrderID string                     `json:"order_id"`
+	Outcome string                     `json:"outcome"`
+	Status  string                     `json:"status,omitempty"`
+	Version int                        `json:"version,omitempty"`
+	Error   apperrors.ApplicationError `json:"error,omitempty"`
+}
+
+// BulkUpdateOrdersOutput reports every order of a bulk update, in request order.
+// Applied is false when any order failed, in which case nothing was written.
+type BulkUpdateOrdersOutput struct {
+	Applied bool              `json:"applied"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Results []BulkOrderResult `json:"results"`
+}
+
+// BulkUpdateOrdersUsecase defines the interface for updating many orders at once
+type BulkUpdateOrdersUsecase interface {
+	Execute(ctx context.Context, input BulkUpdateOrdersInput) (*BulkUpdateOrdersOutput, apperrors.ApplicationError)
+}
+
+type bulkUpdateOrdersUsecase struct {
+	contextFactory          appcontext.Factory
+	notificationSvc         notification.Service
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewBulkUpdateOrdersUsecase creates a new instance of BulkUpdateOrdersUsecase
+func NewBulkUpdateOrdersUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) BulkUpdateOrdersUsecase {
+	return &bulkUpdateOrdersUsecase{
+		contextFactory:          contextFactory,
+		notificationSvc:         notificationSvc,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute validates the changes against every order first and only writes them,
+// in one transaction, when all orders accept them
+func (u *bulkUpdateOrdersUsecase) Execute(ctx context.Context, input BulkUpdateOrdersInput) (*BulkUpdateOrdersOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if inputErr := validateBulkInput(input); inputErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidBulkUpdateError, inputErr)
+	}
+
+	output := &BulkUpdateOrdersOutput{Results: make([]BulkOrderResult, len(input.OrderIDs))}
+	previousStatuses := map[string]domain.OrderStatus{}
+	var changed []*domain.Order
+
+	for i, id := range input.OrderIDs {
+		result := &output.Results[i]
+		result.OrderID = id
+
+		order, previousStatus, isChanged, changeErr := applyBulkChanges(ctx, app, id, input)
+		if changeErr != nil {
+			result.Outcome = BulkOutcomeFailed
+			result.Error = changeErr
+			output.Failed++
+			continue
+		}
+		result.Status = string(order.Status)
+		result.Version = order.Version
+		if !isChanged {
+			result.Outcome = BulkOutcomeUnchanged
+			continue
+		}
+		result.Outcome = BulkOutcomeUpdated
+		previousStatuses[order.ID] = previousStatus
+		changed = append(changed, order)
+	}
+
+	if output.Failed > 0 {
+		for i := range output.Results {
+			if output.Results[i].Outcome == BulkOutcomeUpdated {
+				output.Results[i].Outcome = BulkOutcomeSkipped
+			}
+		}
+		return output, nil
+	}
+
+	output.Applied = true
+	if len(changed) == 0 {
+		return output, nil
+	}
+
+	updatedOrders, err := app.Repositories.Order.UpdateMany(ctx, changed, orderUsecase.ActorUserID(input.UserID))
+	if err != nil {
+		// An order changed between checking and writing it: nothing was written,
+		// report it like an order that failed the checks
+		var conflict *domain.OrderVersionConflict
+		if !errors.As(err.OriginalError(), &conflict) {
+			return nil, err
+		}
+		output.Applied = false
+		for i := range output.Results {
+			result := &output.Results[i]
+			switch {
+			case result.OrderID == conflict.OrderID:
+				result.Outcome = BulkOutcomeFailed
+				result.Error = err
+				output.Failed++
+			case result.Outcome == BulkOutcomeUpdated:
+				result.Outcome = BulkOutcomeSkipped
+			}
+		}
+		return output, nil
+	}
+
+	updatedByID := make(map[string]*domain.Order, len(updatedOrders))
+	for _, updated := range updatedOrders {
+		updatedByID[updated.ID] = updated
+		previousStatus := previousStatuses[updated.ID]
+		if updated.Status != previousStatus {
+			if updated.Status == domain.StatusConfirmed {
+				orderUsecase.EnsureConfirmedPricing(ctx, app, updated, u.calculateDeliveryFeeUse)
+			}
+			orderUsecase.IssueDeliveryCode(ctx, app, updated)
+		}
+		orderUsecase.NotifyOrderUpdated(u.notificationSvc, updated, previousStatus)
+	}
+
+	for i := range output.Results {
+		if updated, ok := updatedByID[output.Results[i].OrderID]; ok {
+			output.Results[i].Status = string(updated.Status)
+			output.Results[i].Version = updated.Version
+			output.Updated++
+		}
+	}
+
+	return output, nil
+}
+
+// validateBulkInput checks what doesn't depend on the orders themselves
+func validateBulkInput(input BulkUpdateOrdersInput) error {
+	if len(input.OrderIDs) == 0 {
+		return errors.New("order_ids is required")
+	}
+	if len(input.OrderIDs) > MaxBulkOrders {
+		return fmt.Errorf("at most %d orders can be updated at once, got %d", MaxBulkOrders, len(input.OrderIDs))
+	}
+	seen := make(map[string]bool, len(input.OrderIDs))
+	for _, id := range input.OrderIDs {
+		if seen[id] {
+			return fmt.Errorf("order %s is listed more than once", id)
+		}
+		seen[id] = true
+	}
+	if input.Status == nil && input.StatusMessage == nil && input.ETA == nil && input.ETAFrom == nil && input.ETATo == nil {
+		return errors.New("nothing to update: give status, status_message, eta, eta_from or eta_to")
+	}
+	if input.Status != nil {
+		if !domain.IsValidStatus(*input.Status) {
+			return fmt.Errorf("unknown status %q", *input.Status)
+		}
+		// Each delivery is confirmed with its own customer's code
+		if domain.OrderStatus(*input.Status) == domain.StatusDelivered {
+			return errors.New("orders can't be delivered in bulk")
+		}
+		// Cancelling refunds each order, see POST /orders/:id/cancel
+		if domain.OrderStatus(*input.Status) == domain.StatusCancelled {
+			return errors.New("orders can't be cancelled in bulk")
+		}
+	}
+	return nil
+}
+
+// applyBulkChanges loads an order and applies the bulk changes to it in memory,
+// with the same checks as a single update. It reports the status the order had
+// and whether anything actually changed.
+func applyBulkChanges(ctx context.Context, app *appcontext.Context, id string, input BulkUpdateOrdersInput) (*domain.Order, domain.OrderStatus, bool, apperrors.ApplicationError) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, "", false, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", false, err
+	}
+	before := *order
+
+	if input.Status != nil {
+		next := domain.OrderStatus(*input.Status)
+		if transitionErr := orderUsecase.ValidateStatusTransition(ctx, app, order, next); transitionErr != nil {
+			return nil, "", false, transitionErr
+		}
+		applyStatus(order, next, input.StatusMessage)
+	}
+	if input.StatusMessage != nil {
+		order.StatusMessage = input.StatusMessage
+	}
+	if input.ETA != nil {
+		order.ETA = *input.ETA
+	}
+	if input.ETAFrom != nil {
+		order.ETAFrom = input.ETAFrom
+	}
+	if input.ETATo != nil {
+		order.ETATo = input.ETATo
+	}
+	if etaErr := orderUsecase.ValidateETAWindow(order.ETAFrom, order.ETATo); etaErr != nil {
+		return nil, "", false, etaErr
+	}
+
+	isChanged := order.Status != before.Status ||
+		!equalOptionalString(order.StatusMessage, before.StatusMessage) ||
+		order.ETA != before.ETA ||
+		!equalOptionalTime(order.ETAFrom, before.ETAFrom) ||
+		!equalOptionalTime(order.ETATo, before.ETATo)
+	return order, before.Status, isChanged, nil
+}
+
+func equalOptionalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalOptionalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
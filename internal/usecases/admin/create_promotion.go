@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// PromotionInput holds the rule of a promotion. Currency defaults to the business
+// currency and Active to true.
+type PromotionInput struct {
+	Code               string       `json:"code" binding:"required"`
+	Description        string       `json:"description"`
+	Kind               string       `json:"kind" binding:"required"`
+	PercentOff         float64      `json:"percent_off"`
+	AmountOff          domain.Money `json:"amount_off"`
+	BuyCode            *string      `json:"buy_code"`
+	BuyQuantity        int          `json:"buy_quantity"`
+	GetQuantity        int          `json:"get_quantity"`
+	MinOrderAmount     domain.Money `json:"min_order_amount"`
+	Currency           string       `json:"currency"`
+	StartsAt           *time.Time   `json:"starts_at"`
+	EndsAt             *time.Time   `json:"ends_at"`
+	MaxUsesPerCustomer *int         `json:"max_uses_per_customer"`
+	Active             *bool        `json:"active"`
+}
+
+// CreatePromotionUsecase defines the interface for creating a promotion
+type CreatePromotionUsecase interface {
+	Execute(ctx context.Context, input PromotionInput) (*PromotionOutput, apperrors.ApplicationError)
+}
+
+type createPromotionUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewCreatePromotionUsecase creates a new instance of CreatePromotionUsecase
+func NewCreatePromotionUsecase(contextFactory appcontext.Factory) CreatePromotionUsecase {
+	return &createPromotionUsecase{contextFactory: contextFactory}
+}
+
+// Execute validates and creates a promotion
+func (u *createPromotionUsecase) Execute(ctx context.Context, input PromotionInput) (*PromotionOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	promotion := &domain.Promotion{Active: true}
+	if appErr := applyPromotionInput(ctx, app, promotion, input); appErr != nil {
+		return nil, appErr
+	}
+
+	created, err := app.Repositories.Promotion.Create(ctx, promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toPromotionOutput(created)
+	return &output, nil
+}
+
+// applyPromotionInput copies the input onto a promotion and validates the result
+func applyPromotionInput(ctx context.Context, app *appcontext.Context, promotion *domain.Promotion, input PromotionInput) apperrors.ApplicationError {
+	promotion.Code = domain.NormalizeCouponCode(input.Code)
+	promotion.Description = input.Description
+	promotion.Kind = domain.PromotionKind(input.Kind)
+	promotion.PercentOff = input.PercentOff
+	promotion.AmountOff = input.AmountOff
+	promotion.BuyCode = input.BuyCode
+	promotion.BuyQuantity = input.BuyQuantity
+	promotion.GetQuantity = input.GetQuantity
+	promotion.MinOrderAmount = input.MinOrderAmount
+	promotion.StartsAt = input.StartsAt
+	promotion.EndsAt = input.EndsAt
+	promotion.MaxUsesPerCustomer = input.MaxUsesPerCustomer
+	if input.Active != nil {
+		promotion.Active = *input.Active
+	}
+
+	promotion.Currency = domain.NormalizeCurrency(input.Currency)
+	if promotion.Currency == "" {
+		settings, err := app.Repositories.Settings.Get(ctx)
+		if err != nil {
+			return err
+		}
+		promotion.Currency = settings.Currency()
+	}
+
+	if err := promotion.Validate(); err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionInvalidError, err)
+	}
+	return nil
+}
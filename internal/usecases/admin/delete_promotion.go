@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// DeletePromotionUsecase defines the interface for deleting a promotion
+type DeletePromotionUsecase interface {
+	Execute(ctx context.Context, id string) apperrors.ApplicationError
+}
+
+type deletePromotionUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewDeletePromotionUsecase creates a new instance of DeletePromotionUsecase
+func NewDeletePromotionUsecase(contextFactory appcontext.Factory) DeletePromotionUsecase {
+	return &deletePromotionUsecase{contextFactory: contextFactory}
+}
+
+// Execute deletes a promotion and its redemptions. Stored snapshots keep the
+// discount they were priced with; to stop new redemptions only, deactivate it.
+func (u *deletePromotionUsecase) Execute(ctx context.Context, id string) apperrors.ApplicationError {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return apperrors.NewApplicationError(mappings.PromotionInvalidIDError, err)
+	}
+	return app.Repositories.Promotion.Delete(ctx, id)
+}
@@ -0,0 +1,211 @@
+package admin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// ExportFormat is the file format of an export
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+// IsValid reports whether the format is supported
+func (f ExportFormat) IsValid() bool {
+	return f == ExportCSV || f == ExportXLSX
+}
+
+// ContentType returns the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	if f == ExportXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// exportTimeFormat matches the timestamps of the JSON outputs
+const exportTimeFormat = "2006-01-02T15:04:05Z"
+
+// exportFlushEvery is how many rows a CSV export buffers before flushing them to the client
+const exportFlushEvery = 500
+
+// rowWriter writes an export one row at a time. Money cells are written as numbers
+// in XLSX and as decimal strings in CSV; nil cells are left empty. Close completes
+// the file; Abort releases it after a failure.
+type rowWriter interface {
+	WriteRow(cells []any) apperrors.ApplicationError
+	Close() apperrors.ApplicationError
+	Abort()
+}
+
+// newRowWriter starts an export in format on w, beginning with the header row
+func newRowWriter(format ExportFormat, w io.Writer, sheet string, header []string) (rowWriter, apperrors.ApplicationError) {
+	var writer rowWriter
+	switch format {
+	case ExportCSV:
+		writer = &csvRowWriter{w: csv.NewWriter(w)}
+	case ExportXLSX:
+		xlsx, err := newXLSXRowWriter(w, sheet)
+		if err != nil {
+			return nil, err
+		}
+		writer = xlsx
+	default:
+		return nil, apperrors.NewApplicationError(mappings.ExportInvalidFormatError, fmt.Errorf("unknown format %q", format))
+	}
+
+	cells := make([]any, len(header))
+	for i, name := range header {
+		cells[i] = name
+	}
+	if err := writer.WriteRow(cells); err != nil {
+		writer.Abort()
+		return nil, err
+	}
+	return writer, nil
+}
+
+// csvRowWriter writes rows through to the client as they come
+type csvRowWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func (c *csvRowWriter) WriteRow(cells []any) apperrors.ApplicationError {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = csvCell(cell)
+	}
+	if err := c.w.Write(record); err != nil {
+		return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	c.rows++
+	if c.rows%exportFlushEvery == 0 {
+		c.w.Flush()
+		if err := c.w.Error(); err != nil {
+			return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+		}
+	}
+	return nil
+}
+
+func (c *csvRowWriter) Close() apperrors.ApplicationError {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	return nil
+}
+
+func (c *csvRowWriter) Abort() {}
+
+func csvCell(cell any) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case domain.Money:
+		return v.String()
+	case *domain.Money:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	case time.Time:
+		return v.UTC().Format(exportTimeFormat)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// xlsxRowWriter writes rows with excelize's stream writer, which spills to a
+// temporary file instead of keeping the whole sheet in memory. The workbook can
+// only be sent once complete, on Close.
+type xlsxRowWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXRowWriter(w io.Writer, sheet string) (*xlsxRowWriter, apperrors.ApplicationError) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), sheet); err != nil {
+		file.Close()
+		return nil, apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	return &xlsxRowWriter{w: w, file: file, stream: stream}, nil
+}
+
+func (x *xlsxRowWriter) WriteRow(cells []any) apperrors.ApplicationError {
+	values := make([]any, len(cells))
+	for i, cell := range cells {
+		values[i] = xlsxCell(cell)
+	}
+	x.row++
+	axis, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	if err := x.stream.SetRow(axis, values); err != nil {
+		return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	return nil
+}
+
+func (x *xlsxRowWriter) Close() apperrors.ApplicationError {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	if err := x.file.Write(x.w); err != nil {
+		return apperrors.NewApplicationError(mappings.ExportWriteError, err)
+	}
+	return nil
+}
+
+func (x *xlsxRowWriter) Abort() {
+	x.file.Close()
+}
+
+func xlsxCell(cell any) any {
+	switch v := cell.(type) {
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case domain.Money:
+		return v.Float64()
+	case *domain.Money:
+		if v == nil {
+			return nil
+		}
+		return v.Float64()
+	case time.Time:
+		return v.UTC().Format(exportTimeFormat)
+	default:
+		return v
+	}
+}
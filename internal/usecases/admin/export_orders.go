@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"context"
+	"io"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Layouts of an order export
+const (
+	ExportRowsPerOrder = "order" // one row per order
+	ExportRowsPerItem  = "item"  // one row per item, repeating the order columns
+)
+
+// orderExportColumns are the order columns of both layouts. Amounts come from the
+// priced snapshot; orders not priced yet are totalled from their items.
+var orderExportColumns = []string{
+	"order_id", "created_at", "updated_at", "status", "user_id", "profile_id", "eta", "currency",
+	"units", "subtotal", "delivery_fee", "surcharges", "discounts", "total", "net", "tax", "priced",
+}
+
+var itemExportColumns = []string{
+	"item_code", "item_name", "item_category", "item_quantity", "item_unit_price", "item_total",
+}
+
+// ExportOrdersInput represents an order export: the list filters, the layout and the format
+type ExportOrdersInput struct {
+	Search ListOrdersInput // Limit, Offset and Cursor are ignored
+	Rows   string          // ExportRowsPerOrder (default) or ExportRowsPerItem
+	Format ExportFormat
+}
+
+// ExportOrdersUsecase defines the interface for exporting orders
+type ExportOrdersUsecase interface {
+	Execute(ctx context.Context, input ExportOrdersInput, w io.Writer) apperrors.ApplicationError
+}
+
+type exportOrdersUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewExportOrdersUsecase creates a new instance of ExportOrdersUsecase
+func NewExportOrdersUsecase(contextFactory appcontext.Factory) ExportOrdersUsecase {
+	return &exportOrdersUsecase{contextFactory: contextFactory}
+}
+
+// Execute writes the matching orders to w as they are read. Invalid input fails
+// before anything is written.
+func (u *exportOrdersUsecase) Execute(ctx context.Context, input ExportOrdersInput, w io.Writer) apperrors.ApplicationError {
+	app := u.contextFactory()
+
+	if !input.Format.IsValid() {
+		return apperrors.NewApplicationError(mappings.ExportInvalidFormatError, nil)
+	}
+	if input.Rows == "" {
+		input.Rows = ExportRowsPerOrder
+	}
+	if input.Rows != ExportRowsPerOrder && input.Rows != ExportRowsPerItem {
+		return apperrors.NewApplicationError(mappings.ExportInvalidLayoutError, nil)
+	}
+	filter, filterErr := toSearchFilter(input.Search)
+	if filterErr != nil {
+		return apperrors.NewApplicationError(mappings.OrderInvalidSearchError, filterErr)
+	}
+
+	header := orderExportColumns
+	if input.Rows == ExportRowsPerItem {
+		header = append(append([]string{}, orderExportColumns...), itemExportColumns...)
+	}
+	writer, err := newRowWriter(input.Format, w, "Orders", header)
+	if err != nil {
+		return err
+	}
+
+	streamErr := app.Repositories.Order.Stream(ctx, filter, func(order *domain.Order) apperrors.ApplicationError {
+		cells := orderExportCells(order)
+		if input.Rows == ExportRowsPerOrder {
+			return writer.WriteRow(cells)
+		}
+		var items []domain.OrderItem
+		if order.Data != nil {
+			items = order.Data.Items
+		}
+		if len(items) == 0 {
+			return writer.WriteRow(cells)
+		}
+		for _, item := range items {
+			row := append(append([]any{}, cells...),
+				item.Code, item.Name, item.Category, item.Quantity, item.Price, item.Price.Mul(item.Quantity))
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if streamErr != nil {
+		writer.Abort()
+		return streamErr
+	}
+
+	return writer.Close()
+}
+
+// orderExportCells returns the cells of orderExportColumns for an order
+func orderExportCells(order *domain.Order) []any {
+	var units int
+	var itemsTotal domain.Money
+	if order.Data != nil {
+		for _, item := range order.Data.Items {
+			units += item.Quantity
+			itemsTotal += item.Price.Mul(item.Quantity)
+		}
+	}
+
+	subtotal, total := itemsTotal, itemsTotal
+	var deliveryFee, surcharges, discounts domain.Money
+	var net, tax *domain.Money
+	priced := "no"
+	if p := order.Pricing; p != nil {
+		subtotal, total = p.Subtotal, p.Total
+		deliveryFee, surcharges, discounts = p.DeliveryFee(), p.SurchargeTotal, p.DiscountTotal
+		if p.Tax != nil {
+			net, tax = &p.Tax.Net, &p.Tax.Tax
+		}
+		priced = "yes"
+	}
+
+	return []any{
+		order.ID, order.CreatedAt, order.UpdatedAt, string(order.Status), order.UserID, order.ProfileID, order.ETA, order.Currency,
+		units, subtotal, deliveryFee, surcharges, discounts, total, net, tax, priced,
+	}
+}
@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"context"
+	"io"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+var transactionExportColumns = []string{
+	"transaction_id", "created_at", "order_id", "user_id", "profile_id", "kind", "status",
+	"amount", "net_amount", "tax_amount", "currency", "gateway_payment_id", "description",
+}
+
+// ExportTransactionsUsecase defines the interface for exporting transactions
+type ExportTransactionsUsecase interface {
+	Execute(ctx context.Context, format ExportFormat, w io.Writer) apperrors.ApplicationError
+}
+
+type exportTransactionsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewExportTransactionsUsecase creates a new instance of ExportTransactionsUsecase
+func NewExportTransactionsUsecase(contextFactory appcontext.Factory) ExportTransactionsUsecase {
+	return &exportTransactionsUsecase{contextFactory: contextFactory}
+}
+
+// Execute writes every transaction to w, newest first, as they are read
+func (u *exportTransactionsUsecase) Execute(ctx context.Context, format ExportFormat, w io.Writer) apperrors.ApplicationError {
+	app := u.contextFactory()
+
+	if !format.IsValid() {
+		return apperrors.NewApplicationError(mappings.ExportInvalidFormatError, nil)
+	}
+
+	writer, err := newRowWriter(format, w, "Transactions", transactionExportColumns)
+	if err != nil {
+		return err
+	}
+
+	streamErr := app.Repositories.Transaction.Stream(ctx, func(t *domain.Transaction) apperrors.ApplicationError {
+		return writer.WriteRow([]any{
+			t.ID, t.CreatedAt, t.OrderID, t.UserID, t.ProfileID, t.Kind, t.Status,
+			t.Amount, t.NetAmount, t.TaxAmount, t.Currency, t.GatewayPaymentID, t.Description,
+		})
+	})
+	if streamErr != nil {
+		writer.Abort()
+		return streamErr
+	}
+
+	return writer.Close()
+}
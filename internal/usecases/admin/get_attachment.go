@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"yego/internal/adapters/web/integrations/storage"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// attachmentURLExpiry is how long a presigned storage URL stays valid
+const attachmentURLExpiry = 15 * time.Minute
+
+// GetAttachmentOutput is either a presigned URL to redirect to, or the file content
+// when the storage backend cannot sign URLs. The caller must close Content.
+type GetAttachmentOutput struct {
+	Attachment  *domain.Attachment
+	RedirectURL string
+	Content     io.ReadCloser
+}
+
+// GetAttachmentUsecase defines the interface for downloading an order attachment
+type GetAttachmentUsecase interface {
+	Execute(ctx context.Context, orderID string, attachmentID string) (*GetAttachmentOutput, apperrors.ApplicationError)
+}
+
+type getAttachmentUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetAttachmentUsecase creates a new instance of GetAttachmentUsecase
+func NewGetAttachmentUsecase(contextFactory appcontext.Factory) GetAttachmentUsecase {
+	return &getAttachmentUsecase{contextFactory: contextFactory}
+}
+
+// Execute resolves an attachment of the order to a download
+func (u *getAttachmentUsecase) Execute(ctx context.Context, orderID string, attachmentID string) (*GetAttachmentOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(orderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+	if _, err := uuid.Parse(attachmentID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentInvalidIDError, err)
+	}
+
+	attachment, err := app.Repositories.Attachment.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.OrderID != orderID {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentNotFoundError, nil)
+	}
+
+	signed, signErr := app.Integrations.Storage.SignedURL(ctx, attachment.StorageKey, attachmentURLExpiry)
+	if signErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentGetError, signErr)
+	}
+	if signed != "" {
+		return &GetAttachmentOutput{Attachment: attachment, RedirectURL: signed}, nil
+	}
+
+	content, openErr := app.Integrations.Storage.Open(ctx, attachment.StorageKey)
+	if openErr != nil {
+		if errors.Is(openErr, storage.ErrNotFound) {
+			return nil, apperrors.NewApplicationError(mappings.AttachmentNotFoundError, openErr)
+		}
+		return nil, apperrors.NewApplicationError(mappings.AttachmentGetError, openErr)
+	}
+
+	return &GetAttachmentOutput{Attachment: attachment, Content: content}, nil
+}
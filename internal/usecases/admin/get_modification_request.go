@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+	pricingUsecase "yego/internal/usecases/pricing"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// ModificationReviewOutput represents a pending modification request as shown to managers
+type ModificationReviewOutput struct {
+	Request         orderUsecase.ModificationRequestOutput `json:"request"`
+	CurrentTotal    domain.Money                           `json:"current_total"`
+	ProposedTotal   domain.Money                           `json:"proposed_total"`
+	PriceDifference domain.Money                           `json:"price_difference"`
+	PaidAmount      domain.Money                           `json:"paid_amount"`
+	CurrentPricing  *domain.OrderPricing                   `json:"current_pricing,omitempty"`
+	ProposedPricing *domain.OrderPricing                   `json:"proposed_pricing,omitempty"`
+}
+
+// GetModificationRequestUsecase defines the interface for reviewing an order's pending modification
+type GetModificationRequestUsecase interface {
+	Execute(ctx context.Context, orderID string) (*ModificationReviewOutput, apperrors.ApplicationError)
+}
+
+type getModificationRequestUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewGetModificationRequestUsecase creates a new instance of GetModificationRequestUsecase
+func NewGetModificationRequestUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) GetModificationRequestUsecase {
+	return &getModificationRequestUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute returns the pending modification request of an order with its diff and price impact
+func (u *getModificationRequestUsecase) Execute(ctx context.Context, orderID string) (*ModificationReviewOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(orderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := app.Repositories.ModificationRequest.GetPendingByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, quoteErr := quoteModification(ctx, app, order, request, u.calculateDeliveryFeeUse)
+	if quoteErr != nil {
+		return nil, quoteErr
+	}
+	return output, nil
+}
+
+// quoteModification prices the order before and after the proposed changes
+func quoteModification(ctx context.Context, app *appcontext.Context, order *domain.Order, request *domain.ModificationRequest, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (*ModificationReviewOutput, apperrors.ApplicationError) {
+	var profile *domain.Profile
+	if order.ProfileID != nil {
+		p, err := app.Repositories.Profile.GetByID(ctx, *order.ProfileID)
+		if err == nil {
+			profile = p
+		}
+	}
+
+	// The current total is what the customer was quoted, not a reprice at today's settings
+	current := order.Pricing
+	if current == nil {
+		var calcErr error
+		current, calcErr = pricingUsecase.PriceOrder(ctx, app, order, profile, calculateDeliveryFeeUse)
+		if calcErr != nil {
+			return nil, quoteError(mappings.InternalServerError, calcErr)
+		}
+	}
+
+	proposedOrder := *order
+	proposedOrder.Data = &domain.OrderData{Items: request.Items}
+	proposed, calcErr := pricingUsecase.PriceOrder(ctx, app, &proposedOrder, profile, calculateDeliveryFeeUse)
+	if calcErr != nil {
+		return nil, quoteError(mappings.InternalServerError, calcErr)
+	}
+
+	paid, paidErr := orderUsecase.PaidAmount(ctx, app, order)
+	if paidErr != nil {
+		return nil, quoteError(mappings.TransactionListError, paidErr)
+	}
+
+	return &ModificationReviewOutput{
+		Request:         orderUsecase.ToModificationRequestOutput(request),
+		CurrentTotal:    current.Total,
+		ProposedTotal:   proposed.Total,
+		PriceDifference: proposed.Total - current.Total,
+		PaidAmount:      paid,
+		CurrentPricing:  current,
+		ProposedPricing: proposed,
+	}, nil
+}
+
+// quoteError wraps a failure to quote a modification, telling amounts in mixed
+// currencies apart from other failures
+func quoteError(details mappings.ErrorDetails, err error) apperrors.ApplicationError {
+	if errors.Is(err, domain.ErrCurrencyMismatch) {
+		return apperrors.NewApplicationError(mappings.OrderCurrencyMismatchError, err)
+	}
+	return apperrors.NewApplicationError(details, err)
+}
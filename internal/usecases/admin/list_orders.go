@@ -2,20 +2,51 @@ package admin
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+
+	"github.com/google/uuid"
 )
 
+// ListOrdersInput represents the search parameters of the admin order list, as
+// given in the query string. Empty fields don't filter.
+type ListOrdersInput struct {
+	Statuses  []string
+	From      string // YYYY-MM-DD or RFC 3339, inclusive
+	To        string // YYYY-MM-DD (the whole day) or RFC 3339, exclusive
+	UserID    string
+	ProfileID string
+	Phone     string
+	ItemCode  string
+	ItemName  string
+	MinTotal  string
+	MaxTotal  string
+	Sort      string // created_at, updated_at, total or status
+	Order     string // asc or desc, desc by default
+	Limit     int
+	Offset    int
+	Cursor    string // next_cursor of the previous page, instead of offset
+}
+
 // ListOrdersOutput represents the output for listing orders
 type ListOrdersOutput struct {
-	Orders []OrderOutput `json:"orders"`
-	Total  int           `json:"total"`
+	Orders     []OrderOutput `json:"orders"`
+	Total      int           `json:"total"` // orders matching the filters, across all pages
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+	NextCursor *string       `json:"next_cursor,omitempty"` // nil on the last page
 }
 
 // ListOrdersUsecase defines the interface for listing orders
 type ListOrdersUsecase interface {
-	Execute(ctx context.Context) (*ListOrdersOutput, apperrors.ApplicationError)
+	Execute(ctx context.Context, input ListOrdersInput) (*ListOrdersOutput, apperrors.ApplicationError)
 }
 
 type listOrdersUsecase struct {
@@ -27,23 +58,152 @@ func NewListOrdersUsecase(contextFactory appcontext.Factory) ListOrdersUsecase {
 	return &listOrdersUsecase{contextFactory: contextFactory}
 }
 
-// Execute lists all orders
-func (u *listOrdersUsecase) Execute(ctx context.Context) (*ListOrdersOutput, apperrors.ApplicationError) {
+// Execute lists one page of the orders matching the search parameters
+func (u *listOrdersUsecase) Execute(ctx context.Context, input ListOrdersInput) (*ListOrdersOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
-	orders, err := app.Repositories.Order.GetAll(ctx)
+	filter, filterErr := toSearchFilter(input)
+	if filterErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidSearchError, filterErr)
+	}
+
+	result, err := app.Repositories.Order.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	orders := result.Orders
+
+	orderIDs := make([]string, len(orders))
+	for i, o := range orders {
+		orderIDs[i] = o.ID
+	}
+	history, err := app.Repositories.Order.GetStatusEventsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := app.Repositories.Attachment.ListByOrderIDs(ctx, orderIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	output := &ListOrdersOutput{
 		Orders: make([]OrderOutput, 0, len(orders)),
-		Total:  len(orders),
+		Total:  result.Total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	if result.NextCursor != "" {
+		output.NextCursor = &result.NextCursor
 	}
 
 	for _, o := range orders {
-		output.Orders = append(output.Orders, toOrderOutput(o))
+		next, nextErr := orderUsecase.AllowedNextStatuses(ctx, app, o)
+		if nextErr != nil {
+			return nil, nextErr
+		}
+		output.Orders = append(output.Orders, toOrderOutput(o, next, history[o.ID], toAttachmentOutputs(app.ConfigService.BackendURL, attachments[o.ID])))
 	}
 
 	return output, nil
 }
+
+// toSearchFilter validates the query parameters and converts them to a search filter
+func toSearchFilter(input ListOrdersInput) (domain.OrderSearchFilter, error) {
+	filter := domain.OrderSearchFilter{
+		UserID:   strings.TrimSpace(input.UserID),
+		Phone:    strings.TrimSpace(input.Phone),
+		ItemCode: strings.TrimSpace(input.ItemCode),
+		ItemName: strings.TrimSpace(input.ItemName),
+		Sort:     input.Sort,
+		Limit:    input.Limit,
+		Offset:   input.Offset,
+		Cursor:   input.Cursor,
+	}
+
+	for _, status := range input.Statuses {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if status == "" {
+			continue
+		}
+		if !domain.IsValidStatus(status) {
+			return filter, fmt.Errorf("unknown status %q", status)
+		}
+		filter.Statuses = append(filter.Statuses, domain.OrderStatus(status))
+	}
+
+	if input.From != "" {
+		from, err := parseSearchTime(input.From, false)
+		if err != nil {
+			return filter, err
+		}
+		filter.CreatedFrom = &from
+	}
+	if input.To != "" {
+		to, err := parseSearchTime(input.To, true)
+		if err != nil {
+			return filter, err
+		}
+		filter.CreatedTo = &to
+	}
+
+	if input.ProfileID != "" {
+		if _, err := uuid.Parse(input.ProfileID); err != nil {
+			return filter, fmt.Errorf("profile_id must be a UUID: %w", err)
+		}
+		filter.ProfileID = input.ProfileID
+	}
+
+	if input.MinTotal != "" {
+		minTotal, err := domain.ParseMoney(input.MinTotal)
+		if err != nil {
+			return filter, fmt.Errorf("min_total: %w", err)
+		}
+		filter.MinTotal = &minTotal
+	}
+	if input.MaxTotal != "" {
+		maxTotal, err := domain.ParseMoney(input.MaxTotal)
+		if err != nil {
+			return filter, fmt.Errorf("max_total: %w", err)
+		}
+		filter.MaxTotal = &maxTotal
+	}
+
+	if filter.Sort != "" && !domain.IsValidOrderSort(filter.Sort) {
+		return filter, fmt.Errorf("sort must be one of %s", strings.Join(domain.OrderSortFields, ", "))
+	}
+	switch strings.ToLower(input.Order) {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return filter, fmt.Errorf("order must be asc or desc")
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = domain.DefaultOrderSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.Cursor != "" {
+		filter.Offset = 0
+	}
+	return filter, nil
+}
+
+// parseSearchTime parses a date or a timestamp. A bare date as an upper bound
+// covers the whole day, so to=2024-05-31 includes orders placed on the 31st.
+func parseSearchTime(value string, upperBound bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q must be YYYY-MM-DD or RFC 3339", value)
+	}
+	if upperBound {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
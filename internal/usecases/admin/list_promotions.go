@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// PromotionOutput represents a promotion in API responses
+type PromotionOutput struct {
+	ID                 string       `json:"id"`
+	Code               string       `json:"code"`
+	Description        string       `json:"description"`
+	Kind               string       `json:"kind"`
+	PercentOff         float64      `json:"percent_off"`
+	AmountOff          domain.Money `json:"amount_off"`
+	BuyCode            *string      `json:"buy_code,omitempty"`
+	BuyQuantity        int          `json:"buy_quantity"`
+	GetQuantity        int          `json:"get_quantity"`
+	MinOrderAmount     domain.Money `json:"min_order_amount"`
+	Currency           string       `json:"currency"`
+	StartsAt           *string      `json:"starts_at,omitempty"`
+	EndsAt             *string      `json:"ends_at,omitempty"`
+	MaxUsesPerCustomer *int         `json:"max_uses_per_customer,omitempty"`
+	Active             bool         `json:"active"`
+	CreatedAt          string       `json:"created_at"`
+	UpdatedAt          string       `json:"updated_at"`
+}
+
+// ListPromotionsOutput is the result of listing promotions
+type ListPromotionsOutput struct {
+	Promotions []PromotionOutput `json:"promotions"`
+	Total      int               `json:"total"`
+}
+
+// ListPromotionsUsecase defines the interface for listing promotions
+type ListPromotionsUsecase interface {
+	Execute(ctx context.Context) (*ListPromotionsOutput, apperrors.ApplicationError)
+}
+
+type listPromotionsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListPromotionsUsecase creates a new instance of ListPromotionsUsecase
+func NewListPromotionsUsecase(contextFactory appcontext.Factory) ListPromotionsUsecase {
+	return &listPromotionsUsecase{contextFactory: contextFactory}
+}
+
+// Execute retrieves all promotions, newest first
+func (u *listPromotionsUsecase) Execute(ctx context.Context) (*ListPromotionsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	promotions, err := app.Repositories.Promotion.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListPromotionsOutput{
+		Promotions: make([]PromotionOutput, 0, len(promotions)),
+		Total:      len(promotions),
+	}
+	for _, p := range promotions {
+		output.Promotions = append(output.Promotions, toPromotionOutput(p))
+	}
+	return output, nil
+}
+
+// toPromotionOutput converts a domain promotion to output
+func toPromotionOutput(promotion *domain.Promotion) PromotionOutput {
+	return PromotionOutput{
+		ID:                 promotion.ID,
+		Code:               promotion.Code,
+		Description:        promotion.Description,
+		Kind:               string(promotion.Kind),
+		PercentOff:         promotion.PercentOff,
+		AmountOff:          promotion.AmountOff,
+		BuyCode:            promotion.BuyCode,
+		BuyQuantity:        promotion.BuyQuantity,
+		GetQuantity:        promotion.GetQuantity,
+		MinOrderAmount:     promotion.MinOrderAmount,
+		Currency:           promotion.Currency,
+		StartsAt:           formatOptionalTime(promotion.StartsAt),
+		EndsAt:             formatOptionalTime(promotion.EndsAt),
+		MaxUsesPerCustomer: promotion.MaxUsesPerCustomer,
+		Active:             promotion.Active,
+		CreatedAt:          promotion.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:          promotion.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -1,22 +1,58 @@
 package admin
 
 import (
+	"fmt"
+	"time"
+
 	"yego/internal/domain"
 )
 
 // OrderOutput represents an order in the admin list
 type OrderOutput struct {
-	ID            string            `json:"id"`
-	ProfileID     *string           `json:"profile_id,omitempty"`
-	UserID        *string           `json:"user_id,omitempty"`
-	Status        string            `json:"status"`
-	StatusMessage *string           `json:"status_message,omitempty"`
-	StatusIndex   int               `json:"status_index"`
-	ETA           string            `json:"eta"`
-	Data          *domain.OrderData `json:"data,omitempty"`
-	CreatedAt     string            `json:"created_at"`
-	UpdatedAt     string            `json:"updated_at"`
-	AllStatuses   []string          `json:"all_statuses"`
+	ID               string               `json:"id"`
+	ProfileID        *string              `json:"profile_id,omitempty"`
+	UserID           *string              `json:"user_id,omitempty"`
+	Status           string               `json:"status"`
+	StatusMessage    *string              `json:"status_message,omitempty"`
+	StatusIndex      int                  `json:"status_index"`
+	ETA              string               `json:"eta"`
+	ETAFrom          *string              `json:"eta_from,omitempty"`
+	ETATo            *string              `json:"eta_to,omitempty"`
+	IsLate           bool                 `json:"is_late"`
+	Data             *domain.OrderData    `json:"data,omitempty"`
+	Pricing          *domain.OrderPricing `json:"pricing,omitempty"`
+	Currency         string               `json:"currency"`
+	Version          int                  `json:"version"`
+	PausedFromStatus *string              `json:"paused_from_status,omitempty"`
+	PauseReason      *string              `json:"pause_reason,omitempty"`
+	ResumeAt         *string              `json:"resume_at,omitempty"`
+	CreatedAt        string               `json:"created_at"`
+	UpdatedAt        string               `json:"updated_at"`
+	AllStatuses      []string             `json:"all_statuses"`
+	NextStatuses     []string             `json:"next_statuses"`
+	History          []StatusEventOutput  `json:"history"`
+	Attachments      []AttachmentOutput   `json:"attachments"`
+}
+
+// AttachmentOutput represents a proof-of-delivery file of an order, URL is the admin download link
+type AttachmentOutput struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"`
+	ContentType string  `json:"content_type"`
+	SizeBytes   int64   `json:"size_bytes"`
+	Checksum    string  `json:"checksum_sha256"`
+	UploadedBy  *string `json:"uploaded_by,omitempty"`
+	URL         string  `json:"url"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// StatusEventOutput represents a single entry of an order's status timeline
+type StatusEventOutput struct {
+	PreviousStatus *string `json:"previous_status,omitempty"`
+	NewStatus      string  `json:"new_status"`
+	ActorUserID    *string `json:"actor_user_id,omitempty"`
+	Message        *string `json:"message,omitempty"`
+	CreatedAt      string  `json:"created_at"`
 }
 
 // ProfileOutput represents a profile in the admin list
@@ -39,29 +75,53 @@ type LocationOutput struct {
 
 // TransactionOutput represents a transaction in the admin list
 type TransactionOutput struct {
-	ID               string  `json:"id"`
-	OrderID          string  `json:"order_id"`
-	UserID           string  `json:"user_id"`
-	ProfileID        *string `json:"profile_id,omitempty"`
-	Amount           float64 `json:"amount"`
-	Currency         string  `json:"currency"`
-	Status           string  `json:"status"`
-	PaymentID        *int    `json:"payment_id,omitempty"`
-	GatewayPaymentID *string `json:"gateway_payment_id,omitempty"`
-	CollectorID      *string `json:"collector_id,omitempty"`
-	Description      *string `json:"description,omitempty"`
-	CreatedAt        string  `json:"created_at"`
-	UpdatedAt        string  `json:"updated_at"`
+	ID               string        `json:"id"`
+	OrderID          string        `json:"order_id"`
+	UserID           string        `json:"user_id"`
+	ProfileID        *string       `json:"profile_id,omitempty"`
+	Amount           domain.Money  `json:"amount"`
+	Currency         string        `json:"currency"`
+	Status           string        `json:"status"`
+	Kind             string        `json:"kind"`
+	PaymentID        *int          `json:"payment_id,omitempty"`
+	GatewayPaymentID *string       `json:"gateway_payment_id,omitempty"`
+	CollectorID      *string       `json:"collector_id,omitempty"`
+	Description      *string       `json:"description,omitempty"`
+	NetAmount        *domain.Money `json:"net_amount,omitempty"`
+	TaxAmount        *domain.Money `json:"tax_amount,omitempty"`
+	CreatedAt        string        `json:"created_at"`
+	UpdatedAt        string        `json:"updated_at"`
 }
 
-// toOrderOutput converts a domain order to output
-func toOrderOutput(order *domain.Order) OrderOutput {
+// toOrderOutput converts a domain order, the statuses it may move to next, its status
+// history and its attachments to output
+func toOrderOutput(order *domain.Order, next []domain.OrderStatus, history []*domain.OrderStatusEvent, attachments []AttachmentOutput) OrderOutput {
 	allStatuses := make([]string, len(domain.ValidStatuses))
 	for i, s := range domain.ValidStatuses {
 		allStatuses[i] = string(s)
 	}
 
-	return OrderOutput{
+	nextStatuses := make([]string, 0)
+	for _, s := range next {
+		nextStatuses = append(nextStatuses, string(s))
+	}
+
+	historyOutput := make([]StatusEventOutput, 0, len(history))
+	for _, e := range history {
+		eventOutput := StatusEventOutput{
+			NewStatus:   string(e.NewStatus),
+			ActorUserID: e.ActorUserID,
+			Message:     e.Message,
+			CreatedAt:   e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if e.PreviousStatus != nil {
+			previous := string(*e.PreviousStatus)
+			eventOutput.PreviousStatus = &previous
+		}
+		historyOutput = append(historyOutput, eventOutput)
+	}
+
+	output := OrderOutput{
 		ID:            order.ID,
 		ProfileID:     order.ProfileID,
 		UserID:        order.UserID,
@@ -69,11 +129,57 @@ func toOrderOutput(order *domain.Order) OrderOutput {
 		StatusMessage: order.StatusMessage,
 		StatusIndex:   order.StatusIndex(),
 		ETA:           order.ETA,
+		ETAFrom:       formatOptionalTime(order.ETAFrom),
+		ETATo:         formatOptionalTime(order.ETATo),
+		IsLate:        order.IsLate(time.Now()),
 		Data:          order.Data,
+		Pricing:       order.Pricing,
+		Currency:      order.Currency,
+		Version:       order.Version,
+		PauseReason:   order.PauseReason,
+		ResumeAt:      formatOptionalTime(order.ResumeAt),
 		CreatedAt:     order.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:     order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 		AllStatuses:   allStatuses,
+		NextStatuses:  nextStatuses,
+		History:       historyOutput,
+		Attachments:   attachments,
+	}
+	if output.Attachments == nil {
+		output.Attachments = []AttachmentOutput{}
+	}
+	if order.PausedFromStatus != nil {
+		pausedFrom := string(*order.PausedFromStatus)
+		output.PausedFromStatus = &pausedFrom
+	}
+	return output
+}
+
+// toAttachmentOutputs converts attachments to output, linking each one to its admin download route
+func toAttachmentOutputs(backendURL string, attachments []*domain.Attachment) []AttachmentOutput {
+	outputs := make([]AttachmentOutput, 0, len(attachments))
+	for _, a := range attachments {
+		outputs = append(outputs, AttachmentOutput{
+			ID:          a.ID,
+			Kind:        string(a.Kind),
+			ContentType: a.ContentType,
+			SizeBytes:   a.SizeBytes,
+			Checksum:    a.Checksum,
+			UploadedBy:  a.UploadedBy,
+			URL:         fmt.Sprintf("%s/api/admin/orders/%s/attachments/%s", backendURL, a.OrderID, a.ID),
+			CreatedAt:   a.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+	return outputs
+}
+
+// formatOptionalTime formats a nullable timestamp for outputs
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
+	formatted := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &formatted
 }
 
 // toTransactionOutput converts a domain transaction to output
@@ -86,10 +192,13 @@ func toTransactionOutput(transaction *domain.Transaction) TransactionOutput {
 		Amount:           transaction.Amount,
 		Currency:         transaction.Currency,
 		Status:           transaction.Status,
+		Kind:             transaction.Kind,
 		PaymentID:        transaction.PaymentID,
 		GatewayPaymentID: transaction.GatewayPaymentID,
 		CollectorID:      transaction.CollectorID,
 		Description:      transaction.Description,
+		NetAmount:        transaction.NetAmount,
+		TaxAmount:        transaction.TaxAmount,
 		CreatedAt:        transaction.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:        transaction.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
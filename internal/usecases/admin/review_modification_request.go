@@ -0,0 +1,171 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of settling the price difference of an approved modification
+const (
+	SettlementNone     = "none"
+	SettlementCharged  = "charged"
+	SettlementRefunded = "refunded"
+	SettlementFailed   = "failed"
+)
+
+// ReviewModificationInput represents a manager's decision on a modification request
+type ReviewModificationInput struct {
+	OrderID string
+	UserID  string
+	Approve bool
+	Note    string
+}
+
+// ReviewModificationOutput represents the result of reviewing a modification request
+type ReviewModificationOutput struct {
+	Order            OrderOutput                            `json:"order"`
+	Request          orderUsecase.ModificationRequestOutput `json:"request"`
+	SettlementStatus string                                 `json:"settlement_status"`
+	SettlementAmount domain.Money                           `json:"settlement_amount"`
+}
+
+// ReviewModificationUsecase defines the interface for approving or rejecting modification requests
+type ReviewModificationUsecase interface {
+	Execute(ctx context.Context, input ReviewModificationInput) (*ReviewModificationOutput, apperrors.ApplicationError)
+}
+
+type reviewModificationUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewReviewModificationUsecase creates a new instance of ReviewModificationUsecase
+func NewReviewModificationUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) ReviewModificationUsecase {
+	return &reviewModificationUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute approves or rejects the pending modification request of an order and
+// returns the order to the status it had before the request. On approval the new
+// items replace the old ones and, if the order was already paid, the difference is
+// charged to the saved payment method or refunded.
+func (u *reviewModificationUsecase) Execute(ctx context.Context, input ReviewModificationInput) (*ReviewModificationOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.StatusModificationRequested {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestNotFoundError,
+			fmt.Errorf("order %s is %s", order.ID, order.Status))
+	}
+
+	request, err := app.Repositories.ModificationRequest.GetPendingByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if transitionErr := orderUsecase.ValidateStatusTransition(ctx, app, order, request.PreviousStatus); transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	var quote *ModificationReviewOutput
+	if input.Approve {
+		quote, err = quoteModification(ctx, app, order, request, u.calculateDeliveryFeeUse)
+		if err != nil {
+			return nil, err
+		}
+		order.Data = &domain.OrderData{Items: request.Items}
+		request.Status = domain.ModificationRequestApproved
+		request.PriceDifference = &quote.PriceDifference
+	} else {
+		request.Status = domain.ModificationRequestRejected
+	}
+
+	order.Status = request.PreviousStatus
+	if input.Note != "" {
+		order.StatusMessage = &input.Note
+		request.ReviewNote = &input.Note
+	}
+	request.ReviewedBy = &input.UserID
+
+	// The versioned order update is what serializes concurrent reviews
+	updatedOrder, err := app.Repositories.Order.Update(ctx, order, orderUsecase.ActorUserID(input.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if input.Approve {
+		orderUsecase.RefreshPricing(ctx, app, updatedOrder, u.calculateDeliveryFeeUse)
+	}
+
+	resolved, err := app.Repositories.ModificationRequest.Resolve(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ReviewModificationOutput{
+		Request:          orderUsecase.ToModificationRequestOutput(resolved),
+		SettlementStatus: SettlementNone,
+	}
+
+	if quote != nil && quote.PaidAmount > 0 && quote.PriceDifference != 0 {
+		output.SettlementStatus, output.SettlementAmount = settleDifference(ctx, app, updatedOrder, quote.PriceDifference)
+	}
+
+	history, err := app.Repositories.Order.GetStatusEvents(ctx, updatedOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := app.Repositories.Attachment.ListByOrderID(ctx, updatedOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	next, err := orderUsecase.AllowedNextStatuses(ctx, app, updatedOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	output.Order = toOrderOutput(updatedOrder, next, history, toAttachmentOutputs(app.ConfigService.BackendURL, attachments))
+
+	return output, nil
+}
+
+// settleDifference charges a positive price difference or refunds a negative one.
+// Failures are reported rather than returned: the modification stays approved and
+// managers settle the payment by hand.
+func settleDifference(ctx context.Context, app *appcontext.Context, order *domain.Order, difference domain.Money) (string, domain.Money) {
+	if difference > 0 {
+		description := fmt.Sprintf("Ajuste por modificación del pedido %s", order.ID)
+		if _, err := orderUsecase.ChargeOrderAmount(ctx, app, order, difference, description); err != nil {
+			log.Printf("ReviewModification: failed to charge difference for order %s: %v", order.ID, err)
+			return SettlementFailed, difference
+		}
+		return SettlementCharged, difference
+	}
+
+	refunded, err := orderUsecase.RefundOrderPayment(ctx, app, order, -difference, "modificación del pedido")
+	if err != nil {
+		log.Printf("ReviewModification: failed to refund difference for order %s: %v", order.ID, err)
+		return SettlementFailed, -difference
+	}
+	if refunded == 0 {
+		return SettlementNone, 0
+	}
+	return SettlementRefunded, refunded
+}
@@ -2,11 +2,15 @@ package admin
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+	orderUsecase "yego/internal/usecases/order"
 	settingsUsecase "yego/internal/usecases/settings"
 
 	"github.com/google/uuid"
@@ -14,11 +18,18 @@ import (
 
 // UpdateOrderInput represents the input for updating an order
 type UpdateOrderInput struct {
-	Status        *string           `json:"status,omitempty"`
-	StatusMessage *string           `json:"status_message,omitempty"`
-	ETA           *string           `json:"eta,omitempty"`
-	Data          *domain.OrderData `json:"data,omitempty"`
-	Token         string            `json:"-"`
+	Status          *string           `json:"status,omitempty"`
+	StatusMessage   *string           `json:"status_message,omitempty"`
+	ETA             *string           `json:"eta,omitempty"`
+	ETAFrom         *time.Time        `json:"eta_from,omitempty"`
+	ETATo           *time.Time        `json:"eta_to,omitempty"`
+	Data            *domain.OrderData `json:"data,omitempty"`
+	Currency        *string           `json:"currency,omitempty"` // only while the order has no payments
+	DeliveryCode    string            `json:"delivery_code,omitempty"`
+	OverrideReason  string            `json:"delivery_override_reason,omitempty"` // deliver without the code
+	Token           string            `json:"-"`
+	UserID          string            `json:"-"`
+	ExpectedVersion int               `json:"-"` // from If-Match, required
 }
 
 // UpdateOrderUsecase defines the interface for updating orders
@@ -28,13 +39,15 @@ type UpdateOrderUsecase interface {
 
 type updateOrderUsecase struct {
 	contextFactory          appcontext.Factory
+	notificationSvc         notification.Service
 	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
 }
 
 // NewUpdateOrderUsecase creates a new instance of UpdateOrderUsecase
-func NewUpdateOrderUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) UpdateOrderUsecase {
+func NewUpdateOrderUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) UpdateOrderUsecase {
 	return &updateOrderUsecase{
 		contextFactory:          contextFactory,
+		notificationSvc:         notificationSvc,
 		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
 	}
 }
@@ -55,12 +68,32 @@ func (u *updateOrderUsecase) Execute(ctx context.Context, id string, input Updat
 	}
 	previousStatus := order.Status
 
+	if input.ExpectedVersion != order.Version {
+		return nil, apperrors.NewApplicationError(mappings.OrderVersionConflictError,
+			&domain.OrderVersionConflict{OrderID: order.ID, Expected: input.ExpectedVersion, Found: order.Version})
+	}
+
 	// Update fields if provided
 	if input.Status != nil {
 		if !domain.IsValidStatus(*input.Status) {
 			return nil, apperrors.NewApplicationError(mappings.OrderInvalidStatusError, nil)
 		}
-		order.Status = domain.OrderStatus(*input.Status)
+		if cancelErr := orderUsecase.RequireCancelEndpoint(order, domain.OrderStatus(*input.Status)); cancelErr != nil {
+			return nil, cancelErr
+		}
+		if transitionErr := orderUsecase.ValidateStatusTransition(ctx, app, order, domain.OrderStatus(*input.Status)); transitionErr != nil {
+			return nil, transitionErr
+		}
+		next := domain.OrderStatus(*input.Status)
+		if next == domain.StatusDelivered && previousStatus != domain.StatusDelivered {
+			if codeErr := orderUsecase.AuthorizeDelivery(ctx, app, order.ID, input.DeliveryCode, input.OverrideReason, input.UserID); codeErr != nil {
+				return nil, codeErr
+			}
+			if input.DeliveryCode == "" && input.StatusMessage == nil {
+				order.StatusMessage = &input.OverrideReason
+			}
+		}
+		applyStatus(order, next, input.StatusMessage)
 	}
 
 	if input.StatusMessage != nil {
@@ -71,20 +104,93 @@ func (u *updateOrderUsecase) Execute(ctx context.Context, id string, input Updat
 		order.ETA = *input.ETA
 	}
 
+	if input.ETAFrom != nil {
+		order.ETAFrom = input.ETAFrom
+	}
+	if input.ETATo != nil {
+		order.ETATo = input.ETATo
+	}
+	if etaErr := orderUsecase.ValidateETAWindow(order.ETAFrom, order.ETATo); etaErr != nil {
+		return nil, etaErr
+	}
+
 	if input.Data != nil {
 		order.Data = input.Data
 	}
 
+	currencyChanged := false
+	if input.Currency != nil {
+		currency := domain.NormalizeCurrency(*input.Currency)
+		if !domain.IsValidCurrency(currency) {
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidCurrencyError, nil)
+		}
+		if currency != order.Currency {
+			transactions, listErr := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+			if listErr != nil {
+				return nil, listErr
+			}
+			if len(transactions) > 0 {
+				return nil, apperrors.NewApplicationError(mappings.OrderCurrencyLockedError,
+					fmt.Errorf("order %s has %d transactions in %s", order.ID, len(transactions), order.Currency))
+			}
+			order.Currency = currency
+			currencyChanged = true
+		}
+	}
+
 	// Save changes
-	updatedOrder, err := app.Repositories.Order.Update(ctx, order)
+	updatedOrder, err := app.Repositories.Order.Update(ctx, order, orderUsecase.ActorUserID(input.UserID))
 	if err != nil {
 		return nil, err
 	}
 
 	// Payment processing removed - payments are now processed at order creation
 	// Keeping this comment for reference
-	_ = previousStatus // Keep variable to avoid compilation error
 
-	output := toOrderOutput(updatedOrder)
+	if input.Data != nil || currencyChanged {
+		orderUsecase.RefreshPricing(ctx, app, updatedOrder, u.calculateDeliveryFeeUse)
+	}
+
+	if updatedOrder.Status != previousStatus {
+		if updatedOrder.Status == domain.StatusConfirmed {
+			orderUsecase.EnsureConfirmedPricing(ctx, app, updatedOrder, u.calculateDeliveryFeeUse)
+		}
+		orderUsecase.IssueDeliveryCode(ctx, app, updatedOrder)
+		orderUsecase.NotifyOrderUpdated(u.notificationSvc, updatedOrder, previousStatus)
+	}
+
+	history, err := app.Repositories.Order.GetStatusEvents(ctx, updatedOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := app.Repositories.Attachment.ListByOrderID(ctx, updatedOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	next, err := orderUsecase.AllowedNextStatuses(ctx, app, updatedOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOrderOutput(updatedOrder, next, history, toAttachmentOutputs(app.ConfigService.BackendURL, attachments))
 	return &output, nil
 }
+
+// applyStatus moves an already validated order to next. Pausing keeps the order's
+// pause reason unless a status message replaces it; leaving PAUSED clears it.
+func applyStatus(order *domain.Order, next domain.OrderStatus, statusMessage *string) {
+	switch {
+	case next == domain.StatusPaused:
+		reason := order.PauseReason
+		if statusMessage != nil {
+			reason = statusMessage
+		}
+		order.Pause(reason, order.ResumeAt)
+	case order.Status == domain.StatusPaused:
+		order.Status = next
+		order.ClearPause()
+	default:
+		order.Status = next
+	}
+}
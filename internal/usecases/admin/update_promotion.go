@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// UpdatePromotionUsecase defines the interface for updating a promotion
+type UpdatePromotionUsecase interface {
+	Execute(ctx context.Context, id string, input PromotionInput) (*PromotionOutput, apperrors.ApplicationError)
+}
+
+type updatePromotionUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewUpdatePromotionUsecase creates a new instance of UpdatePromotionUsecase
+func NewUpdatePromotionUsecase(contextFactory appcontext.Factory) UpdatePromotionUsecase {
+	return &updatePromotionUsecase{contextFactory: contextFactory}
+}
+
+// Execute replaces the rule of a promotion. Orders that already redeemed it are
+// repriced with the new rule the next time their snapshot is taken.
+func (u *updatePromotionUsecase) Execute(ctx context.Context, id string, input PromotionInput) (*PromotionOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.PromotionInvalidIDError, err)
+	}
+
+	promotion, err := app.Repositories.Promotion.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appErr := applyPromotionInput(ctx, app, promotion, input); appErr != nil {
+		return nil, appErr
+	}
+
+	updated, err := app.Repositories.Promotion.Update(ctx, promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toPromotionOutput(updated)
+	return &output, nil
+}
@@ -2,35 +2,56 @@ package admin
 
 import (
 	"yego/internal/platform/appcontext"
+	"yego/internal/usecases/notification"
 	settingsUsecase "yego/internal/usecases/settings"
 )
 
 // Usecases aggregates all admin-related use cases
 type Usecases struct {
-	ListProfiles     ListProfilesUsecase
-	ListOrders       ListOrdersUsecase
-	ListTransactions ListTransactionsUsecase
-	UpdateOrder      UpdateOrderUsecase
-	UploadImport     UploadImportUsecase
-	ListImports      ListImportsUsecase
-	CreateImport     CreateImportUsecase
-	UpdateImport     UpdateImportUsecase
-	DeleteImport     DeleteImportUsecase
-	ClearImports     ClearImportsUsecase
+	ListProfiles       ListProfilesUsecase
+	ListOrders         ListOrdersUsecase
+	ListTransactions   ListTransactionsUsecase
+	ExportOrders       ExportOrdersUsecase
+	ExportTransactions ExportTransactionsUsecase
+	UpdateOrder        UpdateOrderUsecase
+	BulkUpdateOrders   BulkUpdateOrdersUsecase
+	GetModification    GetModificationRequestUsecase
+	ReviewModification ReviewModificationUsecase
+	UploadImport       UploadImportUsecase
+	ListImports        ListImportsUsecase
+	CreateImport       CreateImportUsecase
+	UpdateImport       UpdateImportUsecase
+	DeleteImport       DeleteImportUsecase
+	ClearImports       ClearImportsUsecase
+	GetAttachment      GetAttachmentUsecase
+	ListPromotions     ListPromotionsUsecase
+	CreatePromotion    CreatePromotionUsecase
+	UpdatePromotion    UpdatePromotionUsecase
+	DeletePromotion    DeletePromotionUsecase
 }
 
 // NewUsecases creates all admin use cases
-func NewUsecases(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) *Usecases {
+func NewUsecases(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) *Usecases {
 	return &Usecases{
-		ListProfiles:     NewListProfilesUsecase(contextFactory),
-		ListOrders:       NewListOrdersUsecase(contextFactory),
-		ListTransactions: NewListTransactionsUsecase(contextFactory),
-		UpdateOrder:      NewUpdateOrderUsecase(contextFactory, calculateDeliveryFeeUse),
-		UploadImport:     NewUploadImportUsecase(contextFactory),
-		ListImports:      NewListImportsUsecase(contextFactory),
-		CreateImport:     NewCreateImportUsecase(contextFactory),
-		UpdateImport:     NewUpdateImportUsecase(contextFactory),
-		DeleteImport:     NewDeleteImportUsecase(contextFactory),
-		ClearImports:     NewClearImportsUsecase(contextFactory),
+		ListProfiles:       NewListProfilesUsecase(contextFactory),
+		ListOrders:         NewListOrdersUsecase(contextFactory),
+		ListTransactions:   NewListTransactionsUsecase(contextFactory),
+		ExportOrders:       NewExportOrdersUsecase(contextFactory),
+		ExportTransactions: NewExportTransactionsUsecase(contextFactory),
+		UpdateOrder:        NewUpdateOrderUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse),
+		BulkUpdateOrders:   NewBulkUpdateOrdersUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse),
+		GetModification:    NewGetModificationRequestUsecase(contextFactory, calculateDeliveryFeeUse),
+		ReviewModification: NewReviewModificationUsecase(contextFactory, calculateDeliveryFeeUse),
+		UploadImport:       NewUploadImportUsecase(contextFactory),
+		ListImports:        NewListImportsUsecase(contextFactory),
+		CreateImport:       NewCreateImportUsecase(contextFactory),
+		UpdateImport:       NewUpdateImportUsecase(contextFactory),
+		DeleteImport:       NewDeleteImportUsecase(contextFactory),
+		ClearImports:       NewClearImportsUsecase(contextFactory),
+		GetAttachment:      NewGetAttachmentUsecase(contextFactory),
+		ListPromotions:     NewListPromotionsUsecase(contextFactory),
+		CreatePromotion:    NewCreatePromotionUsecase(contextFactory),
+		UpdatePromotion:    NewUpdatePromotionUsecase(contextFactory),
+		DeletePromotion:    NewDeletePromotionUsecase(contextFactory),
 	}
 }
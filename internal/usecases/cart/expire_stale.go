@@ -0,0 +1,32 @@
+package cart
+
+import (
+	"context"
+	"time"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// ExpireStaleUsecase defines the interface for expiring abandoned carts
+type ExpireStaleUsecase interface {
+	Execute(ctx context.Context) (int, apperrors.ApplicationError)
+}
+
+type expireStaleUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewExpireStaleUsecase creates a new instance of ExpireStaleUsecase
+func NewExpireStaleUsecase(contextFactory appcontext.Factory) ExpireStaleUsecase {
+	return &expireStaleUsecase{contextFactory: contextFactory}
+}
+
+// Execute expires the open carts inactive for longer than the configured cart TTL
+// and returns how many were expired
+func (u *expireStaleUsecase) Execute(ctx context.Context) (int, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	now := time.Now()
+	return app.Repositories.Cart.ExpireInactive(ctx, now.Add(-app.ConfigService.CartTTL), now)
+}
@@ -0,0 +1,105 @@
+package cart
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// GetUsecase defines the interface for showing the user's cart
+type GetUsecase interface {
+	Execute(ctx context.Context, userID string) (*CartOutput, apperrors.ApplicationError)
+}
+
+type getUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewGetUsecase creates a new instance of GetUsecase
+func NewGetUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) GetUsecase {
+	return &getUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute returns the user's open cart with a live quote. Users without one get
+// an empty cart, which is only stored once they add an item.
+func (u *getUsecase) Execute(ctx context.Context, userID string) (*CartOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	cart, err := openCart(ctx, app, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		settings, settingsErr := app.Repositories.Settings.Get(ctx)
+		if settingsErr != nil {
+			return nil, settingsErr
+		}
+		cart = &domain.Cart{
+			UserID:   userID,
+			Status:   domain.CartOpen,
+			Currency: settings.Currency(),
+		}
+	}
+
+	output := toCartOutput(ctx, app, cart, u.calculateDeliveryFeeUse)
+	return &output, nil
+}
+
+// openCart returns the user's open cart, expiring it first when it has been
+// inactive too long. With create, a missing cart is started in the business
+// currency; otherwise nil is returned.
+func openCart(ctx context.Context, app *appcontext.Context, userID string, create bool) (*domain.Cart, apperrors.ApplicationError) {
+	cart, err := app.Repositories.Cart.GetOpenByUserID(ctx, userID)
+	if err != nil {
+		if err.Code() != mappings.CartNotFoundError.Code {
+			return nil, err
+		}
+		cart = nil
+	}
+	if cart != nil && cart.IsExpiredAt(time.Now(), app.ConfigService.CartTTL) {
+		expireCart(ctx, app, cart)
+		cart = nil
+	}
+	if cart != nil || !create {
+		return cart, nil
+	}
+
+	settings, err := app.Repositories.Settings.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	created, err := app.Repositories.Cart.Create(ctx, &domain.Cart{
+		UserID:   userID,
+		Data:     &domain.OrderData{Items: []domain.OrderItem{}},
+		Currency: settings.Currency(),
+	})
+	if err != nil {
+		if err.Code() == mappings.CartAlreadyOpenError.Code {
+			// Another request started the cart meanwhile
+			return app.Repositories.Cart.GetOpenByUserID(ctx, userID)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// expireCart expires an open cart found past its inactivity limit before the
+// worker got to it
+func expireCart(ctx context.Context, app *appcontext.Context, cart *domain.Cart) {
+	now := time.Now()
+	cart.Status = domain.CartExpired
+	cart.ExpiredAt = &now
+	if _, err := app.Repositories.Cart.Transition(ctx, cart, domain.CartOpen); err != nil {
+		log.Printf("Cart: failed to expire cart %s: %v", cart.ID, err)
+	}
+}
@@ -0,0 +1,132 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// Cart item actions a customer can take
+const (
+	ActionAdd    = "add"    // adds Quantity units of the product, priced from the catalog
+	ActionUpdate = "update" // sets the quantity of the item with Key, zero removes it
+	ActionRemove = "remove" // takes the item with Key out
+)
+
+// ManageItemsInput represents a change to the items of the user's cart
+type ManageItemsInput struct {
+	UserID   string
+	Action   string
+	Key      string // item code, or name for products without one; update and remove
+	Code     string // add
+	Name     string // add, when the product has no code
+	Quantity int
+	Weight   *int // add, in grams for the delivery fee
+}
+
+// ManageItemsUsecase defines the interface for adding, updating and removing cart items
+type ManageItemsUsecase interface {
+	Execute(ctx context.Context, input ManageItemsInput) (*CartOutput, apperrors.ApplicationError)
+}
+
+type manageItemsUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewManageItemsUsecase creates a new instance of ManageItemsUsecase
+func NewManageItemsUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) ManageItemsUsecase {
+	return &manageItemsUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute applies the action to the user's open cart, starting one when adding to
+// none, and returns the cart with a fresh quote. Prices always come from the
+// catalog, never from the client.
+func (u *manageItemsUsecase) Execute(ctx context.Context, input ManageItemsInput) (*CartOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	cart, err := openCart(ctx, app, input.UserID, input.Action == ActionAdd)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, apperrors.NewApplicationError(mappings.CartNotFoundError, nil)
+	}
+
+	switch input.Action {
+	case ActionAdd:
+		if addErr := u.addItem(ctx, app, cart, input); addErr != nil {
+			return nil, addErr
+		}
+	case ActionUpdate:
+		if input.Quantity < 0 {
+			return nil, apperrors.NewApplicationError(mappings.CartInvalidItemError, errors.New("quantity can't be negative"))
+		}
+		i := cart.FindItem(input.Key)
+		if i < 0 {
+			return nil, apperrors.NewApplicationError(mappings.CartItemNotFoundError, fmt.Errorf("no item %q", input.Key))
+		}
+		if input.Quantity == 0 {
+			cart.RemoveItem(input.Key)
+		} else {
+			cart.Data.Items[i].Quantity = input.Quantity
+		}
+	case ActionRemove:
+		if !cart.RemoveItem(input.Key) {
+			return nil, apperrors.NewApplicationError(mappings.CartItemNotFoundError, fmt.Errorf("no item %q", input.Key))
+		}
+	default:
+		return nil, apperrors.NewApplicationError(mappings.CartInvalidItemError, fmt.Errorf("unknown action %q", input.Action))
+	}
+
+	updated, err := app.Repositories.Cart.Update(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toCartOutput(ctx, app, updated, u.calculateDeliveryFeeUse)
+	return &output, nil
+}
+
+// addItem looks the product up in the catalog and adds it to the cart, on top of
+// the units already there
+func (u *manageItemsUsecase) addItem(ctx context.Context, app *appcontext.Context, cart *domain.Cart, input ManageItemsInput) apperrors.ApplicationError {
+	item := domain.OrderItem{
+		Code:     strings.TrimSpace(input.Code),
+		Name:     strings.TrimSpace(input.Name),
+		Quantity: input.Quantity,
+		Weight:   input.Weight,
+	}
+	if (item.Code == "" && item.Name == "") || item.Quantity <= 0 {
+		return apperrors.NewApplicationError(mappings.CartInvalidItemError, nil)
+	}
+
+	records, err := app.Repositories.ImportRecord.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	priced, found := orderUsecase.CatalogItem(records, item)
+	if !found {
+		return apperrors.NewApplicationError(mappings.CartProductNotFoundError, fmt.Errorf("no catalog product for %q", domain.CartItemKey(item)))
+	}
+
+	if i := cart.FindItem(domain.CartItemKey(priced)); i >= 0 {
+		priced.Quantity += cart.Data.Items[i].Quantity
+		if priced.Weight == nil {
+			priced.Weight = cart.Data.Items[i].Weight
+		}
+	}
+	cart.SetItem(priced)
+	return nil
+}
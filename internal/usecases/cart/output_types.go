@@ -0,0 +1,71 @@
+package cart
+
+import (
+	"context"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	pricingUsecase "yego/internal/usecases/pricing"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// CartOutput represents a cart with its live quote
+type CartOutput struct {
+	ID        string               `json:"id,omitempty"` // empty until the first item is added
+	Status    string               `json:"status"`
+	Items     []domain.OrderItem   `json:"items"`
+	Currency  string               `json:"currency"`
+	Pricing   *domain.OrderPricing `json:"pricing,omitempty"` // nil when the cart couldn't be quoted
+	OrderID   *string              `json:"order_id,omitempty"`
+	ExpiresAt *string              `json:"expires_at,omitempty"`
+	UpdatedAt *string              `json:"updated_at,omitempty"`
+}
+
+// toCartOutput converts a cart to output, quoting it at the current prices and
+// delivery fee to the user's location
+func toCartOutput(ctx context.Context, app *appcontext.Context, cart *domain.Cart, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) CartOutput {
+	items := cart.Items()
+	if items == nil {
+		items = []domain.OrderItem{}
+	}
+	output := CartOutput{
+		ID:       cart.ID,
+		Status:   string(cart.Status),
+		Items:    items,
+		Currency: cart.Currency,
+		OrderID:  cart.OrderID,
+	}
+	if cart.ID != "" {
+		expiresAt := cart.ExpiresAt(app.ConfigService.CartTTL).UTC().Format("2006-01-02T15:04:05Z")
+		updatedAt := cart.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z")
+		output.ExpiresAt = &expiresAt
+		output.UpdatedAt = &updatedAt
+	}
+
+	if calculateDeliveryFeeUse != nil {
+		output.Pricing = quoteCart(ctx, app, cart, calculateDeliveryFeeUse)
+	}
+	return output
+}
+
+// quoteCart prices the cart like an order, with the delivery fee to the user's
+// location when they have one. Quotes are informative, so failures are logged.
+func quoteCart(ctx context.Context, app *appcontext.Context, cart *domain.Cart, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) *domain.OrderPricing {
+	profile, profileErr := app.Repositories.Profile.GetByUserID(ctx, cart.UserID)
+	if profileErr != nil {
+		profile = nil
+	}
+
+	draft := &domain.Order{
+		UserID:   &cart.UserID,
+		Data:     &domain.OrderData{Items: cart.Items()},
+		Currency: cart.Currency,
+	}
+	pricing, err := pricingUsecase.PriceOrder(ctx, app, draft, profile, calculateDeliveryFeeUse)
+	if err != nil {
+		log.Printf("Cart: failed to quote cart of user %s: %v", cart.UserID, err)
+		return nil
+	}
+	return pricing
+}
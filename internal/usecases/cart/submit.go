@@ -0,0 +1,109 @@
+package cart
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// SubmitInput represents the input for turning the user's cart into an order
+type SubmitInput struct {
+	UserID       string
+	ETA          string
+	DeliverySlot *deliveryslotUsecase.SlotSelection
+}
+
+// SubmitOutput represents the order created from a cart
+type SubmitOutput struct {
+	CartID string                       `json:"cart_id"`
+	Data   orderUsecase.OrderOutputData `json:"data"`
+}
+
+// SubmitUsecase defines the interface for submitting carts
+type SubmitUsecase interface {
+	Execute(ctx context.Context, input SubmitInput) (*SubmitOutput, apperrors.ApplicationError)
+}
+
+type submitUsecase struct {
+	contextFactory appcontext.Factory
+	createUse      orderUsecase.CreateUsecase
+}
+
+// NewSubmitUsecase creates a new instance of SubmitUsecase. Orders go through
+// createUse so items are repriced and slots booked like any other order.
+func NewSubmitUsecase(contextFactory appcontext.Factory, createUse orderUsecase.CreateUsecase) SubmitUsecase {
+	return &submitUsecase{
+		contextFactory: contextFactory,
+		createUse:      createUse,
+	}
+}
+
+// Execute creates a CREATED order from the user's open cart and closes the cart.
+// The cart is claimed before the order is created, so a double submit creates one
+// order; if creating it fails the cart is reopened. The order is then paid like
+// any other, with /pay or a payment link.
+func (u *submitUsecase) Execute(ctx context.Context, input SubmitInput) (*SubmitOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	cart, err := openCart(ctx, app, input.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, apperrors.NewApplicationError(mappings.CartNotFoundError, nil)
+	}
+	if len(cart.Items()) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.CartEmptyError, nil)
+	}
+
+	profile, err := app.Repositories.Profile.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cart.Status = domain.CartSubmitted
+	cart.SubmittedAt = &now
+	claimed, err := app.Repositories.Cart.Transition(ctx, cart, domain.CartOpen)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, apperrors.NewApplicationError(mappings.CartNotOpenError, nil)
+	}
+
+	userID := input.UserID
+	created, err := u.createUse.Execute(ctx, orderUsecase.CreateInput{
+		ProfileID:    profile.ID,
+		ETA:          input.ETA,
+		Currency:     cart.Currency,
+		DeliverySlot: input.DeliverySlot,
+		UserID:       &userID,
+		Data:         cart.Data,
+	})
+	if err != nil {
+		cart.Status = domain.CartOpen
+		cart.SubmittedAt = nil
+		if _, reopenErr := app.Repositories.Cart.Transition(ctx, cart, domain.CartSubmitted); reopenErr != nil {
+			log.Printf("Cart: failed to reopen cart %s after a failed submit: %v", cart.ID, reopenErr)
+		}
+		return nil, err
+	}
+
+	cart.OrderID = &created.Data.ID
+	if _, linkErr := app.Repositories.Cart.Transition(ctx, cart, domain.CartSubmitted); linkErr != nil {
+		log.Printf("Cart: failed to link cart %s to order %s: %v", cart.ID, created.Data.ID, linkErr)
+	}
+
+	return &SubmitOutput{
+		CartID: cart.ID,
+		Data:   created.Data,
+	}, nil
+}
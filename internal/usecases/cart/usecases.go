@@ -0,0 +1,25 @@
+package cart
+
+import (
+	"yego/internal/platform/appcontext"
+	orderUsecase "yego/internal/usecases/order"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// Usecases aggregates all cart use cases
+type Usecases struct {
+	Get         GetUsecase
+	ManageItems ManageItemsUsecase
+	Submit      SubmitUsecase
+	ExpireStale ExpireStaleUsecase
+}
+
+// NewUsecases creates all cart use cases
+func NewUsecases(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, createOrderUse orderUsecase.CreateUsecase) *Usecases {
+	return &Usecases{
+		Get:         NewGetUsecase(contextFactory, calculateDeliveryFeeUse),
+		ManageItems: NewManageItemsUsecase(contextFactory, calculateDeliveryFeeUse),
+		Submit:      NewSubmitUsecase(contextFactory, createOrderUse),
+		ExpireStale: NewExpireStaleUsecase(contextFactory),
+	}
+}
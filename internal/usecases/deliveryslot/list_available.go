@@ -0,0 +1,94 @@
+package deliveryslot
+
+import (
+	"context"
+	"time"
+
+	"yego/internal/adapters/datasources/repositories/deliveryslot"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// ListAvailableOutput lists the delivery slots of one date
+type ListAvailableOutput struct {
+	Date     string                `json:"date"`
+	Timezone string                `json:"timezone"`
+	Slots    []AvailableSlotOutput `json:"slots"`
+}
+
+// AvailableSlotOutput describes one slot and how many places are left
+type AvailableSlotOutput struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Label     string `json:"label"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+	CutoffAt  string `json:"cutoff_at"`
+	Capacity  int    `json:"capacity"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+	Bookable  bool   `json:"bookable"` // false once full or past the cutoff
+}
+
+// ListAvailableUsecase defines the interface for listing delivery slots of a date
+type ListAvailableUsecase interface {
+	Execute(ctx context.Context, date string) (*ListAvailableOutput, apperrors.ApplicationError)
+}
+
+type listAvailableUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListAvailableUsecase creates a new instance of ListAvailableUsecase
+func NewListAvailableUsecase(contextFactory appcontext.Factory) ListAvailableUsecase {
+	return &listAvailableUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists the slots configured for the weekday of date with their remaining capacity
+func (u *listAvailableUsecase) Execute(ctx context.Context, date string) (*ListAvailableOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	settings, err := app.Repositories.Settings.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loc := settings.Location()
+
+	slots, err := slotsForDate(settings.DeliverySlots, date, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := app.Repositories.DeliverySlot.GetReservedCounts(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	output := &ListAvailableOutput{
+		Date:     date,
+		Timezone: loc.String(),
+		Slots:    make([]AvailableSlotOutput, 0, len(slots)),
+	}
+	for _, slot := range slots {
+		reserved := counts[deliveryslot.UsageKey(slot.Start, slot.End)]
+		available := slot.Capacity - reserved
+		if available < 0 {
+			available = 0
+		}
+		output.Slots = append(output.Slots, AvailableSlotOutput{
+			Start:     slot.Start,
+			End:       slot.End,
+			Label:     slot.Label(),
+			StartsAt:  slot.StartsAt.Format(time.RFC3339),
+			EndsAt:    slot.EndsAt.Format(time.RFC3339),
+			CutoffAt:  slot.CutoffAt.Format(time.RFC3339),
+			Capacity:  slot.Capacity,
+			Reserved:  reserved,
+			Available: available,
+			Bookable:  available > 0 && now.Before(slot.CutoffAt),
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,68 @@
+package deliveryslot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// dateLayout is the format of slot dates in requests and storage
+const dateLayout = "2006-01-02"
+
+// SlotSelection identifies the slot a customer picked
+type SlotSelection struct {
+	Date  string `json:"date" binding:"required"`  // YYYY-MM-DD in the business timezone
+	Start string `json:"start" binding:"required"` // HH:MM, as listed by the slots endpoint
+}
+
+// ReserveSlot books a place in the selected slot, checking it exists and its cutoff has
+// not passed. A nil orderID reserves ahead of order creation; link it with AssignOrder.
+func ReserveSlot(ctx context.Context, app *appcontext.Context, selection SlotSelection, orderID *string) (*domain.DeliverySlotReservation, apperrors.ApplicationError) {
+	settings, err := app.Repositories.Settings.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := slotsForDate(settings.DeliverySlots, selection.Date, settings.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slot := range slots {
+		if slot.Start != selection.Start {
+			continue
+		}
+		if !time.Now().Before(slot.CutoffAt) {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotCutoffPassedError,
+				fmt.Errorf("slot %s %s closed at %s", slot.Date, slot.Label(), slot.CutoffAt.Format(time.RFC3339)))
+		}
+		return app.Repositories.DeliverySlot.Reserve(ctx, slot, orderID)
+	}
+
+	return nil, apperrors.NewApplicationError(mappings.DeliverySlotNotFoundError,
+		fmt.Errorf("no slot starting at %s on %s", selection.Start, selection.Date))
+}
+
+// ApplyToOrder sets the order's delivery window to the reserved slot, keeping any ETA label already set
+func ApplyToOrder(order *domain.Order, reservation *domain.DeliverySlotReservation) {
+	startsAt := reservation.StartsAt
+	endsAt := reservation.EndsAt
+	order.ETAFrom = &startsAt
+	order.ETATo = &endsAt
+	if order.ETA == "" {
+		order.ETA = reservation.Start + " - " + reservation.End
+	}
+}
+
+func slotsForDate(definitions []domain.DeliverySlotDefinition, date string, loc *time.Location) ([]domain.DeliverySlot, apperrors.ApplicationError) {
+	day, err := time.ParseInLocation(dateLayout, date, loc)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidDateError, err)
+	}
+	return domain.DeliverySlotsForDate(definitions, day, loc), nil
+}
@@ -0,0 +1,17 @@
+package deliveryslot
+
+import (
+	"yego/internal/platform/appcontext"
+)
+
+// Usecases aggregates all delivery slot use cases
+type Usecases struct {
+	ListAvailable ListAvailableUsecase
+}
+
+// NewUsecases creates all delivery slot use cases
+func NewUsecases(contextFactory appcontext.Factory) *Usecases {
+	return &Usecases{
+		ListAvailable: NewListAvailableUsecase(contextFactory),
+	}
+}
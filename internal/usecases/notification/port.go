@@ -1,5 +1,7 @@
 package notification
 
+import "yego/internal/domain"
+
 // OrderClaimedPayload contains data about a claimed order
 type OrderClaimedPayload struct {
 	OrderID   string `json:"order_id"`
@@ -10,9 +12,38 @@ type OrderClaimedPayload struct {
 	ClaimedAt string `json:"claimed_at"`
 }
 
+// OrderCancelledPayload contains data about a cancelled order and its refund
+type OrderCancelledPayload struct {
+	OrderID        string       `json:"order_id"`
+	UserID         string       `json:"user_id,omitempty"`
+	CancelledBy    string       `json:"cancelled_by"`
+	Reason         string       `json:"reason,omitempty"`
+	PreviousStatus string       `json:"previous_status"`
+	RefundStatus   string       `json:"refund_status"`
+	RefundAmount   domain.Money `json:"refund_amount"`
+	CancelledAt    string       `json:"cancelled_at"`
+}
+
+// OrderUpdatedPayload contains data about an order whose status changed
+type OrderUpdatedPayload struct {
+	OrderID        string  `json:"order_id"`
+	UserID         string  `json:"user_id,omitempty"`
+	Status         string  `json:"status"`
+	PreviousStatus string  `json:"previous_status"`
+	StatusMessage  string  `json:"status_message,omitempty"`
+	PauseReason    string  `json:"pause_reason,omitempty"`
+	ResumeAt       *string `json:"resume_at,omitempty"`
+	Version        int     `json:"version"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
 // Service defines the interface for sending notifications to clients
 // This is a driven port (output port) in hexagonal architecture
 type Service interface {
 	// NotifyOrderClaimed sends a notification when an order is claimed by a user
 	NotifyOrderClaimed(payload OrderClaimedPayload) error
+	// NotifyOrderCancelled sends a notification when an order is cancelled
+	NotifyOrderCancelled(payload OrderCancelledPayload) error
+	// NotifyOrderUpdated sends a notification when an order changes status
+	NotifyOrderUpdated(payload OrderUpdatedPayload) error
 }
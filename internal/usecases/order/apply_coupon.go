@@ -0,0 +1,123 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	pricingUsecase "yego/internal/usecases/pricing"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// CouponInput represents the input for redeeming or removing a coupon on an order
+type CouponInput struct {
+	OrderID string
+	UserID  string
+	Code    string `json:"code" binding:"required"`
+}
+
+// CouponOutput is the order repriced with its coupons
+type CouponOutput struct {
+	Data OrderOutputData `json:"data"`
+}
+
+// ApplyCouponUsecase defines the interface for redeeming a coupon on an order
+type ApplyCouponUsecase interface {
+	Execute(ctx context.Context, input CouponInput) (*CouponOutput, apperrors.ApplicationError)
+}
+
+type applyCouponUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewApplyCouponUsecase creates a new instance of ApplyCouponUsecase
+func NewApplyCouponUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) ApplyCouponUsecase {
+	return &applyCouponUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute redeems a coupon on the user's unpaid order and reprices it
+func (u *applyCouponUsecase) Execute(ctx context.Context, input CouponInput) (*CouponOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	order, appErr := loadCouponOrder(ctx, app, input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	promotion, appErr := app.Repositories.Promotion.GetByCode(ctx, domain.NormalizeCouponCode(input.Code))
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !promotion.IsAvailableAt(time.Now()) {
+		return nil, apperrors.NewApplicationError(mappings.PromotionNotAvailableError, fmt.Errorf("promotion %s is inactive or outside its validity window", promotion.Code))
+	}
+
+	// Check against the current items so customers learn right away why a coupon
+	// doesn't apply; the delivery fee is only known once the order is priced
+	var items []domain.OrderItem
+	if order.Data != nil {
+		items = order.Data.Items
+	}
+	var delivery *domain.DeliveryFeeBreakdown
+	if order.Pricing != nil {
+		delivery = order.Pricing.Delivery
+	}
+	if _, err := pricingUsecase.PromotionDiscount(promotion, items, delivery, orderCurrency(order)); err != nil {
+		if errors.Is(err, domain.ErrCurrencyMismatch) {
+			return nil, apperrors.NewApplicationError(mappings.OrderCurrencyMismatchError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.PromotionNotApplicableError, err)
+	}
+
+	redemption := &domain.PromotionRedemption{
+		PromotionID: promotion.ID,
+		OrderID:     order.ID,
+		UserID:      input.UserID,
+	}
+	if redeemErr := app.Repositories.Promotion.Redeem(ctx, redemption, promotion.MaxUsesPerCustomer); redeemErr != nil {
+		return nil, redeemErr
+	}
+
+	return repriceCouponOrder(ctx, app, order, u.calculateDeliveryFeeUse), nil
+}
+
+// loadCouponOrder loads the order a coupon is redeemed on or removed from, which
+// must belong to the user and not be paid yet
+func loadCouponOrder(ctx context.Context, app *appcontext.Context, input CouponInput) (*domain.Order, apperrors.ApplicationError) {
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+	if order.Status != domain.StatusCreated {
+		return nil, apperrors.NewApplicationError(mappings.PromotionOrderLockedError, fmt.Errorf("order %s is %s", order.ID, order.Status))
+	}
+	return order, nil
+}
+
+// repriceCouponOrder retakes the snapshot after the coupons of an order changed.
+// Orders without a profile yet are priced when they are paid instead.
+func repriceCouponOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) *CouponOutput {
+	if _, err := SnapshotPricing(ctx, app, order, calculateDeliveryFeeUse); err != nil {
+		log.Printf("Coupon: order %s not repriced: %v", order.ID, err)
+	}
+	return &CouponOutput{Data: toOrderOutputData(order, false)}
+}
@@ -0,0 +1,205 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// Refund outcomes reported by a cancellation
+const (
+	RefundStatusNone     = "none"
+	RefundStatusRefunded = "refunded"
+	RefundStatusFailed   = "failed"
+)
+
+// customerCancellableStatuses are the statuses in which a customer may still cancel
+// their own order; once preparation starts only a manager can cancel it.
+var customerCancellableStatuses = []domain.OrderStatus{
+	domain.StatusCreated,
+	domain.StatusConfirmed,
+	domain.StatusModificationRequested,
+}
+
+// CancelInput represents the input for cancelling an order
+type CancelInput struct {
+	OrderID   string
+	UserID    string
+	Reason    string
+	ByManager bool
+}
+
+// CancelOutput represents the output after cancelling an order
+type CancelOutput struct {
+	OrderID      string       `json:"order_id"`
+	Status       string       `json:"status"`
+	RefundStatus string       `json:"refund_status"`
+	RefundAmount domain.Money `json:"refund_amount"`
+}
+
+// CancelUsecase defines the interface for cancelling orders
+type CancelUsecase interface {
+	Execute(ctx context.Context, input CancelInput) (*CancelOutput, apperrors.ApplicationError)
+}
+
+type cancelUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewCancelUsecase creates a new instance of CancelUsecase
+func NewCancelUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) CancelUsecase {
+	return &cancelUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute cancels an order and refunds its approved payment, if any
+func (u *cancelUsecase) Execute(ctx context.Context, input CancelInput) (*CancelOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A manager cancel skips the ownership and status checks and refunds the order
+	if input.ByManager && !app.ConfigService.IsAdmin(input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.OrderManagerCancelForbiddenError,
+			fmt.Errorf("user %q may not cancel order %s", input.UserID, input.OrderID))
+	}
+
+	if !input.ByManager {
+		// Verify the user owns this order
+		if order.UserID == nil || *order.UserID != input.UserID {
+			return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+		}
+		if !isCustomerCancellable(order.Status) {
+			return nil, apperrors.NewApplicationError(mappings.OrderCancelNotAllowedError,
+				fmt.Errorf("order %s is %s", order.ID, order.Status))
+		}
+	}
+
+	if order.Status == domain.StatusCancelled {
+		return nil, apperrors.NewApplicationError(mappings.OrderAlreadyCancelledError, nil)
+	}
+	if transitionErr := ValidateStatusTransition(ctx, app, order, domain.StatusCancelled); transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	previousStatus := order.Status
+	order.Status = domain.StatusCancelled
+	order.ClearPause()
+	if input.Reason != "" {
+		order.StatusMessage = &input.Reason
+	}
+
+	cancelled, err := app.Repositories.Order.Update(ctx, order, ActorUserID(input.UserID))
+	if err != nil {
+		return nil, err
+	}
+	ReleaseDeliverySlot(ctx, app, cancelled.ID)
+	if previousStatus == domain.StatusModificationRequested {
+		rejectPendingModification(ctx, app, cancelled.ID, input.UserID)
+	}
+
+	// The order stays cancelled even if the refund fails; managers are told so
+	// they can settle it by hand.
+	output := &CancelOutput{
+		OrderID:      cancelled.ID,
+		Status:       string(cancelled.Status),
+		RefundStatus: RefundStatusNone,
+	}
+	refunded, refundErr := RefundOrderPayment(ctx, app, cancelled, 0, input.Reason)
+	if refundErr == nil {
+		// Tips given at checkout go back too, the driver never delivered
+		var tipsRefunded domain.Money
+		tipsRefunded, refundErr = RefundOrderTips(ctx, app, cancelled, input.Reason)
+		refunded += tipsRefunded
+	}
+	output.RefundAmount = refunded
+	switch {
+	case refundErr != nil:
+		log.Printf("Cancel: refund failed for order %s: %v", cancelled.ID, refundErr)
+		output.RefundStatus = RefundStatusFailed
+	case refunded > 0:
+		output.RefundStatus = RefundStatusRefunded
+	}
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderCancelledPayload{
+			OrderID:        cancelled.ID,
+			CancelledBy:    "customer",
+			Reason:         input.Reason,
+			PreviousStatus: string(previousStatus),
+			RefundStatus:   output.RefundStatus,
+			RefundAmount:   output.RefundAmount,
+			CancelledAt:    time.Now().Format("2006-01-02T15:04:05Z"),
+		}
+		if input.ByManager {
+			payload.CancelledBy = "manager"
+		}
+		if cancelled.UserID != nil {
+			payload.UserID = *cancelled.UserID
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderCancelled(payload); notifyErr != nil {
+				log.Printf("Cancel: failed to notify managers for order %s: %v", payload.OrderID, notifyErr)
+			}
+		}()
+	}
+
+	return output, nil
+}
+
+// ReleaseDeliverySlot frees the delivery slot held by a cancelled order. Failures are
+// logged only; the order is already cancelled.
+func ReleaseDeliverySlot(ctx context.Context, app *appcontext.Context, orderID string) {
+	if err := app.Repositories.DeliverySlot.ReleaseByOrderID(ctx, orderID); err != nil {
+		log.Printf("Cancel: failed to release delivery slot of order %s: %v", orderID, err)
+	}
+}
+
+// rejectPendingModification closes the modification request of a cancelled order so
+// it can't be approved later. Failures are logged only; the order is already cancelled.
+func rejectPendingModification(ctx context.Context, app *appcontext.Context, orderID, userID string) {
+	request, err := app.Repositories.ModificationRequest.GetPendingByOrderID(ctx, orderID)
+	if err != nil {
+		if err.Code() != mappings.ModificationRequestNotFoundError.Code {
+			log.Printf("Cancel: failed to load the modification request of order %s: %v", orderID, err)
+		}
+		return
+	}
+
+	note := "order cancelled"
+	request.Status = domain.ModificationRequestRejected
+	request.ReviewedBy = ActorUserID(userID)
+	request.ReviewNote = &note
+	if _, err := app.Repositories.ModificationRequest.Resolve(ctx, request); err != nil {
+		log.Printf("Cancel: failed to reject modification request %s of order %s: %v", request.ID, orderID, err)
+	}
+}
+
+func isCustomerCancellable(status domain.OrderStatus) bool {
+	for _, s := range customerCancellableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,415 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"yego/internal/adapters/datasources/repositories"
+	"yego/internal/adapters/datasources/repositories/deliveryslot"
+	"yego/internal/adapters/datasources/repositories/modificationrequest"
+	orderRepository "yego/internal/adapters/datasources/repositories/order"
+	"yego/internal/adapters/datasources/repositories/transaction"
+	"yego/internal/adapters/web/integrations"
+	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	"yego/internal/platform/config"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const (
+	testOrderID   = "5f0c8a5e-1a47-4c3e-9d1e-2f6f0d7a9b10"
+	testUserID    = "user-1"
+	testGatewayID = "mp-123"
+)
+
+// fakeOrders keeps a single order in memory
+type fakeOrders struct {
+	orderRepository.Repository
+	order *domain.Order
+}
+
+func (f *fakeOrders) GetByID(_ context.Context, id string) (*domain.Order, apperrors.ApplicationError) {
+	if f.order == nil || f.order.ID != id {
+		return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, nil)
+	}
+	return f.order, nil
+}
+
+func (f *fakeOrders) Update(_ context.Context, order *domain.Order, _ *string) (*domain.Order, apperrors.ApplicationError) {
+	f.order = order
+	return order, nil
+}
+
+type fakeTransactions struct {
+	transaction.Repository
+	transactions []*domain.Transaction
+	createErr    apperrors.ApplicationError
+}
+
+func (f *fakeTransactions) ListByOrderID(_ context.Context, orderID string) ([]*domain.Transaction, apperrors.ApplicationError) {
+	var found []*domain.Transaction
+	for _, t := range f.transactions {
+		if t.OrderID == orderID {
+			found = append(found, t)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeTransactions) Create(_ context.Context, t *domain.Transaction) (*domain.Transaction, apperrors.ApplicationError) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.transactions = append(f.transactions, t)
+	return t, nil
+}
+
+func (f *fakeTransactions) refunded() domain.Money {
+	var total domain.Money
+	for _, t := range f.transactions {
+		if t.Status == domain.TransactionStatusRefunded {
+			total += t.Amount
+		}
+	}
+	return total
+}
+
+type fakeDeliverySlots struct {
+	deliveryslot.Repository
+}
+
+func (fakeDeliverySlots) ReleaseByOrderID(context.Context, string) apperrors.ApplicationError {
+	return nil
+}
+
+// fakeModificationRequests keeps the modification requests of the test order
+type fakeModificationRequests struct {
+	modificationrequest.Repository
+	requests []*domain.ModificationRequest
+}
+
+func (f *fakeModificationRequests) GetPendingByOrderID(_ context.Context, orderID string) (*domain.ModificationRequest, apperrors.ApplicationError) {
+	for _, r := range f.requests {
+		if r.OrderID == orderID && r.Status == domain.ModificationRequestPending {
+			return r, nil
+		}
+	}
+	return nil, apperrors.NewApplicationError(mappings.ModificationRequestNotFoundError, nil)
+}
+
+func (f *fakeModificationRequests) Resolve(_ context.Context, request *domain.ModificationRequest) (*domain.ModificationRequest, apperrors.ApplicationError) {
+	return request, nil
+}
+
+// newGateway stands in for the payments service in front of MercadoPago, answering
+// every refund with the given status and body
+func newGateway(t *testing.T, status int, body string) (*httptest.Server, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/payments/refunds" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		calls++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTestContext(server *httptest.Server, orders *fakeOrders, transactions *fakeTransactions) *appcontext.Context {
+	cfg := &config.ConfigurationService{PaymentServiceURL: server.URL}
+	return &appcontext.Context{
+		Repositories: &repositories.Repositories{
+			Order:               orders,
+			Transaction:         transactions,
+			DeliverySlot:        fakeDeliverySlots{},
+			ModificationRequest: &fakeModificationRequests{},
+		},
+		Integrations:  &integrations.Integrations{Payments: payments.NewIntegration(cfg)},
+		ConfigService: cfg,
+	}
+}
+
+func confirmedOrder() *domain.Order {
+	userID := testUserID
+	return &domain.Order{
+		ID:       testOrderID,
+		UserID:   &userID,
+		Status:   domain.StatusConfirmed,
+		Currency: domain.DefaultCurrency,
+	}
+}
+
+func approvedPayment(amount domain.Money) *domain.Transaction {
+	gatewayID := testGatewayID
+	return &domain.Transaction{
+		OrderID:          testOrderID,
+		Amount:           amount,
+		Currency:         domain.DefaultCurrency,
+		Status:           domain.TransactionStatusApproved,
+		Kind:             domain.TransactionKindPayment,
+		GatewayPaymentID: &gatewayID,
+	}
+}
+
+func TestCancelUsecase(t *testing.T) {
+	tests := []struct {
+		name           string
+		gatewayStatus  int
+		gatewayBody    string
+		transactions   []*domain.Transaction
+		wantCalls      int
+		wantRefund     string
+		wantAmount     domain.Money
+		wantRefundedTx domain.Money
+	}{
+		{
+			name:           "approved refund",
+			gatewayStatus:  http.StatusOK,
+			gatewayBody:    `{"id":1,"gateway_refund_id":"rf-1","status":"approved"}`,
+			transactions:   []*domain.Transaction{approvedPayment(150000)},
+			wantCalls:      1,
+			wantRefund:     RefundStatusRefunded,
+			wantAmount:     150000,
+			wantRefundedTx: 150000,
+		},
+		{
+			name:           "already refunded by the gateway",
+			gatewayStatus:  http.StatusBadRequest,
+			gatewayBody:    `{"error":"payment already refunded"}`,
+			transactions:   []*domain.Transaction{approvedPayment(150000)},
+			wantCalls:      1,
+			wantRefund:     RefundStatusFailed,
+			wantAmount:     0,
+			wantRefundedTx: 0,
+		},
+		{
+			name:           "gateway error",
+			gatewayStatus:  http.StatusInternalServerError,
+			gatewayBody:    `{"error":"upstream unavailable"}`,
+			transactions:   []*domain.Transaction{approvedPayment(150000)},
+			wantCalls:      1,
+			wantRefund:     RefundStatusFailed,
+			wantAmount:     0,
+			wantRefundedTx: 0,
+		},
+		{
+			name:          "unpaid order",
+			gatewayStatus: http.StatusOK,
+			wantCalls:     0,
+			wantRefund:    RefundStatusNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newGateway(t, tt.gatewayStatus, tt.gatewayBody)
+			orders := &fakeOrders{order: confirmedOrder()}
+			transactions := &fakeTransactions{transactions: tt.transactions}
+			app := newTestContext(server, orders, transactions)
+			usecase := NewCancelUsecase(func(...appcontext.Option) *appcontext.Context { return app }, nil)
+
+			output, err := usecase.Execute(context.Background(), CancelInput{OrderID: testOrderID, UserID: testUserID, Reason: "ya no lo necesito"})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if output.Status != string(domain.StatusCancelled) || orders.order.Status != domain.StatusCancelled {
+				t.Errorf("status = %s, stored %s, want CANCELLED", output.Status, orders.order.Status)
+			}
+			if output.RefundStatus != tt.wantRefund {
+				t.Errorf("RefundStatus = %s, want %s", output.RefundStatus, tt.wantRefund)
+			}
+			if output.RefundAmount != tt.wantAmount {
+				t.Errorf("RefundAmount = %s, want %s", output.RefundAmount, tt.wantAmount)
+			}
+			if got := transactions.refunded(); got != tt.wantRefundedTx {
+				t.Errorf("recorded refunds = %s, want %s", got, tt.wantRefundedTx)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("gateway calls = %d, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCancelUsecaseRejectsOtherUsers(t *testing.T) {
+	server, calls := newGateway(t, http.StatusOK, `{}`)
+	orders := &fakeOrders{order: confirmedOrder()}
+	app := newTestContext(server, orders, &fakeTransactions{transactions: []*domain.Transaction{approvedPayment(1000)}})
+	usecase := NewCancelUsecase(func(...appcontext.Option) *appcontext.Context { return app }, nil)
+
+	_, err := usecase.Execute(context.Background(), CancelInput{OrderID: testOrderID, UserID: "someone-else"})
+	if err == nil || err.Code() != mappings.UnauthorizedError.Code {
+		t.Fatalf("Execute() error = %v, want %s", err, mappings.UnauthorizedError.Code)
+	}
+	if orders.order.Status != domain.StatusConfirmed || *calls != 0 {
+		t.Errorf("order changed to %s with %d gateway calls", orders.order.Status, *calls)
+	}
+}
+
+func TestCancelUsecaseRejectsPendingModification(t *testing.T) {
+	server, _ := newGateway(t, http.StatusOK, `{}`)
+	order := confirmedOrder()
+	order.Status = domain.StatusModificationRequested
+	orders := &fakeOrders{order: order}
+	request := &domain.ModificationRequest{
+		ID:             "request-1",
+		OrderID:        testOrderID,
+		UserID:         testUserID,
+		PreviousStatus: domain.StatusConfirmed,
+		Status:         domain.ModificationRequestPending,
+	}
+	app := newTestContext(server, orders, &fakeTransactions{})
+	app.Repositories.ModificationRequest = &fakeModificationRequests{requests: []*domain.ModificationRequest{request}}
+	usecase := NewCancelUsecase(func(...appcontext.Option) *appcontext.Context { return app }, nil)
+
+	if _, err := usecase.Execute(context.Background(), CancelInput{OrderID: testOrderID, UserID: testUserID}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if orders.order.Status != domain.StatusCancelled {
+		t.Errorf("status = %s, want CANCELLED", orders.order.Status)
+	}
+	if request.Status != domain.ModificationRequestRejected {
+		t.Errorf("modification request is %s, want REJECTED", request.Status)
+	}
+	if request.ReviewedBy == nil || *request.ReviewedBy != testUserID {
+		t.Errorf("ReviewedBy = %v, want %s", request.ReviewedBy, testUserID)
+	}
+}
+
+func TestCancelUsecaseManagerCancel(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantErr    string
+		wantStatus domain.OrderStatus
+		wantCalls  int
+	}{
+		{
+			name:       "admin",
+			userID:     "admin-1",
+			wantStatus: domain.StatusCancelled,
+			wantCalls:  1,
+		},
+		{
+			name:       "non-admin",
+			userID:     "someone-else",
+			wantErr:    mappings.OrderManagerCancelForbiddenError.Code,
+			wantStatus: domain.StatusPreparing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newGateway(t, http.StatusOK, `{"id":1,"gateway_refund_id":"rf-1","status":"approved"}`)
+			order := confirmedOrder()
+			order.Status = domain.StatusPreparing
+			orders := &fakeOrders{order: order}
+			app := newTestContext(server, orders, &fakeTransactions{transactions: []*domain.Transaction{approvedPayment(1000)}})
+			app.ConfigService.AdminUserIDs = []string{"admin-1"}
+			usecase := NewCancelUsecase(func(...appcontext.Option) *appcontext.Context { return app }, nil)
+
+			_, err := usecase.Execute(context.Background(), CancelInput{OrderID: testOrderID, UserID: tt.userID, ByManager: true})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Execute() error = %v", err)
+			case tt.wantErr != "" && (err == nil || err.Code() != tt.wantErr):
+				t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+			}
+			if orders.order.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", orders.order.Status, tt.wantStatus)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("gateway calls = %d, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRefundOrderPayment(t *testing.T) {
+	refunded := approvedPayment(40000)
+	refunded.Status = domain.TransactionStatusRefunded
+
+	tests := []struct {
+		name          string
+		gatewayStatus int
+		transactions  []*domain.Transaction
+		amount        domain.Money
+		createErr     apperrors.ApplicationError
+		wantRefunded  domain.Money
+		wantErr       bool
+		wantCalls     int
+	}{
+		{
+			name:          "approved, full amount",
+			gatewayStatus: http.StatusOK,
+			transactions:  []*domain.Transaction{approvedPayment(100000)},
+			wantRefunded:  100000,
+			wantCalls:     1,
+		},
+		{
+			name:          "approved, partial amount",
+			gatewayStatus: http.StatusCreated,
+			transactions:  []*domain.Transaction{approvedPayment(100000)},
+			amount:        25050,
+			wantRefunded:  25050,
+			wantCalls:     1,
+		},
+		{
+			name:          "partly refunded before, only the rest goes back",
+			gatewayStatus: http.StatusOK,
+			transactions:  []*domain.Transaction{approvedPayment(100000), refunded},
+			wantRefunded:  60000,
+			wantCalls:     1,
+		},
+		{
+			name:          "already fully refunded",
+			gatewayStatus: http.StatusOK,
+			transactions:  []*domain.Transaction{approvedPayment(40000), refunded},
+			wantRefunded:  0,
+			wantCalls:     0,
+		},
+		{
+			name:          "gateway error",
+			gatewayStatus: http.StatusBadGateway,
+			transactions:  []*domain.Transaction{approvedPayment(100000)},
+			wantErr:       true,
+			wantCalls:     1,
+		},
+		{
+			name:          "refund not recorded",
+			gatewayStatus: http.StatusOK,
+			transactions:  []*domain.Transaction{approvedPayment(100000)},
+			createErr:     apperrors.NewApplicationError(mappings.InternalServerError, errors.New("insert failed")),
+			wantRefunded:  100000,
+			wantErr:       true,
+			wantCalls:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newGateway(t, tt.gatewayStatus, `{"id":1,"gateway_refund_id":"rf-1","status":"approved"}`)
+			transactions := &fakeTransactions{transactions: tt.transactions, createErr: tt.createErr}
+			app := newTestContext(server, &fakeOrders{}, transactions)
+
+			got, err := RefundOrderPayment(context.Background(), app, confirmedOrder(), tt.amount, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RefundOrderPayment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantRefunded {
+				t.Errorf("RefundOrderPayment() = %s, want %s", got, tt.wantRefunded)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("gateway calls = %d, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"log"
 	"time"
 
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
 	"yego/internal/usecases/notification"
 	settingsUsecase "yego/internal/usecases/settings"
 	apperrors "yego/internal/platform/errors"
@@ -17,6 +19,8 @@ import (
 type ClaimInput struct {
 	Token  string `json:"token" binding:"required"`
 	UserID string `json:"user_id" binding:"required"`
+
+	DeliverySlot *deliveryslotUsecase.SlotSelection `json:"delivery_slot,omitempty"`
 }
 
 // ClaimOutput represents the output after claiming an order
@@ -92,8 +96,22 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 		return nil, apperrors.NewApplicationError(mappings.OrderAlreadyAssignedError, errors.New("order already assigned to another user"))
 	}
 
+	// Book the delivery slot before claiming so a full slot leaves the order unclaimed
+	var reservation *domain.DeliverySlotReservation
+	if input.DeliverySlot != nil {
+		reservation, err = deliveryslotUsecase.ReserveSlot(ctx, app, *input.DeliverySlot, &order.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Assign user to order
 	if assignErr := app.Repositories.Order.AssignUser(ctx, orderToken.OrderID, input.UserID); assignErr != nil {
+		if reservation != nil {
+			if releaseErr := app.Repositories.DeliverySlot.Release(ctx, reservation.ID); releaseErr != nil {
+				log.Printf("[Claim] failed to release delivery slot %s: %v", reservation.ID, releaseErr)
+			}
+		}
 		return nil, assignErr
 	}
 
@@ -122,6 +140,7 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 		}
 		return 0
 	}())
+	needsUpdate := false
 	if updatedOrder.Data != nil && len(updatedOrder.Data.Items) > 0 {
 		importRecords, importErr := app.Repositories.ImportRecord.GetAll(ctx)
 		log.Printf("[Claim] import records fetched: count=%d err=%v", len(importRecords), importErr)
@@ -129,11 +148,27 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 			corrected, hasChanges := correctItemPrices(updatedOrder.Data.Items, importRecords)
 			if hasChanges {
 				log.Printf("[Claim] applying price corrections to order %s", updatedOrder.ID)
-				updatedOrder.Data.Items = corrected
-				_, _ = app.Repositories.Order.Update(ctx, updatedOrder)
 			}
+			// Kept even without price changes, it carries the catalog category and IVA rate
+			updatedOrder.Data.Items = corrected
+			needsUpdate = true
 		}
 	}
+	if reservation != nil {
+		deliveryslotUsecase.ApplyToOrder(updatedOrder, reservation)
+		needsUpdate = true
+	}
+	if needsUpdate {
+		if saved, saveErr := app.Repositories.Order.Update(ctx, updatedOrder, ActorUserID(input.UserID)); saveErr == nil {
+			updatedOrder = saved
+		}
+	}
+
+	// Freeze what the customer will be charged. Without a profile yet the order is
+	// priced when it is paid instead.
+	if _, pricingErr := SnapshotPricing(ctx, app, updatedOrder, u.calculateDeliveryFeeUse); pricingErr != nil {
+		log.Printf("[Claim] order %s not priced yet: %v", updatedOrder.ID, pricingErr)
+	}
 
 	// Send notification to managers
 	if u.notificationSvc != nil {
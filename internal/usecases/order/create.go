@@ -9,6 +9,7 @@ import (
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
+	deliveryslotUsecase "yego/internal/usecases/deliveryslot"
 	settingsUsecase "yego/internal/usecases/settings"
 )
 
@@ -17,7 +18,16 @@ type CreateInput struct {
 	ProfileID    string `json:"profile_id" binding:"required"`
 	ETA          string `json:"eta"`
 	SecurityCode string `json:"security_code"`
+	Currency     string `json:"currency"` // empty uses the business default
 	Token        string
+
+	DeliverySlot *deliveryslotUsecase.SlotSelection `json:"delivery_slot,omitempty"`
+
+	// Set by the subscription scheduler, which creates orders on the customer's behalf
+	UserID            *string           `json:"-"`
+	Data              *domain.OrderData `json:"-"`
+	SubscriptionID    *string           `json:"-"`
+	ChargeSavedMethod bool              `json:"-"` // charge without a security code
 }
 
 // CreateOutput represents the output after creating an order
@@ -47,31 +57,96 @@ func NewCreateUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse
 func (u *createUsecase) Execute(ctx context.Context, input CreateInput) (*CreateOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	currency, currencyErr := resolveOrderCurrency(ctx, app, input.Currency)
+	if currencyErr != nil {
+		return nil, currencyErr
+	}
+
 	newOrder := &domain.Order{
-		ProfileID: &input.ProfileID,
-		ETA:       input.ETA,
-		Status:    domain.StatusCreated,
+		ProfileID:      &input.ProfileID,
+		UserID:         input.UserID,
+		ETA:            input.ETA,
+		Status:         domain.StatusCreated,
+		SubscriptionID: input.SubscriptionID,
+		Currency:       currency,
+	}
+
+	// Template items may carry stale prices; reprice them like a claimed order
+	if input.Data != nil {
+		data := *input.Data
+		data.Items = append([]domain.OrderItem(nil), input.Data.Items...)
+		importRecords, importErr := app.Repositories.ImportRecord.GetAll(ctx)
+		if importErr == nil && len(importRecords) > 0 {
+			// Kept even without price changes, it carries the catalog category and IVA rate
+			data.Items, _ = correctItemPrices(data.Items, importRecords)
+		}
+		newOrder.Data = &data
+	}
+
+	// Book the slot first so a full slot rejects the order before it exists
+	var reservation *domain.DeliverySlotReservation
+	if input.DeliverySlot != nil {
+		var slotErr apperrors.ApplicationError
+		reservation, slotErr = deliveryslotUsecase.ReserveSlot(ctx, app, *input.DeliverySlot, nil)
+		if slotErr != nil {
+			return nil, slotErr
+		}
+		deliveryslotUsecase.ApplyToOrder(newOrder, reservation)
 	}
 
 	created, err := app.Repositories.Order.Create(ctx, newOrder)
 	if err != nil {
+		if reservation != nil {
+			if releaseErr := app.Repositories.DeliverySlot.Release(ctx, reservation.ID); releaseErr != nil {
+				log.Printf("Create: failed to release delivery slot %s: %v", reservation.ID, releaseErr)
+			}
+		}
 		return nil, err
 	}
 
+	if reservation != nil {
+		if assignErr := app.Repositories.DeliverySlot.AssignOrder(ctx, reservation.ID, created.ID); assignErr != nil {
+			log.Printf("Create: failed to link delivery slot %s to order %s: %v", reservation.ID, created.ID, assignErr)
+		}
+	}
+
 	// Process payment immediately if security code is provided
-	if input.SecurityCode != "" {
+	if input.SecurityCode != "" || input.ChargeSavedMethod {
 		paymentErr := ProcessPaymentForOrder(ctx, app, created, input.Token, input.SecurityCode, u.calculateDeliveryFeeUse)
 		if paymentErr != nil {
 			log.Printf("Payment failed for order %s: %v", created.ID, paymentErr)
 			// Keep status as CREATED but return error
-			return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, fmt.Errorf("payment failed: %w", paymentErr))
+			return nil, paymentFailedError(fmt.Errorf("payment failed: %w", paymentErr))
 		}
 		// Payment successful - update status to CONFIRMED
 		created.Status = domain.StatusConfirmed
-		created, _ = app.Repositories.Order.Update(ctx, created)
+		// The charge went through, so don't fail the request; a conflict usually means
+		// the payment webhook confirmed the order first
+		if confirmed, updateErr := app.Repositories.Order.Update(ctx, created, created.UserID); updateErr != nil {
+			log.Printf("Create: failed to confirm paid order %s: %v", created.ID, updateErr)
+		} else {
+			created = confirmed
+		}
 	}
 
 	return &CreateOutput{
 		Data: toOrderOutputData(created, false),
 	}, nil
 }
+
+// resolveOrderCurrency validates the currency requested for a new order, falling
+// back to the business default when none is given
+func resolveOrderCurrency(ctx context.Context, app *appcontext.Context, requested string) (string, apperrors.ApplicationError) {
+	if requested == "" {
+		settings, err := app.Repositories.Settings.Get(ctx)
+		if err != nil {
+			return "", err
+		}
+		return settings.Currency(), nil
+	}
+	currency := domain.NormalizeCurrency(requested)
+	if !domain.IsValidCurrency(currency) {
+		return "", apperrors.NewApplicationError(mappings.OrderInvalidCurrencyError, fmt.Errorf("unsupported currency %q", requested))
+	}
+	return currency, nil
+}
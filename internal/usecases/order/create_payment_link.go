@@ -5,9 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math"
+	"strings"
 
 	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
@@ -21,6 +22,7 @@ type CreatePaymentLinkInput struct {
 	AuthToken   string
 	FrontendURL string
 	BackendURL  string
+	Tip         domain.Money // optional, paid with the order and recorded apart by the webhook
 }
 
 // CreatePaymentLinkOutput represents the output with the payment link
@@ -51,6 +53,10 @@ func NewCreatePaymentLinkUsecase(contextFactory appcontext.Factory, calculateDel
 func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaymentLinkInput) (*CreatePaymentLinkOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	if input.Tip < 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTipError, fmt.Errorf("tip of %s", input.Tip))
+	}
+
 	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
 	if err != nil {
 		return nil, err
@@ -98,70 +104,22 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 		payerEmail = fmt.Sprintf("%s@yego.local", profile.UserID)
 	}
 
-	// Calculate items subtotal
-	var itemsTotal float64
-	if order.Data != nil {
-		for _, item := range order.Data.Items {
-			itemsTotal += item.Price * float64(item.Quantity)
-		}
+	pricing, pricingErr := EnsurePricing(ctx, app, order, u.calculateDeliveryFeeUse)
+	if pricingErr != nil {
+		return nil, paymentFailedError(fmt.Errorf("failed to price order: %w", pricingErr))
 	}
-
-	// Calculate delivery fee separately
-	var deliveryFee float64
-	if profile.LocationID != nil {
-		location, locErr := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
-		if locErr == nil && location != nil {
-			deliveryInput := settingsUsecase.CalculateDeliveryFeeInput{
-				UserLatitude:  location.Latitude,
-				UserLongitude: location.Longitude,
-				Items: make([]struct {
-					Quantity int  `json:"quantity"`
-					Weight   *int `json:"weight,omitempty"`
-				}, len(order.Data.Items)),
-			}
-			if order.Data != nil {
-				for i, item := range order.Data.Items {
-					deliveryInput.Items[i].Quantity = item.Quantity
-					deliveryInput.Items[i].Weight = item.Weight
-				}
-			}
-			if feeOutput, feeErr := u.calculateDeliveryFeeUse.Execute(ctx, deliveryInput); feeErr == nil && feeOutput != nil {
-				deliveryFee = feeOutput.TotalPrice
-			}
-		}
-	}
-
-	orderTotal := math.Round((itemsTotal+deliveryFee)*100) / 100
+	orderTotal := pricing.Total
 	if orderTotal <= 0 {
 		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, errors.New("order total is zero or negative"))
 	}
 
-	// Build preference items: products + delivery fee if applicable
-	var prefItems []payments.PreferenceItem
-	if order.Data != nil && len(order.Data.Items) > 0 {
-		for _, item := range order.Data.Items {
-			prefItems = append(prefItems, payments.PreferenceItem{
-				Title:      item.Name,
-				Quantity:   item.Quantity,
-				UnitPrice:  math.Round(item.Price*100) / 100,
-				CurrencyID: "ARS",
-			})
-		}
-	}
-	if deliveryFee > 0 {
-		prefItems = append(prefItems, payments.PreferenceItem{
-			Title:      "Envío",
-			Quantity:   1,
-			UnitPrice:  math.Round(deliveryFee*100) / 100,
-			CurrencyID: "ARS",
-		})
-	}
-	if len(prefItems) == 0 {
+	prefItems := preferenceItems(order, pricing)
+	if input.Tip > 0 {
 		prefItems = append(prefItems, payments.PreferenceItem{
-			Title:      fmt.Sprintf("Pedido %s", order.ID),
+			Title:      tipItemTitle,
 			Quantity:   1,
-			UnitPrice:  orderTotal,
-			CurrencyID: "ARS",
+			UnitPrice:  input.Tip,
+			CurrencyID: prefItems[0].CurrencyID,
 		})
 	}
 
@@ -179,7 +137,7 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 	prefResp, prefErr := app.Integrations.Payments.CreatePreference(
 		prefItems,
 		payerEmail,
-		order.ID,
+		paymentLinkReference(order.ID, input.Tip),
 		orderURL,
 		orderURL,
 		orderURL,
@@ -194,3 +152,62 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 		SandboxInitPoint: prefResp.SandboxInitPoint,
 	}, nil
 }
+
+// tipItemTitle is the Checkout Pro item of a tip paid with the order
+const tipItemTitle = "Propina para el repartidor"
+
+// tipReferenceSeparator joins a tip to the order ID in a payment link's external reference
+const tipReferenceSeparator = ";tip="
+
+// paymentLinkReference is the Checkout Pro external reference of an order's payment
+// link. A tip paid with the order travels in it, so the webhook records the tip the
+// customer chose instead of guessing it from the amount paid.
+func paymentLinkReference(orderID string, tip domain.Money) string {
+	if tip <= 0 {
+		return orderID
+	}
+	return orderID + tipReferenceSeparator + tip.String()
+}
+
+// parsePaymentLinkReference reads back the order ID and tip of a payment link's
+// external reference. Links created without a tip carry only the order ID.
+func parsePaymentLinkReference(reference string) (string, domain.Money, error) {
+	orderID, tipText, hasTip := strings.Cut(reference, tipReferenceSeparator)
+	if !hasTip {
+		return reference, 0, nil
+	}
+	tip, err := domain.ParseMoney(tipText)
+	if err != nil || tip < 0 {
+		return orderID, 0, fmt.Errorf("invalid tip in external reference %q", reference)
+	}
+	return orderID, tip, nil
+}
+
+// preferenceItems turns the priced lines of an order into Checkout Pro items.
+// Discounts go as lines with a negative unit price, so the items add up to the
+// stored total. Snapshots taken before line items existed are charged as a single
+// line for the total.
+func preferenceItems(order *domain.Order, pricing *domain.OrderPricing) []payments.PreferenceItem {
+	currency := pricing.Currency
+	if currency == "" {
+		currency = orderCurrency(order)
+	}
+	var prefItems []payments.PreferenceItem
+	for _, line := range pricing.Lines {
+		prefItems = append(prefItems, payments.PreferenceItem{
+			Title:      line.Description,
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+			CurrencyID: currency,
+		})
+	}
+	if len(prefItems) == 0 {
+		prefItems = append(prefItems, payments.PreferenceItem{
+			Title:      fmt.Sprintf("Pedido %s", order.ID),
+			Quantity:   1,
+			UnitPrice:  pricing.Total,
+			CurrencyID: currency,
+		})
+	}
+	return prefItems
+}
@@ -0,0 +1,64 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"yego/internal/adapters/datasources/repositories"
+	"yego/internal/adapters/datasources/repositories/importrecord"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+func (f *fakeOrders) Create(_ context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError) {
+	order.ID = testOrderID
+	f.order = order
+	return order, nil
+}
+
+type fakeImportRecords struct {
+	importrecord.Repository
+	records []*domain.ImportRecord
+}
+
+func (f *fakeImportRecords) GetAll(context.Context) ([]*domain.ImportRecord, apperrors.ApplicationError) {
+	return f.records, nil
+}
+
+func TestCreateUsecaseKeepsCatalogTaxRate(t *testing.T) {
+	orders := &fakeOrders{}
+	app := &appcontext.Context{
+		Repositories: &repositories.Repositories{
+			Order: orders,
+			ImportRecord: &fakeImportRecords{records: []*domain.ImportRecord{{
+				ID:   "import-1",
+				Data: map[string]any{"Código": "PAN-1", "Descripción": "Pan", "Precio": "1105", "Categoría": "Panadería", "IVA": "10,5"},
+			}}},
+		},
+	}
+	usecase := NewCreateUsecase(func(...appcontext.Option) *appcontext.Context { return app }, nil)
+
+	// Name and price already match the catalog, only the category and rate are new
+	_, err := usecase.Execute(context.Background(), CreateInput{
+		ProfileID: "profile-1",
+		Currency:  domain.CurrencyARS,
+		Data: &domain.OrderData{Items: []domain.OrderItem{
+			{Code: "PAN-1", Name: "Pan", Price: 110500, Quantity: 1},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	item := orders.order.Data.Items[0]
+	if item.TaxRate == nil || *item.TaxRate != domain.TaxRateReduced {
+		t.Errorf("TaxRate = %v, want %v", item.TaxRate, domain.TaxRateReduced)
+	}
+	if item.Category != "Panadería" {
+		t.Errorf("Category = %q, want Panadería", item.Category)
+	}
+	if item.Price != 110500 {
+		t.Errorf("Price = %s, want 1105.00", item.Price)
+	}
+}
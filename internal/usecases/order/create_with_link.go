@@ -11,11 +11,11 @@ import (
 
 // CreateWithLinkItemInput represents a single item in the order
 type CreateWithLinkItemInput struct {
-	Code     string  `json:"code,omitempty"`
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
-	Weight   *int    `json:"weight,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Name     string       `json:"name"`
+	Price    domain.Money `json:"price"`
+	Quantity int          `json:"quantity"`
+	Weight   *int         `json:"weight,omitempty"`
 }
 
 // CreateWithLinkDataInput represents the order data/items
@@ -27,18 +27,23 @@ type CreateWithLinkDataInput struct {
 type CreateWithLinkInput struct {
 	PhoneNumber string                   `json:"phone_number"`
 	ETA         string                   `json:"eta"`
+	ETAFrom     *time.Time               `json:"eta_from,omitempty"`
+	ETATo       *time.Time               `json:"eta_to,omitempty"`
+	Currency    string                   `json:"currency,omitempty"`
 	Data        *CreateWithLinkDataInput `json:"data,omitempty"`
 }
 
 // CreateWithLinkOutput represents the output after creating an order with link
 type CreateWithLinkOutput struct {
-	OrderID   string `json:"order_id"`
-	Token     string `json:"token"`
-	ClaimURL  string `json:"claim_url"`
-	Status    string `json:"status"`
-	ETA       string `json:"eta"`
-	ExpiresAt string `json:"expires_at"`
-	CreatedAt string `json:"created_at"`
+	OrderID   string  `json:"order_id"`
+	Token     string  `json:"token"`
+	ClaimURL  string  `json:"claim_url"`
+	Status    string  `json:"status"`
+	ETA       string  `json:"eta"`
+	ETAFrom   *string `json:"eta_from,omitempty"`
+	ETATo     *string `json:"eta_to,omitempty"`
+	ExpiresAt string  `json:"expires_at"`
+	CreatedAt string  `json:"created_at"`
 }
 
 // CreateWithLinkUsecase defines the interface for creating orders with claim links
@@ -59,9 +64,21 @@ func NewCreateWithLinkUsecase(contextFactory appcontext.Factory) CreateWithLinkU
 func (u *createWithLinkUsecase) Execute(ctx context.Context, input CreateWithLinkInput, baseURL string) (*CreateWithLinkOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	if etaErr := ValidateETAWindow(input.ETAFrom, input.ETATo); etaErr != nil {
+		return nil, etaErr
+	}
+
+	currency, currencyErr := resolveOrderCurrency(ctx, app, input.Currency)
+	if currencyErr != nil {
+		return nil, currencyErr
+	}
+
 	// Create order without user assignment
 	newOrder := &domain.Order{
-		ETA: input.ETA,
+		ETA:      input.ETA,
+		ETAFrom:  input.ETAFrom,
+		ETATo:    input.ETATo,
+		Currency: currency,
 	}
 
 	// Convert input data to domain OrderData if provided
@@ -110,6 +127,8 @@ func (u *createWithLinkUsecase) Execute(ctx context.Context, input CreateWithLin
 		ClaimURL:  claimURL,
 		Status:    string(created.Status),
 		ETA:       created.ETA,
+		ETAFrom:   formatOptionalTime(created.ETAFrom),
+		ETATo:     formatOptionalTime(created.ETATo),
 		ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z"),
 		CreatedAt: created.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}, nil
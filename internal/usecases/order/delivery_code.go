@@ -0,0 +1,134 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// IssueDeliveryCode gives an order that just went ON_THE_WAY its proof-of-delivery code.
+// Failures are logged only; an admin can still override delivery.
+func IssueDeliveryCode(ctx context.Context, app *appcontext.Context, order *domain.Order) {
+	if order.Status != domain.StatusOnTheWay {
+		return
+	}
+	code, err := domain.NewDeliveryCode()
+	if err != nil {
+		log.Printf("DeliveryCode: failed to generate code for order %s: %v", order.ID, err)
+		return
+	}
+	if _, issueErr := app.Repositories.DeliveryCode.Issue(ctx, order.ID, code); issueErr != nil {
+		log.Printf("DeliveryCode: failed to issue code for order %s: %v", order.ID, issueErr)
+	}
+}
+
+// VerifyDeliveryCode checks the code the customer gave the courier. Wrong codes are
+// counted and lock verification once domain.DeliveryCodeMaxAttempts is reached.
+func VerifyDeliveryCode(ctx context.Context, app *appcontext.Context, orderID string, candidate string) apperrors.ApplicationError {
+	if candidate == "" {
+		return apperrors.NewApplicationError(mappings.DeliveryCodeRequiredError, nil)
+	}
+
+	code, err := app.Repositories.DeliveryCode.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if code.IsLocked(now) {
+		return apperrors.NewApplicationError(mappings.DeliveryCodeLockedError,
+			fmt.Errorf("order %s: verification locked until %s", orderID, code.LockedUntil.Format(time.RFC3339)))
+	}
+
+	if !code.Matches(candidate) {
+		updated, failErr := app.Repositories.DeliveryCode.RecordFailure(ctx, orderID, now)
+		if failErr != nil {
+			return failErr
+		}
+		if updated.IsLocked(now) {
+			return apperrors.NewApplicationError(mappings.DeliveryCodeLockedError,
+				fmt.Errorf("order %s: %d wrong codes in total", orderID, updated.TotalFailedAttempts))
+		}
+		return apperrors.NewApplicationError(mappings.DeliveryCodeInvalidError,
+			fmt.Errorf("order %s: %d of %d attempts used", orderID, updated.FailedAttempts, domain.DeliveryCodeMaxAttempts))
+	}
+
+	return app.Repositories.DeliveryCode.MarkVerified(ctx, orderID, now)
+}
+
+// AuthorizeDelivery lets an order move to DELIVERED with either the customer's code or,
+// for admins, an override reason that is kept with the order's delivery code record.
+func AuthorizeDelivery(ctx context.Context, app *appcontext.Context, orderID string, candidate string, overrideReason string, actorUserID string) apperrors.ApplicationError {
+	if candidate != "" {
+		return VerifyDeliveryCode(ctx, app, orderID, candidate)
+	}
+	if overrideReason == "" {
+		return apperrors.NewApplicationError(mappings.DeliveryCodeRequiredError,
+			errors.New("provide the delivery code or an override reason"))
+	}
+	// The admin routes only check the caller is signed in
+	if !app.ConfigService.IsAdmin(actorUserID) {
+		return apperrors.NewApplicationError(mappings.DeliveryCodeOverrideForbiddenError,
+			fmt.Errorf("user %q may not override the delivery code of order %s", actorUserID, orderID))
+	}
+	log.Printf("DeliveryCode: order %s delivered without code by %s: %s", orderID, actorUserID, overrideReason)
+	return app.Repositories.DeliveryCode.RecordOverride(ctx, orderID, actorUserID, overrideReason, time.Now())
+}
+
+// pendingDeliveryCode returns the code to show the owner of an order on its way, if any
+func pendingDeliveryCode(ctx context.Context, app *appcontext.Context, order *domain.Order) *string {
+	if !showsDeliveryCode(order) {
+		return nil
+	}
+	code, err := app.Repositories.DeliveryCode.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil
+	}
+	return unverifiedCode(code)
+}
+
+// pendingDeliveryCodes is pendingDeliveryCode for a page of orders, loaded in one
+// query and keyed by order ID. A failed lookup is logged and shows no codes.
+func pendingDeliveryCodes(ctx context.Context, app *appcontext.Context, orders []*domain.Order) map[string]*string {
+	pending := make(map[string]*string)
+	var orderIDs []string
+	for _, o := range orders {
+		if showsDeliveryCode(o) {
+			orderIDs = append(orderIDs, o.ID)
+		}
+	}
+	if len(orderIDs) == 0 {
+		return pending
+	}
+
+	codes, err := app.Repositories.DeliveryCode.ListByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		log.Printf("DeliveryCode: failed to load the codes of %d orders: %v", len(orderIDs), err)
+		return pending
+	}
+	for orderID, code := range codes {
+		if c := unverifiedCode(code); c != nil {
+			pending[orderID] = c
+		}
+	}
+	return pending
+}
+
+// showsDeliveryCode reports whether the owner of an order should see its code
+func showsDeliveryCode(order *domain.Order) bool {
+	return order.Status == domain.StatusOnTheWay || order.Status == domain.StatusPaused
+}
+
+func unverifiedCode(code *domain.DeliveryCode) *string {
+	if code.Code == "" || code.VerifiedAt != nil {
+		return nil
+	}
+	return &code.Code
+}
@@ -0,0 +1,287 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Printable documents of an order
+const (
+	DocumentReceipt      = "receipt"       // for the customer, once the order is paid
+	DocumentDeliveryNote = "delivery-note" // for the driver, signed on delivery
+)
+
+// documentTimeFormat is how dates are printed, in the business timezone
+const documentTimeFormat = "02/01/2006 15:04"
+
+// OrderDocument is everything printed on an order's documents. Rendering only
+// reads these fields, so the same document always renders to the same bytes.
+type OrderDocument struct {
+	BusinessName string
+	Location     *time.Location
+	Order        *domain.Order
+	Profile      *domain.Profile         // nil for orders without a profile
+	Address      *domain.ProfileLocation // nil when the profile has no location
+	Payment      *domain.Transaction     // latest approved payment, nil while unpaid
+	Paid         domain.Money            // approved payments minus refunds
+	Tips         domain.Money            // approved tips
+}
+
+// LoadOrderDocument gathers the data printed on an order's documents
+func LoadOrderDocument(ctx context.Context, app *appcontext.Context, order *domain.Order) (*OrderDocument, apperrors.ApplicationError) {
+	settings, err := app.Repositories.Settings.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc := &OrderDocument{
+		BusinessName: settings.BusinessName,
+		Location:     settings.Location(),
+		Order:        order,
+	}
+
+	if order.ProfileID != nil {
+		profile, profileErr := app.Repositories.Profile.GetByID(ctx, *order.ProfileID)
+		if profileErr != nil {
+			return nil, profileErr
+		}
+		doc.Profile = profile
+		if profile.LocationID != nil {
+			address, addressErr := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
+			if addressErr != nil {
+				return nil, addressErr
+			}
+			doc.Address = address
+		}
+	}
+
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transactions {
+		switch {
+		case t.Kind == domain.TransactionKindTip && t.Status == domain.TransactionStatusApproved:
+			doc.Tips += t.Amount
+		case t.Kind == domain.TransactionKindTip:
+		case t.Status == domain.TransactionStatusApproved:
+			doc.Paid += t.Amount
+			if doc.Payment == nil || t.CreatedAt.After(doc.Payment.CreatedAt) {
+				doc.Payment = t
+			}
+		case t.Status == domain.TransactionStatusRefunded:
+			doc.Paid -= t.Amount
+		}
+	}
+
+	return doc, nil
+}
+
+// RenderReceipt prints the receipt of a paid order: its priced lines, the delivery
+// fee breakdown, the IVA included and the approved payment
+func RenderReceipt(doc *OrderDocument) ([]byte, error) {
+	if doc.Payment == nil {
+		return nil, fmt.Errorf("order %s has no approved payment", doc.Order.ID)
+	}
+	order := doc.Order
+	currency := orderCurrency(order)
+	pdf := newDocumentPDF(doc, "Comprobante de pago", doc.Payment.CreatedAt)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	documentField(pdf, tr, "Pedido", order.ID)
+	documentField(pdf, tr, "Fecha de pago", doc.Payment.CreatedAt.In(doc.Location).Format(documentTimeFormat))
+	if doc.Payment.GatewayPaymentID != nil {
+		documentField(pdf, tr, "Pago", *doc.Payment.GatewayPaymentID)
+	}
+	if doc.Profile != nil {
+		documentField(pdf, tr, "Cliente", doc.Profile.PhoneNumber)
+	}
+	pdf.Ln(4)
+
+	widths := []float64{90, 15, 30, 15, 30}
+	documentRow(pdf, tr, widths, 1, true, "Descripción", "Cant.", "Precio unit.", "IVA", "Importe")
+	if order.Pricing != nil {
+		for _, line := range order.Pricing.Lines {
+			documentRow(pdf, tr, widths, 1, false, line.Description, fmt.Sprint(line.Quantity),
+				formatDocumentMoney(line.UnitPrice, currency), formatTaxRate(line.TaxRate), formatDocumentMoney(line.Amount, currency))
+		}
+	} else if order.Data != nil {
+		for _, item := range order.Data.Items {
+			documentRow(pdf, tr, widths, 1, false, item.Name, fmt.Sprint(item.Quantity),
+				formatDocumentMoney(item.Price, currency), "", formatDocumentMoney(item.Price.Mul(item.Quantity), currency))
+		}
+	}
+	pdf.Ln(4)
+
+	if p := order.Pricing; p != nil {
+		documentTotal(pdf, tr, "Subtotal", formatDocumentMoney(p.Subtotal, currency), false)
+		if p.Delivery != nil {
+			d := p.Delivery
+			documentTotal(pdf, tr, "Envío", formatDocumentMoney(d.TotalPrice, currency), false)
+			documentTotal(pdf, tr, "  Base", formatDocumentMoney(d.BasePrice, currency), false)
+			documentTotal(pdf, tr, fmt.Sprintf("  Distancia (%.1f km)", d.DistanceKm), formatDocumentMoney(d.DistancePrice, currency), false)
+			documentTotal(pdf, tr, fmt.Sprintf("  Peso (%.1f kg)", d.TotalWeightKg), formatDocumentMoney(d.WeightPrice, currency), false)
+		}
+		if p.SurchargeTotal != 0 {
+			documentTotal(pdf, tr, "Recargos", formatDocumentMoney(p.SurchargeTotal, currency), false)
+		}
+		if p.DiscountTotal != 0 {
+			documentTotal(pdf, tr, "Descuentos", formatDocumentMoney(-p.DiscountTotal, currency), false)
+		}
+		documentTotal(pdf, tr, "Total", formatDocumentMoney(p.Total, currency), true)
+		if p.Tax != nil {
+			for _, rate := range p.Tax.Rates {
+				documentTotal(pdf, tr, fmt.Sprintf("IVA %s incluido (neto %s)", formatTaxRate(rate.Rate), formatDocumentMoney(rate.Net, currency)),
+					formatDocumentMoney(rate.Tax, currency), false)
+			}
+		}
+	}
+	documentTotal(pdf, tr, "Pagado", formatDocumentMoney(doc.Paid, currency), true)
+	if doc.Tips > 0 {
+		documentTotal(pdf, tr, "Propina", formatDocumentMoney(doc.Tips, currency), false)
+	}
+
+	pdf.Ln(8)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.MultiCell(0, 4, tr("Precios con IVA incluido."), "", "L", false)
+
+	return outputDocumentPDF(pdf)
+}
+
+// RenderDeliveryNote prints what the driver hands over and where, with the amount
+// still to collect and room for the customer's signature
+func RenderDeliveryNote(doc *OrderDocument) ([]byte, error) {
+	order := doc.Order
+	currency := orderCurrency(order)
+	pdf := newDocumentPDF(doc, "Remito de entrega", order.UpdatedAt)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	documentField(pdf, tr, "Pedido", order.ID)
+	documentField(pdf, tr, "Fecha", order.CreatedAt.In(doc.Location).Format(documentTimeFormat))
+	if order.ETAFrom != nil && order.ETATo != nil {
+		documentField(pdf, tr, "Entrega", fmt.Sprintf("%s - %s",
+			order.ETAFrom.In(doc.Location).Format(documentTimeFormat), order.ETATo.In(doc.Location).Format("15:04")))
+	} else if order.ETA != "" {
+		documentField(pdf, tr, "Entrega", order.ETA)
+	}
+	if doc.Profile != nil {
+		documentField(pdf, tr, "Cliente", doc.Profile.PhoneNumber)
+	}
+	if doc.Address != nil {
+		documentField(pdf, tr, "Dirección", doc.Address.Address)
+	}
+	pdf.Ln(4)
+
+	widths := []float64{30, 100, 20, 30}
+	documentRow(pdf, tr, widths, 2, true, "Código", "Descripción", "Cant.", "Peso")
+	var units, grams int
+	if order.Data != nil {
+		for _, item := range order.Data.Items {
+			weight := ""
+			if item.Weight != nil {
+				itemGrams := *item.Weight * item.Quantity
+				weight = fmt.Sprintf("%d g", itemGrams)
+				grams += itemGrams
+			}
+			units += item.Quantity
+			documentRow(pdf, tr, widths, 2, false, item.Code, item.Name, fmt.Sprint(item.Quantity), weight)
+		}
+	}
+	documentRow(pdf, tr, widths, 2, true, "", "Total", fmt.Sprint(units), fmt.Sprintf("%d g", grams))
+	pdf.Ln(4)
+
+	var total domain.Money
+	if order.Pricing != nil {
+		total = order.Pricing.Total
+	}
+	if due := total - doc.Paid; due > 0 {
+		documentTotal(pdf, tr, "A cobrar", formatDocumentMoney(due, currency), true)
+	} else {
+		documentTotal(pdf, tr, "Pagado", formatDocumentMoney(doc.Paid, currency), true)
+	}
+
+	pdf.Ln(24)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(80, 6, tr("Firma y aclaración"), "T", 1, "L", false, 0, "")
+
+	return outputDocumentPDF(pdf)
+}
+
+// newDocumentPDF starts an A4 document headed with the business name and title.
+// Its metadata dates are issuedAt rather than the current time, so renders repeat.
+func newDocumentPDF(doc *OrderDocument, title string, issuedAt time.Time) *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(issuedAt.UTC())
+	pdf.SetModificationDate(issuedAt.UTC())
+	pdf.SetTitle(title, true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if doc.BusinessName != "" {
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.CellFormat(0, 8, tr(doc.BusinessName), "", 1, "L", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 8, tr(title), "B", 1, "L", false, 0, "")
+	pdf.Ln(4)
+	return pdf
+}
+
+func documentField(pdf *fpdf.Fpdf, tr func(string) string, label string, value string) {
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(35, 6, tr(label+":"), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr(value), "", 1, "L", false, 0, "")
+}
+
+// documentRow prints a table row; the first textColumns are left aligned, the rest right aligned
+func documentRow(pdf *fpdf.Fpdf, tr func(string) string, widths []float64, textColumns int, header bool, cells ...string) {
+	style, border := "", ""
+	if header {
+		style, border = "B", "B"
+	}
+	pdf.SetFont("Helvetica", style, 9)
+	for i, cell := range cells {
+		align := "R"
+		if i < textColumns {
+			align = "L"
+		}
+		pdf.CellFormat(widths[i], 6, tr(cell), border, 0, align, false, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func documentTotal(pdf *fpdf.Fpdf, tr func(string) string, label string, value string, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	pdf.SetFont("Helvetica", style, 10)
+	pdf.CellFormat(140, 6, tr(label), "", 0, "R", false, 0, "")
+	pdf.CellFormat(40, 6, tr(value), "", 1, "R", false, 0, "")
+}
+
+func outputDocumentPDF(pdf *fpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatDocumentMoney(amount domain.Money, currency string) string {
+	return currency + " " + amount.String()
+}
+
+func formatTaxRate(rate float64) string {
+	return fmt.Sprintf("%g%%", rate)
+}
@@ -0,0 +1,122 @@
+package order
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"yego/internal/domain"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenDocument is a paid, delivered order with every section of both documents.
+// Its pricing is what Calculate gives for these items, delivery and coupon.
+func goldenDocument() *OrderDocument {
+	createdAt := time.Date(2026, time.March, 14, 15, 4, 5, 0, time.UTC)
+	paidAt := createdAt.Add(10 * time.Minute)
+	etaFrom := createdAt.Add(2 * time.Hour)
+	etaTo := etaFrom.Add(time.Hour)
+	weight := 500
+	gatewayID := "1234567890"
+	profileID := "6a1f3c2e-8f4b-4d6a-9c1e-3b2a5d7f9e10"
+
+	return &OrderDocument{
+		BusinessName: "Almacén Yego",
+		Location:     time.FixedZone("ART", -3*60*60),
+		Order: &domain.Order{
+			ID:        "5f0c8a5e-1a47-4c3e-9d1e-2f6f0d7a9b10",
+			ProfileID: &profileID,
+			Status:    domain.StatusDelivered,
+			ETAFrom:   &etaFrom,
+			ETATo:     &etaTo,
+			Currency:  domain.CurrencyARS,
+			CreatedAt: createdAt,
+			UpdatedAt: etaTo,
+			Data: &domain.OrderData{Items: []domain.OrderItem{
+				{Code: "YER-1", Name: "Yerba mate suave", Price: 325050, Quantity: 2, Weight: &weight},
+				{Code: "AZU-1", Name: "Azúcar", Price: 110500, Quantity: 1},
+			}},
+			Pricing: &domain.OrderPricing{
+				Lines: []domain.PricingLine{
+					{Kind: domain.PricingLineProduct, Code: "YER-1", Description: "Yerba mate suave", Quantity: 2, UnitPrice: 325050, Amount: 650100, TaxRate: 21, TaxAmount: 112827},
+					{Kind: domain.PricingLineProduct, Code: "AZU-1", Description: "Azúcar", Quantity: 1, UnitPrice: 110500, Amount: 110500, TaxRate: 10.5, TaxAmount: 10500},
+					{Kind: domain.PricingLineDelivery, Description: "Envío", Quantity: 1, UnitPrice: 90000, Amount: 90000, TaxRate: 21, TaxAmount: 15620},
+					{Kind: domain.PricingLineDiscount, Code: "PROMO", Description: "Cupón PROMO", Quantity: 1, UnitPrice: -50000, Amount: -50000, TaxRate: 0, TaxAmount: -8167},
+				},
+				Subtotal: 760600,
+				Delivery: &domain.DeliveryFeeBreakdown{
+					DistanceKm: 4.2, TotalWeightG: 1000, TotalWeightKg: 1,
+					BasePrice: 50000, DistancePrice: 30000, WeightPrice: 10000, TotalPrice: 90000,
+				},
+				Discounts:     []domain.OrderDiscount{{Code: "PROMO", Description: "Cupón PROMO", Amount: 50000}},
+				DiscountTotal: 50000,
+				Total:         800600,
+				Currency:      domain.CurrencyARS,
+				Tax: &domain.TaxBreakdown{Net: 669820, Tax: 130780, Gross: 800600, Rates: []domain.TaxRateBreakdown{
+					{Rate: 21, Net: 575698, Tax: 120897, Gross: 696595},
+					{Rate: 10.5, Net: 94122, Tax: 9883, Gross: 104005},
+				}},
+				PricedAt: createdAt,
+			},
+		},
+		Profile: &domain.Profile{ID: profileID, PhoneNumber: "+54 9 11 5555-0101"},
+		Address: &domain.ProfileLocation{Address: "Av. Corrientes 1234, CABA"},
+		Payment: &domain.Transaction{
+			Amount:           800600,
+			Currency:         domain.CurrencyARS,
+			Status:           domain.TransactionStatusApproved,
+			Kind:             domain.TransactionKindPayment,
+			GatewayPaymentID: &gatewayID,
+			CreatedAt:        paidAt,
+		},
+		Paid: 800600,
+		Tips: 50000,
+	}
+}
+
+func TestRenderDocumentsMatchGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		golden string
+		render func(*OrderDocument) ([]byte, error)
+	}{
+		{name: "receipt", golden: "receipt.golden", render: RenderReceipt},
+		{name: "delivery note", golden: "delivery_note.golden", render: RenderDeliveryNote},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.render(goldenDocument())
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *updateGolden {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run go test -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from %s (%d bytes, want %d); if the change is intended run go test -update",
+					tt.name, path, len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestRenderReceiptRequiresPayment(t *testing.T) {
+	doc := goldenDocument()
+	doc.Payment = nil
+	if _, err := RenderReceipt(doc); err == nil {
+		t.Error("RenderReceipt() of an unpaid order succeeded")
+	}
+}
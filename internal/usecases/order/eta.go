@@ -0,0 +1,28 @@
+package order
+
+import (
+	"fmt"
+	"time"
+
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// ValidateETAWindow checks that a delivery window does not end before it starts.
+// Either bound may be omitted.
+func ValidateETAWindow(from, to *time.Time) apperrors.ApplicationError {
+	if from != nil && to != nil && to.Before(*from) {
+		return apperrors.NewApplicationError(mappings.OrderInvalidETAWindowError,
+			fmt.Errorf("eta_to %s is before eta_from %s", to.Format(time.RFC3339), from.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// formatOptionalTime formats a nullable timestamp for outputs
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &formatted
+}
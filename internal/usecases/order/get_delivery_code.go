@@ -0,0 +1,66 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// GetDeliveryCodeInput represents the input for showing an order's delivery code
+type GetDeliveryCodeInput struct {
+	OrderID string
+	UserID  string
+}
+
+// GetDeliveryCodeOutput is the code the customer reads out to the courier
+type GetDeliveryCodeOutput struct {
+	OrderID string `json:"order_id"`
+	Code    string `json:"code"`
+}
+
+// GetDeliveryCodeUsecase defines the interface for showing the owner their delivery code
+type GetDeliveryCodeUsecase interface {
+	Execute(ctx context.Context, input GetDeliveryCodeInput) (*GetDeliveryCodeOutput, apperrors.ApplicationError)
+}
+
+type getDeliveryCodeUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetDeliveryCodeUsecase creates a new instance of GetDeliveryCodeUsecase
+func NewGetDeliveryCodeUsecase(contextFactory appcontext.Factory) GetDeliveryCodeUsecase {
+	return &getDeliveryCodeUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns the delivery code of the user's order while it is on its way.
+// The public order view never includes it, since anyone with the UUID can see that.
+func (u *getDeliveryCodeUsecase) Execute(ctx context.Context, input GetDeliveryCodeInput) (*GetDeliveryCodeOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	code := pendingDeliveryCode(ctx, app, order)
+	if code == nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliveryCodeNotAvailableError, nil)
+	}
+
+	return &GetDeliveryCodeOutput{
+		OrderID: order.ID,
+		Code:    *code,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// GetDocumentInput represents the input for printing an order document
+type GetDocumentInput struct {
+	OrderID string
+	Kind    string // DocumentReceipt or DocumentDeliveryNote
+	UserID  string // the order must belong to this user, unless AsAdmin
+	AsAdmin bool
+}
+
+// DocumentOutput is a rendered PDF
+type DocumentOutput struct {
+	Filename string
+	Content  []byte
+}
+
+// GetDocumentUsecase defines the interface for printing order documents
+type GetDocumentUsecase interface {
+	Execute(ctx context.Context, input GetDocumentInput) (*DocumentOutput, apperrors.ApplicationError)
+}
+
+type getDocumentUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetDocumentUsecase creates a new instance of GetDocumentUsecase
+func NewGetDocumentUsecase(contextFactory appcontext.Factory) GetDocumentUsecase {
+	return &getDocumentUsecase{contextFactory: contextFactory}
+}
+
+// Execute renders the receipt or the delivery note of an order as a PDF
+func (u *getDocumentUsecase) Execute(ctx context.Context, input GetDocumentInput) (*DocumentOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	doc, err := LoadOrderDocument(ctx, app, order)
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	var renderErr error
+	switch input.Kind {
+	case DocumentReceipt:
+		if doc.Payment == nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderReceiptNotAvailableError, fmt.Errorf("order %s is %s", order.ID, order.Status))
+		}
+		content, renderErr = RenderReceipt(doc)
+	case DocumentDeliveryNote:
+		content, renderErr = RenderDeliveryNote(doc)
+	default:
+		return nil, apperrors.NewApplicationError(mappings.OrderDocumentError, fmt.Errorf("unknown document %q", input.Kind))
+	}
+	if renderErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderDocumentError, renderErr)
+	}
+
+	return &DocumentOutput{
+		Filename: fmt.Sprintf("%s-%s.pdf", input.Kind, order.ID),
+		Content:  content,
+	}, nil
+}
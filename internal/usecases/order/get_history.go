@@ -0,0 +1,55 @@
+package order
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// GetHistoryOutput represents the status timeline of an order
+type GetHistoryOutput struct {
+	OrderID string                   `json:"order_id"`
+	Events  []OrderStatusEventOutput `json:"events"`
+}
+
+// GetHistoryUsecase defines the interface for getting an order's status history
+type GetHistoryUsecase interface {
+	Execute(ctx context.Context, id string) (*GetHistoryOutput, apperrors.ApplicationError)
+}
+
+type getHistoryUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetHistoryUsecase creates a new instance of GetHistoryUsecase
+func NewGetHistoryUsecase(contextFactory appcontext.Factory) GetHistoryUsecase {
+	return &getHistoryUsecase{contextFactory: contextFactory}
+}
+
+// Execute retrieves the status timeline of an order
+func (u *getHistoryUsecase) Execute(ctx context.Context, id string) (*GetHistoryOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	// Make sure the order exists so unknown IDs return 404 instead of an empty timeline
+	if _, err := app.Repositories.Order.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	events, err := app.Repositories.Order.GetStatusEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetHistoryOutput{
+		OrderID: id,
+		Events:  toOrderStatusEventOutputs(events),
+	}, nil
+}
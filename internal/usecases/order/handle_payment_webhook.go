@@ -11,6 +11,7 @@ import (
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
 )
 
 // HandlePaymentWebhookInput represents the MercadoPago webhook notification
@@ -25,6 +26,9 @@ type HandlePaymentWebhookInput struct {
 	}
 }
 
+// maxConfirmAttempts bounds the retries when confirming an order hits a version conflict
+const maxConfirmAttempts = 3
+
 type HandlePaymentWebhookUsecase interface {
 	Execute(ctx context.Context, resourceID string, topic string) apperrors.ApplicationError
 }
@@ -53,8 +57,9 @@ func (u *handlePaymentWebhookUsecase) Execute(ctx context.Context, resourceID st
 	}
 
 	type paymentInfo struct {
-		orderID     string
-		amount      float64
+		reference   string
+		amount      domain.Money
+		currency    string
 		mpPaymentID string
 	}
 
@@ -67,7 +72,7 @@ func (u *handlePaymentWebhookUsecase) Execute(ctx context.Context, resourceID st
 			log.Printf("Webhook: error getting payment %s: %v", resourceID, err)
 			return nil
 		}
-		info = paymentInfo{orderID: pi.orderID, amount: pi.amount, mpPaymentID: resourceID}
+		info = paymentInfo{reference: pi.reference, amount: pi.amount, currency: pi.currency, mpPaymentID: resourceID}
 
 	case "merchant_order":
 		pi, err := u.getMerchantOrderInfo(resourceID, checkoutProToken)
@@ -75,19 +80,24 @@ func (u *handlePaymentWebhookUsecase) Execute(ctx context.Context, resourceID st
 			log.Printf("Webhook: error getting merchant_order %s: %v", resourceID, err)
 			return nil
 		}
-		info = paymentInfo{orderID: pi.orderID, amount: pi.amount, mpPaymentID: pi.mpPaymentID}
+		info = paymentInfo{reference: pi.reference, amount: pi.amount, currency: pi.currency, mpPaymentID: pi.mpPaymentID}
 
 	default:
 		log.Printf("Webhook: unsupported topic %s, skipping", topic)
 		return nil
 	}
 
-	if info.orderID == "" {
+	if info.reference == "" {
 		log.Printf("Webhook: no external_reference found for %s/%s, skipping", topic, resourceID)
 		return nil
 	}
 
-	return u.confirmOrder(ctx, info.orderID, info.mpPaymentID, info.amount)
+	orderID, tip, refErr := parsePaymentLinkReference(info.reference)
+	if refErr != nil {
+		log.Printf("Webhook: %v, recording %s/%s without a tip", refErr, topic, resourceID)
+	}
+
+	return u.confirmOrder(ctx, orderID, info.mpPaymentID, info.amount, tip, info.currency)
 }
 
 func (u *handlePaymentWebhookUsecase) mpGet(path string, token string) ([]byte, error) {
@@ -109,8 +119,9 @@ func (u *handlePaymentWebhookUsecase) mpGet(path string, token string) ([]byte,
 }
 
 type mpPaymentResult struct {
-	orderID     string
-	amount      float64
+	reference   string // external_reference, see paymentLinkReference
+	amount      domain.Money
+	currency    string
 	mpPaymentID string
 }
 
@@ -120,18 +131,19 @@ func (u *handlePaymentWebhookUsecase) getPaymentInfo(paymentID string, token str
 		return nil, err
 	}
 	var p struct {
-		Status            string  `json:"status"`
-		ExternalReference string  `json:"external_reference"`
-		TransactionAmount float64 `json:"transaction_amount"`
+		Status            string       `json:"status"`
+		ExternalReference string       `json:"external_reference"`
+		TransactionAmount domain.Money `json:"transaction_amount"`
+		CurrencyID        string       `json:"currency_id"`
 	}
 	if err := json.Unmarshal(body, &p); err != nil {
 		return nil, err
 	}
-	log.Printf("Webhook: payment %s status=%s external_reference=%s amount=%.2f", paymentID, p.Status, p.ExternalReference, p.TransactionAmount)
-	if p.Status != "approved" {
+	log.Printf("Webhook: payment %s status=%s external_reference=%s amount=%s", paymentID, p.Status, p.ExternalReference, p.TransactionAmount)
+	if p.Status != domain.TransactionStatusApproved {
 		return &mpPaymentResult{}, nil
 	}
-	return &mpPaymentResult{orderID: p.ExternalReference, amount: p.TransactionAmount, mpPaymentID: paymentID}, nil
+	return &mpPaymentResult{reference: p.ExternalReference, amount: p.TransactionAmount, currency: p.CurrencyID, mpPaymentID: paymentID}, nil
 }
 
 func (u *handlePaymentWebhookUsecase) getMerchantOrderInfo(orderID string, token string) (*mpPaymentResult, error) {
@@ -140,13 +152,14 @@ func (u *handlePaymentWebhookUsecase) getMerchantOrderInfo(orderID string, token
 		return nil, err
 	}
 	var mo struct {
-		Status            string  `json:"status"`
-		ExternalReference string  `json:"external_reference"`
-		TotalAmount       float64 `json:"total_amount"`
+		Status            string       `json:"status"`
+		ExternalReference string       `json:"external_reference"`
+		TotalAmount       domain.Money `json:"total_amount"`
 		Payments          []struct {
-			ID     int64   `json:"id"`
-			Status string  `json:"status"`
-			Amount float64 `json:"transaction_amount"`
+			ID         int64        `json:"id"`
+			Status     string       `json:"status"`
+			Amount     domain.Money `json:"transaction_amount"`
+			CurrencyID string       `json:"currency_id"`
 		} `json:"payments"`
 	}
 	if err := json.Unmarshal(body, &mo); err != nil {
@@ -155,11 +168,13 @@ func (u *handlePaymentWebhookUsecase) getMerchantOrderInfo(orderID string, token
 	log.Printf("Webhook: merchant_order %s status=%s external_reference=%s", orderID, mo.Status, mo.ExternalReference)
 
 	var approvedPaymentID string
-	var approvedAmount float64
+	var approvedAmount domain.Money
+	var approvedCurrency string
 	for _, p := range mo.Payments {
-		if p.Status == "approved" {
+		if p.Status == domain.TransactionStatusApproved {
 			approvedPaymentID = fmt.Sprintf("%d", p.ID)
 			approvedAmount = p.Amount
+			approvedCurrency = p.CurrencyID
 			break
 		}
 	}
@@ -170,47 +185,93 @@ func (u *handlePaymentWebhookUsecase) getMerchantOrderInfo(orderID string, token
 	if amount == 0 {
 		amount = mo.TotalAmount
 	}
-	return &mpPaymentResult{orderID: mo.ExternalReference, amount: amount, mpPaymentID: approvedPaymentID}, nil
+	return &mpPaymentResult{reference: mo.ExternalReference, amount: amount, currency: approvedCurrency, mpPaymentID: approvedPaymentID}, nil
 }
 
-func (u *handlePaymentWebhookUsecase) confirmOrder(ctx context.Context, orderID string, mpPaymentID string, amount float64) apperrors.ApplicationError {
+func (u *handlePaymentWebhookUsecase) confirmOrder(ctx context.Context, orderID string, mpPaymentID string, amount domain.Money, tip domain.Money, currency string) apperrors.ApplicationError {
 	app := u.contextFactory()
 
-	order, appErr := app.Repositories.Order.GetByID(ctx, orderID)
-	if appErr != nil {
-		log.Printf("Webhook: order %s not found: %v", orderID, appErr)
-		return nil
-	}
+	// MP usually notifies both the payment and the merchant_order, so two webhooks
+	// may race to confirm the same order. The version guard lets only one of them
+	// win; the loser re-reads the order and finds it already confirmed.
+	var order *domain.Order
+	for attempt := 1; ; attempt++ {
+		var appErr apperrors.ApplicationError
+		order, appErr = app.Repositories.Order.GetByID(ctx, orderID)
+		if appErr != nil {
+			log.Printf("Webhook: order %s not found: %v", orderID, appErr)
+			return nil
+		}
 
-	if order.Status != domain.StatusCreated {
-		log.Printf("Webhook: order %s already in status %s, skipping", orderID, order.Status)
-		return nil
-	}
+		if order.Status != domain.StatusCreated {
+			log.Printf("Webhook: order %s already in status %s, skipping", orderID, order.Status)
+			return nil
+		}
 
-	_, appErr = app.Repositories.Order.UpdateStatus(ctx, orderID, domain.StatusConfirmed)
-	if appErr != nil {
-		return appErr
+		// A payment in another currency doesn't settle the order; leave it for review
+		if currency != "" && currency != orderCurrency(order) {
+			log.Printf("Webhook: order %s is in %s but payment %s is in %s, skipping", orderID, orderCurrency(order), mpPaymentID, currency)
+			return nil
+		}
+
+		order.Status = domain.StatusConfirmed
+		_, appErr = app.Repositories.Order.Update(ctx, order, nil)
+		if appErr == nil {
+			break
+		}
+		if appErr.Code() != mappings.OrderVersionConflictError.Code || attempt == maxConfirmAttempts {
+			return appErr
+		}
+		log.Printf("Webhook: order %s changed while confirming (attempt %d), retrying", orderID, attempt)
 	}
 
 	userID := ""
 	if order.UserID != nil {
 		userID = *order.UserID
 	}
+	// The tip added to the payment link is part of the amount paid and is recorded
+	// as its own transaction
+	if tip > amount {
+		log.Printf("Webhook: order %s paid %s, less than its tip of %s, recording no tip", orderID, amount, tip)
+		tip = 0
+	}
+	amount -= tip
+
 	description := fmt.Sprintf("Pago por link para pedido %s", orderID)
 	transaction := &domain.Transaction{
 		OrderID:          orderID,
 		UserID:           userID,
 		ProfileID:        order.ProfileID,
 		Amount:           amount,
-		Currency:         "ARS",
-		Status:           "approved",
+		Currency:         orderCurrency(order),
+		Status:           domain.TransactionStatusApproved,
+		Kind:             domain.TransactionKindPayment,
 		GatewayPaymentID: &mpPaymentID,
 		Description:      &description,
 	}
+	setTransactionTax(order, transaction)
 	if _, transErr := app.Repositories.Transaction.Create(ctx, transaction); transErr != nil {
 		log.Printf("Webhook: warning: failed to create transaction for order %s: %v", orderID, transErr)
 	}
 
-	log.Printf("Webhook: order %s confirmed via payment link (mp_payment %s amount=%.2f)", orderID, mpPaymentID, amount)
+	if tip > 0 {
+		tipDescription := fmt.Sprintf("Propina por pedido %s", orderID)
+		tipTransaction := &domain.Transaction{
+			OrderID:          orderID,
+			UserID:           userID,
+			ProfileID:        order.ProfileID,
+			Amount:           tip,
+			Currency:         orderCurrency(order),
+			Status:           domain.TransactionStatusApproved,
+			Kind:             domain.TransactionKindTip,
+			GatewayPaymentID: &mpPaymentID,
+			Description:      &tipDescription,
+		}
+		if _, transErr := app.Repositories.Transaction.Create(ctx, tipTransaction); transErr != nil {
+			log.Printf("Webhook: warning: failed to create tip transaction for order %s: %v", orderID, transErr)
+		}
+	}
+
+	log.Printf("Webhook: order %s confirmed via payment link (mp_payment %s amount=%s)", orderID, mpPaymentID, amount)
 	return nil
 }
@@ -0,0 +1,60 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// ListModificationsInput represents the input for listing an order's modification requests
+type ListModificationsInput struct {
+	OrderID string
+	UserID  string
+}
+
+// ListModificationsUsecase defines the interface for listing an order's modification requests
+type ListModificationsUsecase interface {
+	Execute(ctx context.Context, input ListModificationsInput) ([]ModificationRequestOutput, apperrors.ApplicationError)
+}
+
+type listModificationsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListModificationsUsecase creates a new instance of ListModificationsUsecase
+func NewListModificationsUsecase(contextFactory appcontext.Factory) ListModificationsUsecase {
+	return &listModificationsUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists the modification requests of the user's order, newest first
+func (u *listModificationsUsecase) Execute(ctx context.Context, input ListModificationsInput) ([]ModificationRequestOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	requests, err := app.Repositories.ModificationRequest.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]ModificationRequestOutput, 0, len(requests))
+	for _, request := range requests {
+		outputs = append(outputs, ToModificationRequestOutput(request))
+	}
+	return outputs, nil
+}
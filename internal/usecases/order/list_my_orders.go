@@ -2,20 +2,40 @@ package order
 
 import (
 	"context"
+	"fmt"
 
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
 )
 
+// Order history states customers can filter by
+const (
+	OrderStateActive   = "active"   // orders still in progress
+	OrderStateFinished = "finished" // delivered or cancelled orders
+)
+
+// ListMyOrdersInput represents the input for listing user's orders. Without a
+// limit or a cursor every order is returned, newest first, in a single page.
+type ListMyOrdersInput struct {
+	UserID string
+	State  string // OrderStateActive, OrderStateFinished or empty for all
+	Limit  int
+	Cursor string // next_cursor of the previous page
+}
+
 // ListMyOrdersOutput represents the output for listing user's orders
 type ListMyOrdersOutput struct {
-	Orders []OrderOutputData `json:"orders"`
-	Total  int               `json:"total"`
+	Orders     []OrderOutputData `json:"orders"`
+	Total      int               `json:"total"` // orders matching the state, across all pages
+	HasMore    bool              `json:"has_more"`
+	NextCursor *string           `json:"next_cursor,omitempty"`
 }
 
 // ListMyOrdersUsecase defines the interface for listing user's orders
 type ListMyOrdersUsecase interface {
-	Execute(ctx context.Context, userID string) (*ListMyOrdersOutput, apperrors.ApplicationError)
+	Execute(ctx context.Context, input ListMyOrdersInput) (*ListMyOrdersOutput, apperrors.ApplicationError)
 }
 
 type listMyOrdersUsecase struct {
@@ -27,22 +47,62 @@ func NewListMyOrdersUsecase(contextFactory appcontext.Factory) ListMyOrdersUseca
 	return &listMyOrdersUsecase{contextFactory: contextFactory}
 }
 
-// Execute lists all orders for a specific user
-func (u *listMyOrdersUsecase) Execute(ctx context.Context, userID string) (*ListMyOrdersOutput, apperrors.ApplicationError) {
+// Execute lists the orders of a specific user, newest first
+func (u *listMyOrdersUsecase) Execute(ctx context.Context, input ListMyOrdersInput) (*ListMyOrdersOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
-	orders, err := app.Repositories.Order.GetByUserID(ctx, userID)
-	if err != nil {
-		return nil, err
+	var statuses []domain.OrderStatus
+	switch input.State {
+	case "":
+	case OrderStateActive:
+		statuses = domain.StatusesByFinished(false)
+	case OrderStateFinished:
+		statuses = domain.StatusesByFinished(true)
+	default:
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidSearchError,
+			fmt.Errorf("state must be %s or %s", OrderStateActive, OrderStateFinished))
 	}
 
-	output := &ListMyOrdersOutput{
-		Orders: make([]OrderOutputData, 0, len(orders)),
-		Total:  len(orders),
+	var orders []*domain.Order
+	output := &ListMyOrdersOutput{}
+
+	if input.Limit <= 0 && input.Cursor == "" {
+		all, err := app.Repositories.Order.GetByUserID(ctx, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range all {
+			if input.State == "" || domain.IsFinishedStatus(o.Status) == (input.State == OrderStateFinished) {
+				orders = append(orders, o)
+			}
+		}
+		output.Total = len(orders)
+	} else {
+		// Keyset pages on (created_at, id) stay stable while new orders arrive
+		result, err := app.Repositories.Order.Search(ctx, domain.OrderSearchFilter{
+			UserID:   input.UserID,
+			Statuses: statuses,
+			Sort:     domain.OrderSortCreatedAt,
+			Limit:    input.Limit,
+			Cursor:   input.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		orders = result.Orders
+		output.Total = result.Total
+		if result.NextCursor != "" {
+			output.HasMore = true
+			output.NextCursor = &result.NextCursor
+		}
 	}
 
+	codes := pendingDeliveryCodes(ctx, app, orders)
+	output.Orders = make([]OrderOutputData, 0, len(orders))
 	for _, o := range orders {
-		output.Orders = append(output.Orders, toOrderOutputData(o, true))
+		data := toOrderOutputData(o, true)
+		data.DeliveryCode = codes[o.ID]
+		output.Orders = append(output.Orders, data)
 	}
 
 	return output, nil
@@ -0,0 +1,88 @@
+package order
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"yego/internal/adapters/datasources/repositories"
+	"yego/internal/adapters/datasources/repositories/deliverycode"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+type fakeOrderList struct {
+	fakeOrders
+	orders []*domain.Order
+}
+
+func (f *fakeOrderList) GetByUserID(context.Context, string) ([]*domain.Order, apperrors.ApplicationError) {
+	return f.orders, nil
+}
+
+// fakeDeliveryCodes records the order IDs of every batch lookup
+type fakeDeliveryCodes struct {
+	deliverycode.Repository
+	codes   map[string]*domain.DeliveryCode
+	lookups [][]string
+}
+
+func (f *fakeDeliveryCodes) ListByOrderIDs(_ context.Context, orderIDs []string) (map[string]*domain.DeliveryCode, apperrors.ApplicationError) {
+	f.lookups = append(f.lookups, orderIDs)
+	found := make(map[string]*domain.DeliveryCode)
+	for _, id := range orderIDs {
+		if code, ok := f.codes[id]; ok {
+			found[id] = code
+		}
+	}
+	return found, nil
+}
+
+func TestListMyOrdersLoadsDeliveryCodesInOneQuery(t *testing.T) {
+	verifiedAt := time.Now()
+	orders := &fakeOrderList{orders: []*domain.Order{
+		{ID: "on-the-way", Status: domain.StatusOnTheWay},
+		{ID: "paused", Status: domain.StatusPaused},
+		{ID: "verified", Status: domain.StatusOnTheWay},
+		{ID: "delivered", Status: domain.StatusDelivered},
+		{ID: "confirmed", Status: domain.StatusConfirmed},
+	}}
+	codes := &fakeDeliveryCodes{codes: map[string]*domain.DeliveryCode{
+		"on-the-way": {OrderID: "on-the-way", Code: "1234"},
+		"paused":     {OrderID: "paused", Code: "5678"},
+		"verified":   {OrderID: "verified", Code: "9012", VerifiedAt: &verifiedAt},
+		"delivered":  {OrderID: "delivered", Code: "3456", VerifiedAt: &verifiedAt},
+	}}
+	app := &appcontext.Context{
+		Repositories: &repositories.Repositories{Order: orders, DeliveryCode: codes},
+	}
+	usecase := NewListMyOrdersUsecase(func(...appcontext.Option) *appcontext.Context { return app })
+
+	output, err := usecase.Execute(context.Background(), ListMyOrdersInput{UserID: testUserID})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(codes.lookups) != 1 {
+		t.Fatalf("got %d delivery code lookups, want 1", len(codes.lookups))
+	}
+	looked := append([]string(nil), codes.lookups[0]...)
+	sort.Strings(looked)
+	if want := []string{"on-the-way", "paused", "verified"}; !reflect.DeepEqual(looked, want) {
+		t.Errorf("looked up %v, want %v", looked, want)
+	}
+
+	want := map[string]string{"on-the-way": "1234", "paused": "5678"}
+	for _, o := range output.Orders {
+		got := ""
+		if o.DeliveryCode != nil {
+			got = *o.DeliveryCode
+		}
+		if got != want[o.ID] {
+			t.Errorf("order %s DeliveryCode = %q, want %q", o.ID, got, want[o.ID])
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package order
+
+import (
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/usecases/notification"
+)
+
+// NotifyOrderUpdated tells managers an order moved from previousStatus to its
+// current status. It sends asynchronously and only logs failures.
+func NotifyOrderUpdated(notificationSvc notification.Service, order *domain.Order, previousStatus domain.OrderStatus) {
+	if notificationSvc == nil {
+		return
+	}
+
+	payload := notification.OrderUpdatedPayload{
+		OrderID:        order.ID,
+		Status:         string(order.Status),
+		PreviousStatus: string(previousStatus),
+		Version:        order.Version,
+		UpdatedAt:      order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if order.UserID != nil {
+		payload.UserID = *order.UserID
+	}
+	if order.StatusMessage != nil {
+		payload.StatusMessage = *order.StatusMessage
+	}
+	if order.PauseReason != nil {
+		payload.PauseReason = *order.PauseReason
+	}
+	if order.ResumeAt != nil {
+		resumeAt := order.ResumeAt.Format("2006-01-02T15:04:05Z")
+		payload.ResumeAt = &resumeAt
+	}
+
+	go func() {
+		if err := notificationSvc.NotifyOrderUpdated(payload); err != nil {
+			log.Printf("Failed to send order_updated notification for order %s: %v", payload.OrderID, err)
+		}
+	}()
+}
@@ -1,21 +1,33 @@
 package order
 
 import (
+	"time"
+
 	"yego/internal/domain"
 )
 
 // OrderOutputData represents basic order data for outputs
 type OrderOutputData struct {
-	ID          string          `json:"id"`
-	ProfileID   *string         `json:"profile_id,omitempty"`
-	UserID      *string         `json:"user_id,omitempty"`
-	Status      string          `json:"status"`
-	StatusIndex int             `json:"status_index"`
-	ETA         string          `json:"eta"`
-	Data        *OrderItemsData `json:"data,omitempty"`
-	CreatedAt   string          `json:"created_at"`
-	UpdatedAt   string          `json:"updated_at"`
-	AllStatuses []string        `json:"all_statuses,omitempty"`
+	ID             string               `json:"id"`
+	ProfileID      *string              `json:"profile_id,omitempty"`
+	UserID         *string              `json:"user_id,omitempty"`
+	Status         string               `json:"status"`
+	StatusIndex    int                  `json:"status_index"`
+	ETA            string               `json:"eta"`
+	ETAFrom        *string              `json:"eta_from,omitempty"`
+	ETATo          *string              `json:"eta_to,omitempty"`
+	IsLate         bool                 `json:"is_late"`
+	Data           *OrderItemsData      `json:"data,omitempty"`
+	Version        int                  `json:"version"`
+	PauseReason    *string              `json:"pause_reason,omitempty"`
+	ResumeAt       *string              `json:"resume_at,omitempty"`
+	SubscriptionID *string              `json:"subscription_id,omitempty"`
+	DeliveryCode   *string              `json:"delivery_code,omitempty"` // owner views only
+	Pricing        *domain.OrderPricing `json:"pricing,omitempty"`
+	Currency       string               `json:"currency"`
+	CreatedAt      string               `json:"created_at"`
+	UpdatedAt      string               `json:"updated_at"`
+	AllStatuses    []string             `json:"all_statuses,omitempty"`
 }
 
 // OrderItemsData represents the items data in an order
@@ -25,34 +37,65 @@ type OrderItemsData struct {
 
 // OrderItemOutput represents a single item in the order output
 type OrderItemOutput struct {
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
-	Weight   *int    `json:"weight,omitempty"`
+	Name     string       `json:"name"`
+	Price    domain.Money `json:"price"`
+	Quantity int          `json:"quantity"`
+	Weight   *int         `json:"weight,omitempty"`
+}
+
+// OrderStatusEventOutput represents a single entry of the order status timeline.
+// The timeline is public to anyone tracking the order, so it doesn't say who made each change.
+type OrderStatusEventOutput struct {
+	PreviousStatus *string `json:"previous_status,omitempty"`
+	NewStatus      string  `json:"new_status"`
+	Message        *string `json:"message,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// toOrderStatusEventOutputs converts domain status events to output
+func toOrderStatusEventOutputs(events []*domain.OrderStatusEvent) []OrderStatusEventOutput {
+	outputs := make([]OrderStatusEventOutput, 0, len(events))
+	for _, e := range events {
+		output := OrderStatusEventOutput{
+			NewStatus: string(e.NewStatus),
+			Message:   e.Message,
+			CreatedAt: e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if e.PreviousStatus != nil {
+			previous := string(*e.PreviousStatus)
+			output.PreviousStatus = &previous
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs
 }
 
 // toOrderOutputData converts a domain order to output data
 func toOrderOutputData(order *domain.Order, includeStatuses bool) OrderOutputData {
 	output := OrderOutputData{
-		ID:          order.ID,
-		ProfileID:   order.ProfileID,
-		UserID:      order.UserID,
-		Status:      string(order.Status),
-		StatusIndex: order.StatusIndex(),
-		ETA:         order.ETA,
-		CreatedAt:   order.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:   order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:             order.ID,
+		ProfileID:      order.ProfileID,
+		UserID:         order.UserID,
+		Status:         string(order.Status),
+		StatusIndex:    order.StatusIndex(),
+		ETA:            order.ETA,
+		ETAFrom:        formatOptionalTime(order.ETAFrom),
+		ETATo:          formatOptionalTime(order.ETATo),
+		IsLate:         order.IsLate(time.Now()),
+		Version:        order.Version,
+		PauseReason:    order.PauseReason,
+		ResumeAt:       formatOptionalTime(order.ResumeAt),
+		SubscriptionID: order.SubscriptionID,
+		Pricing:        order.Pricing,
+		Currency:       order.Currency,
+		CreatedAt:      order.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 
 	if order.Data != nil && len(order.Data.Items) > 0 {
 		items := make([]OrderItemOutput, len(order.Data.Items))
 		for i, item := range order.Data.Items {
-			items[i] = OrderItemOutput{
-				Name:     item.Name,
-				Price:    item.Price,
-				Quantity: item.Quantity,
-				Weight:   item.Weight,
-			}
+			items[i] = toOrderItemOutput(item)
 		}
 		output.Data = &OrderItemsData{Items: items}
 	}
@@ -68,6 +111,16 @@ func toOrderOutputData(order *domain.Order, includeStatuses bool) OrderOutputDat
 	return output
 }
 
+// toOrderItemOutput converts a domain order item to output
+func toOrderItemOutput(item domain.OrderItem) OrderItemOutput {
+	return OrderItemOutput{
+		Name:     item.Name,
+		Price:    item.Price,
+		Quantity: item.Quantity,
+		Weight:   item.Weight,
+	}
+}
+
 // getAllStatuses returns all valid order statuses
 func getAllStatuses() []string {
 	allStatuses := make([]string, len(domain.ValidStatuses))
@@ -76,3 +129,42 @@ func getAllStatuses() []string {
 	}
 	return allStatuses
 }
+
+// ModificationRequestOutput represents a modification request together with its diff
+// against the order items it was proposed for
+type ModificationRequestOutput struct {
+	ID              string                   `json:"id"`
+	OrderID         string                   `json:"order_id"`
+	Status          string                   `json:"status"`
+	PreviousStatus  string                   `json:"previous_status"`
+	OriginalItems   []domain.OrderItem       `json:"original_items"`
+	Items           []domain.OrderItem       `json:"items"`
+	Changes         []domain.OrderItemChange `json:"changes"`
+	ReviewedBy      *string                  `json:"reviewed_by,omitempty"`
+	ReviewNote      *string                  `json:"review_note,omitempty"`
+	PriceDifference *domain.Money            `json:"price_difference,omitempty"`
+	CreatedAt       string                   `json:"created_at"`
+	ResolvedAt      *string                  `json:"resolved_at,omitempty"`
+}
+
+// ToModificationRequestOutput converts a modification request to output
+func ToModificationRequestOutput(request *domain.ModificationRequest) ModificationRequestOutput {
+	output := ModificationRequestOutput{
+		ID:              request.ID,
+		OrderID:         request.OrderID,
+		Status:          string(request.Status),
+		PreviousStatus:  string(request.PreviousStatus),
+		OriginalItems:   request.OriginalItems,
+		Items:           request.Items,
+		Changes:         request.Changes(),
+		ReviewedBy:      request.ReviewedBy,
+		ReviewNote:      request.ReviewNote,
+		PriceDifference: request.PriceDifference,
+		CreatedAt:       request.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if request.ResolvedAt != nil {
+		resolvedAt := request.ResolvedAt.Format("2006-01-02T15:04:05Z")
+		output.ResolvedAt = &resolvedAt
+	}
+	return output
+}
@@ -0,0 +1,85 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// PauseInput represents the input for pausing an order
+type PauseInput struct {
+	OrderID  string
+	UserID   string
+	Reason   string
+	ResumeAt *time.Time // optional, the order is resumed automatically at this time
+}
+
+// PauseOutput represents the output after pausing or resuming an order
+type PauseOutput struct {
+	Data OrderOutputData `json:"data"`
+}
+
+// PauseUsecase defines the interface for pausing orders
+type PauseUsecase interface {
+	Execute(ctx context.Context, input PauseInput) (*PauseOutput, apperrors.ApplicationError)
+}
+
+type pauseUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewPauseUsecase creates a new instance of PauseUsecase
+func NewPauseUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) PauseUsecase {
+	return &pauseUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute pauses an order, keeping the status it had so it can be restored later
+func (u *pauseUsecase) Execute(ctx context.Context, input PauseInput) (*PauseOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+	if input.ResumeAt != nil && !input.ResumeAt.After(time.Now()) {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidResumeAtError, errors.New("resume_at is in the past"))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if transitionErr := ValidateStatusTransition(ctx, app, order, domain.StatusPaused); transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	previousStatus := order.Status
+	var reason *string
+	if input.Reason != "" {
+		reason = &input.Reason
+	}
+	order.Pause(reason, input.ResumeAt)
+	order.StatusMessage = reason
+
+	paused, err := app.Repositories.Order.Update(ctx, order, ActorUserID(input.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	NotifyOrderUpdated(u.notificationSvc, paused, previousStatus)
+
+	return &PauseOutput{
+		Data: toOrderOutputData(paused, false),
+	}, nil
+}
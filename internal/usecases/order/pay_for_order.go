@@ -3,6 +3,10 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+
+	"yego/internal/domain"
 
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
@@ -16,12 +20,15 @@ type PayForOrderInput struct {
 	UserID       string
 	AuthToken    string
 	SecurityCode string
+	Tip          domain.Money // optional, charged apart from the order
 }
 
 // PayForOrderOutput represents the output after paying an order
 type PayForOrderOutput struct {
-	OrderID string `json:"order_id"`
-	Status  string `json:"status"`
+	OrderID   string       `json:"order_id"`
+	Status    string       `json:"status"`
+	Tip       domain.Money `json:"tip,omitempty"`
+	TipStatus string       `json:"tip_status,omitempty"` // TipStatusCharged or TipStatusFailed
 }
 
 // PayForOrderUsecase defines the interface for paying an order
@@ -42,10 +49,16 @@ func NewPayForOrderUsecase(contextFactory appcontext.Factory, calculateDeliveryF
 	}
 }
 
-// Execute processes the payment for an order and moves it from CREATED to CONFIRMED
+// Execute processes the payment for an order and moves it from CREATED to CONFIRMED.
+// A tip is charged after the order as a separate transaction; if it fails the order
+// stays paid and the output says so.
 func (u *payForOrderUsecase) Execute(ctx context.Context, input PayForOrderInput) (*PayForOrderOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	if input.Tip < 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTipError, fmt.Errorf("tip of %s", input.Tip))
+	}
+
 	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
 	if err != nil {
 		return nil, err
@@ -63,13 +76,22 @@ func (u *payForOrderUsecase) Execute(ctx context.Context, input PayForOrderInput
 
 	paymentErr := ProcessPaymentForOrder(ctx, app, order, input.AuthToken, input.SecurityCode, u.calculateDeliveryFeeUse)
 	if paymentErr != nil {
-		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, paymentErr)
+		return nil, paymentFailedError(paymentErr)
 	}
 
-	_, _ = app.Repositories.Order.UpdateStatus(ctx, input.OrderID, "CONFIRMED")
+	_, _ = app.Repositories.Order.UpdateStatus(ctx, input.OrderID, "CONFIRMED", nil, ActorUserID(input.UserID))
 
-	return &PayForOrderOutput{
+	output := &PayForOrderOutput{
 		OrderID: input.OrderID,
 		Status:  "CONFIRMED",
-	}, nil
+	}
+	if input.Tip > 0 {
+		output.Tip = input.Tip
+		output.TipStatus = TipStatusCharged
+		if _, tipErr := ChargeTip(ctx, app, order, input.Tip, input.AuthToken, input.SecurityCode); tipErr != nil {
+			log.Printf("PayForOrder: tip failed for order %s: %v", order.ID, tipErr)
+			output.TipStatus = TipStatusFailed
+		}
+	}
+	return output, nil
 }
@@ -2,20 +2,77 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"math"
 
 	"yego/internal/adapters/web/integrations/payments"
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
 	settingsUsecase "yego/internal/usecases/settings"
 )
 
-// ProcessPaymentForOrder processes the payment when an order is delivered.
-// It resolves the user's internal UUID, checks for a payment method, calculates
-// the order total, charges the user, and records the transaction.
+// ProcessPaymentForOrder charges the order's priced snapshot to the user's saved
+// payment method and records the transaction. Orders without a snapshot are priced
+// first, so settings changes after that point don't change what is charged.
 func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, token string, securityCode string, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) error {
+	pricing, err := EnsurePricing(ctx, app, order, calculateDeliveryFeeUse)
+	if err != nil {
+		return fmt.Errorf("failed to calculate order total: %w", err)
+	}
+	if pricing.Total <= 0 {
+		return fmt.Errorf("order total is zero or negative")
+	}
+
+	profile, err := resolveOrderProfile(ctx, app, order)
+	if err != nil {
+		return err
+	}
+
+	_, err = chargeSavedMethod(ctx, app, order, profile, pricing.Total, domain.TransactionKindPayment, fmt.Sprintf("Pago por pedido %s", order.ID), token, securityCode)
+	return err
+}
+
+// ChargeOrderAmount charges an extra amount for an already paid order with the
+// customer's saved payment method and records it as a separate transaction.
+func ChargeOrderAmount(ctx context.Context, app *appcontext.Context, order *domain.Order, amount domain.Money, description string) (*domain.Transaction, error) {
+	profile, err := resolveOrderProfile(ctx, app, order)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("charge amount is zero or negative")
+	}
+	return chargeSavedMethod(ctx, app, order, profile, amount, domain.TransactionKindPayment, description, "", "")
+}
+
+// ChargeTip charges a tip for the order's driver with the customer's saved payment
+// method, as its own transaction apart from the order payment.
+func ChargeTip(ctx context.Context, app *appcontext.Context, order *domain.Order, amount domain.Money, token string, securityCode string) (*domain.Transaction, error) {
+	profile, err := resolveOrderProfile(ctx, app, order)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("tip amount is zero or negative")
+	}
+	return chargeSavedMethod(ctx, app, order, profile, amount, domain.TransactionKindTip, fmt.Sprintf("Propina por pedido %s", order.ID), token, securityCode)
+}
+
+// paymentFailedError wraps a failed charge, telling a currency mismatch apart so the
+// client sees why the order can't be paid
+func paymentFailedError(err error) apperrors.ApplicationError {
+	if errors.Is(err, domain.ErrCurrencyMismatch) {
+		return apperrors.NewApplicationError(mappings.OrderCurrencyMismatchError, err)
+	}
+	return apperrors.NewApplicationError(mappings.OrderPaymentFailedError, err)
+}
+
+// resolveOrderProfile loads the profile of an order, assigning the owner's profile
+// first when it was created after the order was claimed.
+func resolveOrderProfile(ctx context.Context, app *appcontext.Context, order *domain.Order) (*domain.Profile, error) {
 	if order.ProfileID == nil {
 		// Profile may have been created after claiming — try to find and assign it now
 		if order.UserID != nil {
@@ -26,37 +83,32 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 			}
 		}
 		if order.ProfileID == nil {
-			return fmt.Errorf("order has no profile_id")
+			return nil, fmt.Errorf("order has no profile_id")
 		}
 	}
 
 	profile, profileErr := app.Repositories.Profile.GetByID(ctx, *order.ProfileID)
 	if profileErr != nil {
-		return fmt.Errorf("failed to get profile: %w", profileErr)
+		return nil, fmt.Errorf("failed to get profile: %w", profileErr)
 	}
+	return profile, nil
+}
 
+// chargeSavedMethod charges amount to the profile owner's saved payment method and
+// records the resulting transaction of the given kind.
+func chargeSavedMethod(ctx context.Context, app *appcontext.Context, order *domain.Order, profile *domain.Profile, amount domain.Money, kind string, description string, token string, securityCode string) (*domain.Transaction, error) {
 	// Payment methods are stored under profile.UserID (auth username).
 	// Use it directly to avoid the GetUserIDByUsername UUID mismatch.
 	paymentUserID := profile.UserID
 
 	hasPaymentMethod, paymentErr := app.Integrations.Payments.HasPaymentMethod(paymentUserID)
 	if paymentErr != nil {
-		return fmt.Errorf("failed to check payment method: %w", paymentErr)
+		return nil, fmt.Errorf("failed to check payment method: %w", paymentErr)
 	}
 	if !hasPaymentMethod {
-		return fmt.Errorf("user has no payment method configured")
+		return nil, fmt.Errorf("user has no payment method configured")
 	}
 
-	orderTotal, calcErr := calculateOrderTotal(ctx, app, order, profile, calculateDeliveryFeeUse)
-	if calcErr != nil {
-		return fmt.Errorf("failed to calculate order total: %w", calcErr)
-	}
-	if orderTotal <= 0 {
-		return fmt.Errorf("order total is zero or negative")
-	}
-	// Round to 2 decimal places to avoid floating point issues with MercadoPago
-	orderTotal = math.Round(orderTotal*100) / 100
-
 	var userEmail string
 	if token != "" {
 		var emailErr error
@@ -70,7 +122,7 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 		log.Printf("Warning: No token provided, using placeholder email for user %s", profile.UserID)
 	}
 	if userEmail == "" {
-		return fmt.Errorf("user email not found")
+		return nil, fmt.Errorf("user email not found")
 	}
 
 	var collectorID string
@@ -82,81 +134,210 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 	var paymentResponse *payments.ProcessPaymentResponse
 	paymentResponse, paymentErr = app.Integrations.Payments.ProcessPaymentWithSavedMethod(
 		paymentUserID,
-		orderTotal,
-		fmt.Sprintf("Pago por pedido %s", order.ID),
+		amount,
+		orderCurrency(order),
+		description,
 		order.ID,
 		userEmail,
 		collectorID,
 		securityCode,
 	)
 	if paymentErr != nil {
-		return fmt.Errorf("failed to process payment: %w", paymentErr)
+		return nil, fmt.Errorf("failed to process payment: %w", paymentErr)
 	}
 
 	log.Printf("Payment processed for order %s: Payment ID %d, Gateway ID %s, Status %s",
 		order.ID, paymentResponse.PaymentID, paymentResponse.GatewayPaymentID, paymentResponse.Status)
 
 	if paymentResponse.Status == "rejected" {
-		return fmt.Errorf("payment rejected by gateway (status: %s)", paymentResponse.Status)
+		return nil, fmt.Errorf("payment rejected by gateway (status: %s)", paymentResponse.Status)
 	}
 
-	description := fmt.Sprintf("Pago por pedido %s", order.ID)
 	transaction := &domain.Transaction{
 		OrderID:          order.ID,
 		UserID:           paymentUserID,
 		ProfileID:        order.ProfileID,
-		Amount:           orderTotal,
-		Currency:         "ARS",
+		Amount:           amount,
+		Currency:         orderCurrency(order),
 		Status:           paymentResponse.Status,
+		Kind:             kind,
 		PaymentID:        &paymentResponse.PaymentID,
 		GatewayPaymentID: &paymentResponse.GatewayPaymentID,
 		CollectorID:      &collectorID,
 		Description:      &description,
 	}
+	if kind == domain.TransactionKindPayment {
+		setTransactionTax(order, transaction)
+	}
 
-	_, transErr := app.Repositories.Transaction.Create(ctx, transaction)
-	if transErr != nil {
-		log.Printf("Warning: Failed to create transaction record for order %s: %v", order.ID, transErr)
+	// The customer is already charged: an unrecorded charge would be missing from
+	// PaidAmount and from refunds, so the caller has to see it
+	if _, transErr := app.Repositories.Transaction.Create(ctx, transaction); transErr != nil {
+		return nil, fmt.Errorf("payment %s of %s went through but was not recorded: %w",
+			paymentResponse.GatewayPaymentID, amount, transErr)
 	}
 
-	return nil
+	return transaction, nil
 }
 
-// calculateOrderTotal calculates the total amount for an order (items + delivery fee).
-func calculateOrderTotal(ctx context.Context, app *appcontext.Context, order *domain.Order, profile *domain.Profile, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (float64, error) {
-	if order.Data == nil || len(order.Data.Items) == 0 {
+// RefundOrderPayment refunds the approved payments of an order through the payments
+// service and records one refund transaction per refunded payment, newest payment
+// first. An amount of zero refunds everything not yet refunded. It returns the
+// amount refunded, which is zero when the order has nothing left to refund.
+// Payments in a currency other than the order's fail with domain.ErrCurrencyMismatch.
+// Tips are left alone.
+func RefundOrderPayment(ctx context.Context, app *appcontext.Context, order *domain.Order, amount domain.Money, reason string) (domain.Money, error) {
+	return refundTransactions(ctx, app, order, domain.TransactionKindPayment, amount, reason)
+}
+
+// RefundOrderTips refunds every tip of an order not yet refunded, e.g. when an
+// order tipped at checkout is cancelled. It returns the amount refunded.
+func RefundOrderTips(ctx context.Context, app *appcontext.Context, order *domain.Order, reason string) (domain.Money, error) {
+	return refundTransactions(ctx, app, order, domain.TransactionKindTip, 0, reason)
+}
+
+// refundTransactions refunds the approved transactions of one kind, see RefundOrderPayment
+func refundTransactions(ctx context.Context, app *appcontext.Context, order *domain.Order, kind string, amount domain.Money, reason string) (domain.Money, error) {
+	transactions, listErr := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if listErr != nil {
+		return 0, fmt.Errorf("failed to list transactions: %w", listErr)
+	}
+
+	// Refunds reference the gateway ID of the payment they return
+	refundedByPayment := make(map[string]domain.Money)
+	var payments []*domain.Transaction
+	for _, t := range transactions {
+		if t.GatewayPaymentID == nil || *t.GatewayPaymentID == "" || t.Kind != kind {
+			continue
+		}
+		if err := checkTransactionCurrency(order, t); err != nil {
+			return 0, err
+		}
+		switch t.Status {
+		case domain.TransactionStatusApproved:
+			payments = append(payments, t)
+		case domain.TransactionStatusRefunded:
+			refundedByPayment[*t.GatewayPaymentID] += t.Amount
+		}
+	}
+
+	var refundable domain.Money
+	for _, payment := range payments {
+		refundable += payment.Amount - refundedByPayment[*payment.GatewayPaymentID]
+	}
+	if refundable <= 0 {
 		return 0, nil
 	}
+	if amount <= 0 || amount > refundable {
+		amount = refundable
+	}
+	remaining := amount
 
-	var itemsTotal float64
-	for _, item := range order.Data.Items {
-		itemsTotal += item.Price * float64(item.Quantity)
+	description := fmt.Sprintf("Reembolso por pedido %s", order.ID)
+	if reason != "" {
+		description = fmt.Sprintf("%s: %s", description, reason)
 	}
 
-	var deliveryFee float64
-	if profile.LocationID != nil {
-		location, err := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
-		if err == nil && location != nil {
-			deliveryFeeInput := settingsUsecase.CalculateDeliveryFeeInput{
-				UserLatitude:  location.Latitude,
-				UserLongitude: location.Longitude,
-				Items: make([]struct {
-					Quantity int  `json:"quantity"`
-					Weight   *int `json:"weight,omitempty"`
-				}, len(order.Data.Items)),
-			}
+	var refundedTotal domain.Money
+	for i := len(payments) - 1; i >= 0 && remaining > 0; i-- {
+		payment := payments[i]
+		available := payment.Amount - refundedByPayment[*payment.GatewayPaymentID]
+		if available <= 0 {
+			continue
+		}
+		refundAmount := domain.MinMoney(available, remaining)
 
-			for i, item := range order.Data.Items {
-				deliveryFeeInput.Items[i].Quantity = item.Quantity
-				deliveryFeeInput.Items[i].Weight = item.Weight
-			}
+		refundResponse, refundErr := app.Integrations.Payments.Refund(*payment.GatewayPaymentID, refundAmount, order.ID)
+		if refundErr != nil {
+			return refundedTotal, fmt.Errorf("failed to refund payment: %w", refundErr)
+		}
 
-			deliveryFeeOutput, err := calculateDeliveryFeeUse.Execute(ctx, deliveryFeeInput)
-			if err == nil && deliveryFeeOutput != nil {
-				deliveryFee = deliveryFeeOutput.TotalPrice
-			}
+		log.Printf("Refund processed for order %s: Refund ID %d, Gateway Refund ID %s, Status %s, Amount %s",
+			order.ID, refundResponse.RefundID, refundResponse.GatewayRefundID, refundResponse.Status, refundAmount)
+
+		refund := &domain.Transaction{
+			OrderID:          order.ID,
+			UserID:           payment.UserID,
+			ProfileID:        payment.ProfileID,
+			Amount:           refundAmount,
+			Currency:         payment.Currency,
+			Status:           domain.TransactionStatusRefunded,
+			Kind:             kind,
+			PaymentID:        payment.PaymentID,
+			GatewayPaymentID: payment.GatewayPaymentID,
+			CollectorID:      payment.CollectorID,
+			Description:      &description,
+		}
+		if kind == domain.TransactionKindPayment {
+			setTransactionTax(order, refund)
+		}
+
+		// The gateway already returned the money: without its record PaidAmount would
+		// still count it and a later refund could return it twice, so this must fail loudly
+		refundedTotal += refundAmount
+		if _, transErr := app.Repositories.Transaction.Create(ctx, refund); transErr != nil {
+			return refundedTotal, fmt.Errorf("refund %s of payment %s went through but was not recorded: %w",
+				refundAmount, *payment.GatewayPaymentID, transErr)
 		}
+
+		remaining -= refundAmount
 	}
 
-	return itemsTotal + deliveryFee, nil
+	return refundedTotal, nil
+}
+
+// PaidAmount returns how much of an order is currently paid: approved payments
+// minus refunds, not counting tips. Transactions in another currency can't be netted and fail with
+// domain.ErrCurrencyMismatch.
+func PaidAmount(ctx context.Context, app *appcontext.Context, order *domain.Order) (domain.Money, error) {
+	transactions, listErr := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if listErr != nil {
+		return 0, fmt.Errorf("failed to list transactions: %w", listErr)
+	}
+
+	var paid domain.Money
+	for _, t := range transactions {
+		if t.Kind == domain.TransactionKindTip {
+			continue
+		}
+		if err := checkTransactionCurrency(order, t); err != nil {
+			return 0, err
+		}
+		switch t.Status {
+		case domain.TransactionStatusApproved:
+			paid += t.Amount
+		case domain.TransactionStatusRefunded:
+			paid -= t.Amount
+		}
+	}
+	return paid, nil
+}
+
+// orderCurrency returns the currency an order is charged in
+func orderCurrency(order *domain.Order) string {
+	if order.Currency == "" {
+		return domain.DefaultCurrency
+	}
+	return order.Currency
+}
+
+// setTransactionTax splits a transaction's amount into net and IVA like the order's
+// priced snapshot. Orders priced before taxes were recorded leave it unsplit.
+func setTransactionTax(order *domain.Order, transaction *domain.Transaction) {
+	if order.Pricing == nil || order.Pricing.Tax == nil {
+		return
+	}
+	net, tax := order.Pricing.Tax.Split(transaction.Amount)
+	transaction.NetAmount = &net
+	transaction.TaxAmount = &tax
+}
+
+// checkTransactionCurrency rejects a transaction recorded in a currency other than
+// the order's, since its amount can't be added to the order's totals
+func checkTransactionCurrency(order *domain.Order, transaction *domain.Transaction) error {
+	if transaction.Currency != orderCurrency(order) {
+		return fmt.Errorf("transaction %s in %s for an order in %s: %w",
+			transaction.ID, transaction.Currency, orderCurrency(order), domain.ErrCurrencyMismatch)
+	}
+	return nil
 }
@@ -3,7 +3,6 @@ package order
 import (
 	"fmt"
 	"log"
-	"math"
 	"strconv"
 	"strings"
 	"unicode"
@@ -62,8 +61,8 @@ func importName(data map[string]any) string {
 	return val
 }
 
-// importPrice extracts the unit price from an import record and rounds to nearest integer.
-func importPrice(data map[string]any) (float64, bool) {
+// importPrice extracts the unit price from an import record, exact to the cent.
+func importPrice(data map[string]any) (domain.Money, bool) {
 	val, ok := findColValue(data, []string{"precio unitario", "precio", "price", "costo", "valor", "importe"})
 	if !ok {
 		log.Printf("[PriceValidator] price column not found in data keys: %v", mapKeys(data))
@@ -71,13 +70,41 @@ func importPrice(data map[string]any) (float64, bool) {
 	}
 	log.Printf("[PriceValidator] price raw value=%q", val)
 	cleaned := strings.NewReplacer("$", "", " ", "", "\u00a0", "").Replace(val)
+	if strings.Contains(cleaned, ",") && strings.Contains(cleaned, ".") {
+		// "1.234,56": dots group thousands, the comma is the decimal separator
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+	}
 	cleaned = strings.ReplaceAll(cleaned, ",", ".")
-	price, err := strconv.ParseFloat(cleaned, 64)
+	price, err := domain.ParseMoney(cleaned)
 	if err != nil {
 		log.Printf("[PriceValidator] price parse error: %v (cleaned=%q)", err, cleaned)
 		return 0, false
 	}
-	return math.Round(price), true
+	return price, true
+}
+
+// importCategory extracts the catalog category of a product, which selects its IVA rate
+func importCategory(data map[string]any) string {
+	val, ok := findColValue(data, []string{"categoria", "category", "rubro"})
+	if !ok {
+		return ""
+	}
+	return val
+}
+
+// importTaxRate extracts an explicit IVA rate in percent, e.g. "10,5" or "21%"
+func importTaxRate(data map[string]any) (float64, bool) {
+	val, ok := findColValue(data, []string{"iva", "alicuota"})
+	if !ok || val == "" {
+		return 0, false
+	}
+	cleaned := strings.NewReplacer("%", "", " ", "", ",", ".").Replace(val)
+	rate, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		log.Printf("[PriceValidator] ignoring IVA rate %q", val)
+		return 0, false
+	}
+	return rate, true
 }
 
 func mapKeys(m map[string]any) []string {
@@ -120,26 +147,49 @@ func findImportByName(records []*domain.ImportRecord, name string) *domain.Impor
 	return nil
 }
 
+// matchImportRecord finds the import record of an item by code, or by name as a
+// fallback. It returns nil when the product is no longer in the catalog.
+func matchImportRecord(records []*domain.ImportRecord, item domain.OrderItem) *domain.ImportRecord {
+	var matched *domain.ImportRecord
+	if item.Code != "" {
+		matched = findImportByCode(records, item.Code)
+		if matched == nil {
+			log.Printf("[PriceValidator] no import match for code=%q, trying by name", item.Code)
+		}
+	}
+	if matched == nil && item.Name != "" {
+		matched = findImportByName(records, item.Name)
+	}
+	return matched
+}
+
+// CatalogItem prices an item from the import records, matched by code or name, and
+// fills in its catalog name, category and IVA rate. It reports false when the
+// product is not in the catalog or has no usable price there.
+func CatalogItem(records []*domain.ImportRecord, item domain.OrderItem) (domain.OrderItem, bool) {
+	matched := matchImportRecord(records, item)
+	if matched == nil {
+		return item, false
+	}
+	if _, ok := importPrice(matched.Data); !ok {
+		return item, false
+	}
+	corrected, _ := correctItemPrices([]domain.OrderItem{item}, []*domain.ImportRecord{matched})
+	return corrected[0], true
+}
+
 // correctItemPrices looks up each item by code (or name as fallback) against the
-// import records and corrects Name and Price to match. Returns the (possibly
+// import records and corrects Name and Price to match. The catalog category and IVA
+// rate are copied onto the item for taxes and are not counted as changes. Returns the (possibly
 // corrected) slice and a boolean indicating whether any changes were made.
 func correctItemPrices(items []domain.OrderItem, records []*domain.ImportRecord) ([]domain.OrderItem, bool) {
 	hasChanges := false
 	corrected := make([]domain.OrderItem, len(items))
 	for i, item := range items {
 		corrected[i] = item
-		log.Printf("[PriceValidator] item[%d] code=%q name=%q price=%.2f", i, item.Code, item.Name, item.Price)
+		log.Printf("[PriceValidator] item[%d] code=%q name=%q price=%s", i, item.Code, item.Name, item.Price)
 
-		var matched *domain.ImportRecord
-		if item.Code != "" {
-			matched = findImportByCode(records, item.Code)
-			if matched == nil {
-				log.Printf("[PriceValidator] item[%d] no import match for code=%q, trying by name", i, item.Code)
-			}
-		}
-		if matched == nil && item.Name != "" {
-			matched = findImportByName(records, item.Name)
-		}
+		matched := matchImportRecord(records, item)
 		if matched == nil {
 			log.Printf("[PriceValidator] item[%d] no match found, skipping", i)
 			continue
@@ -152,10 +202,16 @@ func correctItemPrices(items []domain.OrderItem, records []*domain.ImportRecord)
 			hasChanges = true
 		}
 		if price, ok := importPrice(matched.Data); ok && price != item.Price {
-			log.Printf("[PriceValidator] item[%d] correcting price: %.2f → %.2f", i, item.Price, price)
+			log.Printf("[PriceValidator] item[%d] correcting price: %s → %s", i, item.Price, price)
 			corrected[i].Price = price
 			hasChanges = true
 		}
+		if category := importCategory(matched.Data); category != "" {
+			corrected[i].Category = category
+		}
+		if rate, ok := importTaxRate(matched.Data); ok {
+			corrected[i].TaxRate = &rate
+		}
 	}
 	log.Printf("[PriceValidator] hasChanges=%v", hasChanges)
 	return corrected, hasChanges
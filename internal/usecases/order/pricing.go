@@ -0,0 +1,61 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	pricingUsecase "yego/internal/usecases/pricing"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// SnapshotPricing prices the order from the live settings and stores the result on it.
+// Called when an order is claimed or confirmed, and again when its items change.
+func SnapshotPricing(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (*domain.OrderPricing, error) {
+	profile, err := resolveOrderProfile(ctx, app, order)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := pricingUsecase.PriceOrder(ctx, app, order, profile, calculateDeliveryFeeUse)
+	if err != nil {
+		return nil, err
+	}
+
+	if setErr := app.Repositories.Order.SetPricing(ctx, order.ID, pricing); setErr != nil {
+		return nil, fmt.Errorf("failed to store order pricing: %w", setErr)
+	}
+	order.Pricing = pricing
+	return pricing, nil
+}
+
+// EnsurePricing returns the stored snapshot of the order, taking it first for orders
+// confirmed before snapshots existed or never claimed. A snapshot priced in another
+// currency than the order's is retaken.
+func EnsurePricing(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (*domain.OrderPricing, error) {
+	if order.Pricing != nil && (order.Pricing.Currency == "" || order.Pricing.Currency == orderCurrency(order)) {
+		return order.Pricing, nil
+	}
+	return SnapshotPricing(ctx, app, order, calculateDeliveryFeeUse)
+}
+
+// RefreshPricing retakes the snapshot of an order whose items changed after it was
+// priced. Orders not priced yet are left alone, they are priced on confirmation.
+func RefreshPricing(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) {
+	if order.Pricing == nil {
+		return
+	}
+	if _, err := SnapshotPricing(ctx, app, order, calculateDeliveryFeeUse); err != nil {
+		log.Printf("RefreshPricing: failed to reprice order %s: %v", order.ID, err)
+	}
+}
+
+// EnsureConfirmedPricing takes the snapshot of an order that was just confirmed
+// without being claimed or paid, logging instead of failing the status change
+func EnsureConfirmedPricing(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) {
+	if _, err := EnsurePricing(ctx, app, order, calculateDeliveryFeeUse); err != nil {
+		log.Printf("Order %s confirmed without pricing: %v", order.ID, err)
+	}
+}
@@ -0,0 +1,48 @@
+package order
+
+import (
+	"context"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// RemoveCouponUsecase defines the interface for taking a coupon off an order
+type RemoveCouponUsecase interface {
+	Execute(ctx context.Context, input CouponInput) (*CouponOutput, apperrors.ApplicationError)
+}
+
+type removeCouponUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewRemoveCouponUsecase creates a new instance of RemoveCouponUsecase
+func NewRemoveCouponUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) RemoveCouponUsecase {
+	return &removeCouponUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute removes a coupon from the user's unpaid order and reprices it
+func (u *removeCouponUsecase) Execute(ctx context.Context, input CouponInput) (*CouponOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	order, appErr := loadCouponOrder(ctx, app, input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	promotion, appErr := app.Repositories.Promotion.GetByCode(ctx, domain.NormalizeCouponCode(input.Code))
+	if appErr != nil {
+		return nil, appErr
+	}
+	if removeErr := app.Repositories.Promotion.RemoveRedemption(ctx, promotion.ID, order.ID); removeErr != nil {
+		return nil, removeErr
+	}
+
+	return repriceCouponOrder(ctx, app, order, u.calculateDeliveryFeeUse), nil
+}
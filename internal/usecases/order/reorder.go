@@ -0,0 +1,151 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// ReorderInput represents the input for ordering again from a past order
+type ReorderInput struct {
+	OrderID string // the past order to copy
+	UserID  string
+}
+
+// ReorderOutput is the new order with what changed since the past one
+type ReorderOutput struct {
+	Data          OrderOutputData      `json:"data"` // its pricing holds the new totals
+	SourceOrderID string               `json:"source_order_id"`
+	MissingItems  []OrderItemOutput    `json:"missing_items"`
+	PriceChanges  []ReorderPriceChange `json:"price_changes"`
+}
+
+// ReorderPriceChange is an item whose catalog price differs from the past order
+type ReorderPriceChange struct {
+	Code     string       `json:"code,omitempty"`
+	Name     string       `json:"name"`
+	OldPrice domain.Money `json:"old_price"`
+	NewPrice domain.Money `json:"new_price"`
+	Quantity int          `json:"quantity"`
+}
+
+// ReorderUsecase defines the interface for ordering again from a past order
+type ReorderUsecase interface {
+	Execute(ctx context.Context, input ReorderInput) (*ReorderOutput, apperrors.ApplicationError)
+}
+
+type reorderUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+}
+
+// NewReorderUsecase creates a new instance of ReorderUsecase
+func NewReorderUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) ReorderUsecase {
+	return &reorderUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+	}
+}
+
+// Execute copies the items of one of the user's orders into a new CREATED order,
+// priced from the current catalog. Products no longer in the catalog are left out
+// and reported. The new order is paid like any other, with /pay or a payment link.
+func (u *reorderUsecase) Execute(ctx context.Context, input ReorderInput) (*ReorderOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	orders, err := app.Repositories.Order.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var source *domain.Order
+	for _, o := range orders {
+		if o.ID == input.OrderID {
+			source = o
+			break
+		}
+	}
+	if source == nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, errors.New("order not found among the user's orders"))
+	}
+	if source.Data == nil || len(source.Data.Items) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderReorderEmptyError, errors.New("order has no items"))
+	}
+
+	output := &ReorderOutput{
+		SourceOrderID: source.ID,
+		MissingItems:  []OrderItemOutput{},
+		PriceChanges:  []ReorderPriceChange{},
+	}
+
+	items := make([]domain.OrderItem, 0, len(source.Data.Items))
+	importRecords, importErr := app.Repositories.ImportRecord.GetAll(ctx)
+	if importErr != nil || len(importRecords) == 0 {
+		// Without a catalog there is nothing to check against; keep the past prices
+		log.Printf("Reorder: no import records to validate order %s against: %v", source.ID, importErr)
+		items = append(items, source.Data.Items...)
+	} else {
+		for _, item := range source.Data.Items {
+			if item.Quantity <= 0 {
+				continue
+			}
+			if matchImportRecord(importRecords, item) == nil {
+				output.MissingItems = append(output.MissingItems, toOrderItemOutput(item))
+				continue
+			}
+			items = append(items, item)
+		}
+		corrected, _ := correctItemPrices(items, importRecords)
+		for i, item := range corrected {
+			if item.Price != items[i].Price {
+				output.PriceChanges = append(output.PriceChanges, ReorderPriceChange{
+					Code:     item.Code,
+					Name:     item.Name,
+					OldPrice: items[i].Price,
+					NewPrice: item.Price,
+					Quantity: item.Quantity,
+				})
+			}
+		}
+		items = corrected
+	}
+	if len(items) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderReorderEmptyError, errors.New("no products left in the catalog"))
+	}
+
+	currency, currencyErr := resolveOrderCurrency(ctx, app, source.Currency)
+	if currencyErr != nil {
+		return nil, currencyErr
+	}
+
+	userID := input.UserID
+	created, err := app.Repositories.Order.Create(ctx, &domain.Order{
+		ProfileID: source.ProfileID,
+		UserID:    &userID,
+		Status:    domain.StatusCreated,
+		Data:      &domain.OrderData{Items: items},
+		Currency:  currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The snapshot is what /pay charges; without it the order is priced when paid
+	if _, pricingErr := SnapshotPricing(ctx, app, created, u.calculateDeliveryFeeUse); pricingErr != nil {
+		log.Printf("Reorder: failed to price order %s: %v", created.ID, pricingErr)
+	}
+
+	output.Data = toOrderOutputData(created, false)
+	return output, nil
+}
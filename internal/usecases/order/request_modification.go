@@ -0,0 +1,238 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// Item change actions accepted in a modification request
+const (
+	ItemChangeAdd            = "add"
+	ItemChangeRemove         = "remove"
+	ItemChangeUpdateQuantity = "update_quantity"
+)
+
+// modifiableStatuses are the statuses from which a customer may propose changes;
+// once preparation starts the items are fixed.
+var modifiableStatuses = []domain.OrderStatus{
+	domain.StatusCreated,
+	domain.StatusConfirmed,
+}
+
+// ItemChangeInput describes a single change to the order items. Items are matched
+// by code, or by name when no code is given.
+type ItemChangeInput struct {
+	Action   string `json:"action" binding:"required"`
+	Code     string `json:"code,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+	Weight   *int   `json:"weight,omitempty"`
+}
+
+// RequestModificationInput represents the input for proposing order changes
+type RequestModificationInput struct {
+	OrderID string
+	UserID  string
+	Changes []ItemChangeInput
+}
+
+// RequestModificationUsecase defines the interface for proposing changes to an order
+type RequestModificationUsecase interface {
+	Execute(ctx context.Context, input RequestModificationInput) (*ModificationRequestOutput, apperrors.ApplicationError)
+}
+
+type requestModificationUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewRequestModificationUsecase creates a new instance of RequestModificationUsecase
+func NewRequestModificationUsecase(contextFactory appcontext.Factory) RequestModificationUsecase {
+	return &requestModificationUsecase{contextFactory: contextFactory}
+}
+
+// Execute stores the proposed items and moves the order to MODIFICATION_REQUESTED
+// until a manager reviews it
+func (u *requestModificationUsecase) Execute(ctx context.Context, input RequestModificationInput) (*ModificationRequestOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+	if len(input.Changes) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError, errors.New("no changes given"))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	if order.Status == domain.StatusModificationRequested {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestAlreadyPendingError, nil)
+	}
+	if !isModifiable(order.Status) {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestNotAllowedError,
+			fmt.Errorf("order %s is %s", order.ID, order.Status))
+	}
+	if transitionErr := ValidateStatusTransition(ctx, app, order, domain.StatusModificationRequested); transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	currentItems := make([]domain.OrderItem, 0)
+	if order.Data != nil {
+		currentItems = order.Data.Items
+	}
+
+	importRecords, importErr := app.Repositories.ImportRecord.GetAll(ctx)
+	if importErr != nil {
+		return nil, importErr
+	}
+
+	proposedItems, applyErr := applyItemChanges(currentItems, input.Changes, importRecords)
+	if applyErr != nil {
+		return nil, applyErr
+	}
+	if len(proposedItems) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestEmptyOrderError, nil)
+	}
+	if len(domain.DiffOrderItems(currentItems, proposedItems)) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.ModificationRequestNoChangesError, nil)
+	}
+
+	// A request left pending while the order was moved on by hand no longer applies
+	if stale, staleErr := app.Repositories.ModificationRequest.GetPendingByOrderID(ctx, order.ID); staleErr == nil {
+		note := "superseded by a newer request"
+		stale.Status = domain.ModificationRequestRejected
+		stale.ReviewNote = &note
+		if _, resolveErr := app.Repositories.ModificationRequest.Resolve(ctx, stale); resolveErr != nil {
+			return nil, resolveErr
+		}
+	}
+
+	request, err := app.Repositories.ModificationRequest.Create(ctx, &domain.ModificationRequest{
+		OrderID:        order.ID,
+		UserID:         input.UserID,
+		PreviousStatus: order.Status,
+		OriginalItems:  currentItems,
+		Items:          proposedItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = domain.StatusModificationRequested
+	if _, err := app.Repositories.Order.Update(ctx, order, ActorUserID(input.UserID)); err != nil {
+		// Don't leave a pending request behind for an order that never changed status
+		note := "order changed before the request was registered"
+		request.Status = domain.ModificationRequestRejected
+		request.ReviewNote = &note
+		if _, resolveErr := app.Repositories.ModificationRequest.Resolve(ctx, request); resolveErr != nil {
+			log.Printf("RequestModification: failed to discard request %s: %v", request.ID, resolveErr)
+		}
+		return nil, err
+	}
+
+	output := ToModificationRequestOutput(request)
+	return &output, nil
+}
+
+// applyItemChanges returns a copy of items with the changes applied. Added products
+// must exist in the imported catalog, whose name and price they take.
+func applyItemChanges(items []domain.OrderItem, changes []ItemChangeInput, records []*domain.ImportRecord) ([]domain.OrderItem, apperrors.ApplicationError) {
+	result := make([]domain.OrderItem, len(items))
+	copy(result, items)
+
+	indexOf := func(key string) int {
+		for i, item := range result {
+			if item.Key() == key {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, change := range changes {
+		target := domain.OrderItem{Code: change.Code, Name: change.Name, Quantity: change.Quantity, Weight: change.Weight}
+		if target.Code == "" && target.Name == "" {
+			return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError, errors.New("change requires code or name"))
+		}
+
+		switch change.Action {
+		case ItemChangeAdd:
+			if change.Quantity <= 0 {
+				return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError,
+					fmt.Errorf("quantity for %s must be positive", target.Key()))
+			}
+			var matched *domain.ImportRecord
+			if target.Code != "" {
+				matched = findImportByCode(records, target.Code)
+			}
+			if matched == nil && target.Name != "" {
+				matched = findImportByName(records, target.Name)
+			}
+			if matched == nil {
+				return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError,
+					fmt.Errorf("product %s not found in catalog", target.Key()))
+			}
+			corrected, _ := correctItemPrices([]domain.OrderItem{target}, []*domain.ImportRecord{matched})
+			added := corrected[0]
+			if i := indexOf(added.Key()); i >= 0 {
+				result[i].Quantity += added.Quantity
+				result[i].Price = added.Price
+			} else {
+				result = append(result, added)
+			}
+
+		case ItemChangeRemove:
+			i := indexOf(target.Key())
+			if i < 0 {
+				return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError,
+					fmt.Errorf("item %s is not in the order", target.Key()))
+			}
+			result = append(result[:i], result[i+1:]...)
+
+		case ItemChangeUpdateQuantity:
+			i := indexOf(target.Key())
+			if i < 0 {
+				return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError,
+					fmt.Errorf("item %s is not in the order", target.Key()))
+			}
+			if change.Quantity < 0 {
+				return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError,
+					fmt.Errorf("quantity for %s cannot be negative", target.Key()))
+			}
+			if change.Quantity == 0 {
+				result = append(result[:i], result[i+1:]...)
+			} else {
+				result[i].Quantity = change.Quantity
+			}
+
+		default:
+			return nil, apperrors.NewApplicationError(mappings.ModificationRequestInvalidError,
+				fmt.Errorf("unknown action %q", change.Action))
+		}
+	}
+
+	return result, nil
+}
+
+func isModifiable(status domain.OrderStatus) bool {
+	for _, s := range modifiableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
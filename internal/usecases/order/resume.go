@@ -0,0 +1,87 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// ResumeInput represents the input for resuming a paused order
+type ResumeInput struct {
+	OrderID string
+	UserID  string
+}
+
+// ResumeUsecase defines the interface for resuming paused orders
+type ResumeUsecase interface {
+	Execute(ctx context.Context, input ResumeInput) (*PauseOutput, apperrors.ApplicationError)
+}
+
+type resumeUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewResumeUsecase creates a new instance of ResumeUsecase
+func NewResumeUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) ResumeUsecase {
+	return &resumeUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute restores a paused order to the status it had before being paused
+func (u *resumeUsecase) Execute(ctx context.Context, input ResumeInput) (*PauseOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	resumed, err := resumeOrder(ctx, app, order, ActorUserID(input.UserID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	NotifyOrderUpdated(u.notificationSvc, resumed, domain.StatusPaused)
+
+	return &PauseOutput{
+		Data: toOrderOutputData(resumed, false),
+	}, nil
+}
+
+// resumeOrder moves a paused order back to its pre-pause status and clears the pause data
+func resumeOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, actorUserID *string, message *string) (*domain.Order, apperrors.ApplicationError) {
+	if order.Status != domain.StatusPaused {
+		return nil, apperrors.NewApplicationError(mappings.OrderNotPausedError,
+			fmt.Errorf("order %s is %s", order.ID, order.Status))
+	}
+
+	next := order.ResumeStatus()
+	if transitionErr := ValidateStatusTransition(ctx, app, order, next); transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	order.Status = next
+	order.StatusMessage = message
+	order.ClearPause()
+
+	resumed, err := app.Repositories.Order.Update(ctx, order, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	IssueDeliveryCode(ctx, app, resumed)
+	return resumed, nil
+}
@@ -0,0 +1,59 @@
+package order
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/usecases/notification"
+)
+
+// autoResumeMessage is recorded in the status history of orders resumed by the worker
+const autoResumeMessage = "Reanudado automáticamente"
+
+// ResumeDueUsecase defines the interface for resuming paused orders whose resume time has passed
+type ResumeDueUsecase interface {
+	Execute(ctx context.Context) (int, apperrors.ApplicationError)
+}
+
+type resumeDueUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewResumeDueUsecase creates a new instance of ResumeDueUsecase
+func NewResumeDueUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) ResumeDueUsecase {
+	return &resumeDueUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute resumes every paused order whose resume_at has passed and returns how many
+// were resumed. Orders changed concurrently are skipped and retried on the next run
+// if they are still due.
+func (u *resumeDueUsecase) Execute(ctx context.Context) (int, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	due, err := app.Repositories.Order.ListDueForResume(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	message := autoResumeMessage
+	resumedCount := 0
+	for _, order := range due {
+		resumed, resumeErr := resumeOrder(ctx, app, order, nil, &message)
+		if resumeErr != nil {
+			log.Printf("ResumeDue: failed to resume order %s: %v", order.ID, resumeErr)
+			continue
+		}
+		NotifyOrderUpdated(u.notificationSvc, resumed, domain.StatusPaused)
+		resumedCount++
+	}
+
+	return resumedCount, nil
+}
@@ -0,0 +1,77 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// AllowedNextStatuses returns the statuses an order may move to next. An order under
+// a pending modification request only goes back to the status it had when the
+// request was made, or is cancelled.
+func AllowedNextStatuses(ctx context.Context, app *appcontext.Context, order *domain.Order) ([]domain.OrderStatus, apperrors.ApplicationError) {
+	if order.Status != domain.StatusModificationRequested {
+		return order.AllowedNextStatuses(), nil
+	}
+
+	request, err := app.Repositories.ModificationRequest.GetPendingByOrderID(ctx, order.ID)
+	if err != nil {
+		// Without its request there is nothing to go back to, fall back to the workflow
+		if err.Code() == mappings.ModificationRequestNotFoundError.Code {
+			return order.AllowedNextStatuses(), nil
+		}
+		return nil, err
+	}
+	return request.AllowedOrderStatuses(), nil
+}
+
+// ValidateStatusTransition checks that an order may move to the given status.
+// Keeping the current status is always allowed so other fields can be edited.
+func ValidateStatusTransition(ctx context.Context, app *appcontext.Context, order *domain.Order, next domain.OrderStatus) apperrors.ApplicationError {
+	if order.Status == next {
+		return nil
+	}
+
+	allowed, err := AllowedNextStatuses(ctx, app, order)
+	if err != nil {
+		return err
+	}
+	for _, s := range allowed {
+		if s == next {
+			return nil
+		}
+	}
+
+	allowedNames := make([]string, len(allowed))
+	for i, s := range allowed {
+		allowedNames[i] = string(s)
+	}
+
+	return apperrors.NewApplicationError(
+		mappings.NewOrderInvalidTransitionError(string(order.Status), string(next), allowedNames),
+		fmt.Errorf("order %s: transition %s -> %s not allowed", order.ID, order.Status, next),
+	)
+}
+
+// RequireCancelEndpoint rejects moving an order to CANCELLED through a plain status
+// change. Cancelling goes through CancelUsecase, the one path that refunds the customer.
+func RequireCancelEndpoint(order *domain.Order, next domain.OrderStatus) apperrors.ApplicationError {
+	if next != domain.StatusCancelled || order.Status == domain.StatusCancelled {
+		return nil
+	}
+	return apperrors.NewApplicationError(mappings.OrderCancelEndpointRequiredError,
+		fmt.Errorf("order %s: status change to %s outside the cancel endpoint", order.ID, next))
+}
+
+// ActorUserID returns the user to record as the author of a status change, nil
+// when the request carries no user
+func ActorUserID(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
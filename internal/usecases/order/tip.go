@@ -0,0 +1,89 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of a tip given together with the order payment
+const (
+	TipStatusCharged = "charged"
+	TipStatusFailed  = "failed"
+)
+
+// TipOrderInput represents the input for tipping the driver of a delivered order
+type TipOrderInput struct {
+	OrderID      string
+	UserID       string
+	AuthToken    string
+	SecurityCode string
+	Amount       domain.Money
+}
+
+// TipOrderOutput represents the tip charged
+type TipOrderOutput struct {
+	OrderID       string       `json:"order_id"`
+	TransactionID string       `json:"transaction_id"`
+	Amount        domain.Money `json:"amount"`
+	Currency      string       `json:"currency"`
+	Status        string       `json:"status"`
+}
+
+// TipOrderUsecase defines the interface for tipping after delivery
+type TipOrderUsecase interface {
+	Execute(ctx context.Context, input TipOrderInput) (*TipOrderOutput, apperrors.ApplicationError)
+}
+
+type tipOrderUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewTipOrderUsecase creates a new instance of TipOrderUsecase
+func NewTipOrderUsecase(contextFactory appcontext.Factory) TipOrderUsecase {
+	return &tipOrderUsecase{contextFactory: contextFactory}
+}
+
+// Execute charges a tip for a delivered order to the owner's saved payment method.
+// The tip is its own transaction in the order's currency; the order is not touched.
+func (u *tipOrderUsecase) Execute(ctx context.Context, input TipOrderInput) (*TipOrderOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+	if input.Amount <= 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTipError, fmt.Errorf("tip of %s", input.Amount))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+	if order.Status != domain.StatusDelivered {
+		return nil, apperrors.NewApplicationError(mappings.OrderTipNotAllowedError, fmt.Errorf("order is %s", order.Status))
+	}
+
+	transaction, tipErr := ChargeTip(ctx, app, order, input.Amount, input.AuthToken, input.SecurityCode)
+	if tipErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderTipFailedError, tipErr)
+	}
+
+	return &TipOrderOutput{
+		OrderID:       order.ID,
+		TransactionID: transaction.ID,
+		Amount:        transaction.Amount,
+		Currency:      transaction.Currency,
+		Status:        transaction.Status,
+	}, nil
+}
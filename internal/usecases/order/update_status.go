@@ -7,6 +7,7 @@ import (
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
 	settingsUsecase "yego/internal/usecases/settings"
 
 	"github.com/google/uuid"
@@ -14,8 +15,11 @@ import (
 
 // UpdateStatusInput represents the input for updating order status
 type UpdateStatusInput struct {
-	Status string `json:"status" binding:"required"`
-	Token  string
+	Status          string `json:"status" binding:"required"`
+	DeliveryCode    string `json:"delivery_code"` // required to move to DELIVERED
+	Token           string
+	UserID          string
+	ExpectedVersion *int `json:"-"` // from If-Match; the version read here is enforced regardless
 }
 
 // UpdateStatusOutput represents the output after updating order status
@@ -30,13 +34,15 @@ type UpdateStatusUsecase interface {
 
 type updateStatusUsecase struct {
 	contextFactory          appcontext.Factory
+	notificationSvc         notification.Service
 	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
 }
 
 // NewUpdateStatusUsecase creates a new instance of UpdateStatusUsecase
-func NewUpdateStatusUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) UpdateStatusUsecase {
+func NewUpdateStatusUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) UpdateStatusUsecase {
 	return &updateStatusUsecase{
 		contextFactory:          contextFactory,
+		notificationSvc:         notificationSvc,
 		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
 	}
 }
@@ -53,11 +59,58 @@ func (u *updateStatusUsecase) Execute(ctx context.Context, id string, input Upda
 		return nil, apperrors.NewApplicationError(mappings.OrderInvalidStatusError, nil)
 	}
 
-	updated, err := app.Repositories.Order.UpdateStatus(ctx, id, domain.OrderStatus(input.Status))
+	order, err := app.Repositories.Order.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		return nil, apperrors.NewApplicationError(mappings.OrderVersionConflictError,
+			&domain.OrderVersionConflict{OrderID: order.ID, Expected: *input.ExpectedVersion, Found: order.Version})
+	}
+
+	if cancelErr := RequireCancelEndpoint(order, domain.OrderStatus(input.Status)); cancelErr != nil {
+		return nil, cancelErr
+	}
+	if transitionErr := ValidateStatusTransition(ctx, app, order, domain.OrderStatus(input.Status)); transitionErr != nil {
+		return nil, transitionErr
+	}
+
+	previousStatus := order.Status
+	next := domain.OrderStatus(input.Status)
+
+	if next == domain.StatusDelivered && previousStatus != domain.StatusDelivered {
+		if codeErr := VerifyDeliveryCode(ctx, app, order.ID, input.DeliveryCode); codeErr != nil {
+			return nil, codeErr
+		}
+	}
+
+	var updated *domain.Order
+	if next == domain.StatusPaused || previousStatus == domain.StatusPaused {
+		// Pausing and resuming also carry the pause data, which only Update writes
+		if next == domain.StatusPaused {
+			order.Pause(order.PauseReason, order.ResumeAt)
+		} else {
+			order.Status = next
+			order.ClearPause()
+		}
+		updated, err = app.Repositories.Order.Update(ctx, order, ActorUserID(input.UserID))
+	} else {
+		// Guarded by the version the transition was validated against
+		updated, err = app.Repositories.Order.UpdateStatus(ctx, id, next, &order.Version, ActorUserID(input.UserID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if updated.Status != previousStatus {
+		if updated.Status == domain.StatusConfirmed {
+			EnsureConfirmedPricing(ctx, app, updated, u.calculateDeliveryFeeUse)
+		}
+		IssueDeliveryCode(ctx, app, updated)
+		NotifyOrderUpdated(u.notificationSvc, updated, previousStatus)
+	}
+
 	// Payment processing removed - payments are now processed at order creation
 	// Keeping this comment for reference
 
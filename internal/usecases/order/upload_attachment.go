@@ -0,0 +1,135 @@
+package order
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// UploadAttachmentInput represents a proof-of-delivery file sent for an order
+type UploadAttachmentInput struct {
+	OrderID string
+	UserID  string
+	Kind    string
+	File    io.Reader
+}
+
+// attachableStatuses are the statuses in which proof of delivery may be attached
+var attachableStatuses = []domain.OrderStatus{
+	domain.StatusOnTheWay,
+	domain.StatusDelivered,
+}
+
+// UploadAttachmentUsecase defines the interface for attaching a photo or signature to an order
+type UploadAttachmentUsecase interface {
+	Execute(ctx context.Context, input UploadAttachmentInput) (*domain.Attachment, apperrors.ApplicationError)
+}
+
+type uploadAttachmentUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewUploadAttachmentUsecase creates a new instance of UploadAttachmentUsecase
+func NewUploadAttachmentUsecase(contextFactory appcontext.Factory) UploadAttachmentUsecase {
+	return &uploadAttachmentUsecase{contextFactory: contextFactory}
+}
+
+// Execute validates the file, writes it to storage and records its metadata.
+// The type is sniffed from the content, the client's Content-Type is not trusted.
+func (u *uploadAttachmentUsecase) Execute(ctx context.Context, input UploadAttachmentInput) (*domain.Attachment, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+	if !domain.IsValidAttachmentKind(input.Kind) {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentInvalidKindError, nil)
+	}
+	if input.File == nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentMissingFileError, nil)
+	}
+
+	// Orders don't record a driver yet, so only admins, who dispatch them, may upload
+	if !app.ConfigService.IsAdmin(input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentUploadForbiddenError,
+			fmt.Errorf("user %q may not attach files to order %s", input.UserID, input.OrderID))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAttachable(order.Status) {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentNotAllowedError,
+			fmt.Errorf("order %s is %s", order.ID, order.Status))
+	}
+
+	maxBytes := app.ConfigService.AttachmentMaxBytes
+	data, readErr := io.ReadAll(io.LimitReader(input.File, maxBytes+1))
+	if readErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentCreateError, readErr)
+	}
+	if len(data) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentMissingFileError, nil)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentTooLargeError,
+			fmt.Errorf("attachment exceeds %d bytes", maxBytes))
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := domain.AttachmentContentTypes[contentType]
+	if !ok {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentUnsupportedTypeError,
+			fmt.Errorf("detected content type %s", contentType))
+	}
+
+	checksum := sha256.Sum256(data)
+	attachment := &domain.Attachment{
+		ID:          uuid.New().String(),
+		OrderID:     order.ID,
+		Kind:        domain.AttachmentKind(input.Kind),
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+	}
+	attachment.StorageKey = fmt.Sprintf("orders/%s/%s%s", order.ID, attachment.ID, extension)
+	if input.UserID != "" {
+		attachment.UploadedBy = &input.UserID
+	}
+
+	if storeErr := app.Integrations.Storage.Put(ctx, attachment.StorageKey, contentType, data); storeErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.AttachmentCreateError, storeErr)
+	}
+
+	created, err := app.Repositories.Attachment.Create(ctx, attachment)
+	if err != nil {
+		// Don't leave an orphaned object behind when the metadata can't be saved
+		if delErr := app.Integrations.Storage.Delete(ctx, attachment.StorageKey); delErr != nil {
+			log.Printf("UploadAttachment: failed to remove orphaned attachment %s: %v", attachment.StorageKey, delErr)
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func isAttachable(status domain.OrderStatus) bool {
+	for _, s := range attachableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
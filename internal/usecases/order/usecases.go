@@ -8,22 +8,50 @@ import (
 
 // Usecases aggregates all order-related use cases
 type Usecases struct {
-	Create         CreateUsecase
-	CreateWithLink CreateWithLinkUsecase
-	Claim          ClaimUsecase
-	Get            GetUsecase
-	UpdateStatus   UpdateStatusUsecase
-	ListMyOrders   ListMyOrdersUsecase
+	Create              CreateUsecase
+	CreateWithLink      CreateWithLinkUsecase
+	Claim               ClaimUsecase
+	Get                 GetUsecase
+	GetHistory          GetHistoryUsecase
+	UpdateStatus        UpdateStatusUsecase
+	ListMyOrders        ListMyOrdersUsecase
+	Cancel              CancelUsecase
+	RequestModification RequestModificationUsecase
+	ListModifications   ListModificationsUsecase
+	Pause               PauseUsecase
+	Resume              ResumeUsecase
+	ResumeDue           ResumeDueUsecase
+	GetDeliveryCode     GetDeliveryCodeUsecase
+	UploadAttachment    UploadAttachmentUsecase
+	ApplyCoupon         ApplyCouponUsecase
+	RemoveCoupon        RemoveCouponUsecase
+	TipOrder            TipOrderUsecase
+	Reorder             ReorderUsecase
+	GetDocument         GetDocumentUsecase
 }
 
 // NewUsecases creates all order use cases
 func NewUsecases(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) *Usecases {
 	return &Usecases{
-		Create:         NewCreateUsecase(contextFactory, calculateDeliveryFeeUse),
-		CreateWithLink: NewCreateWithLinkUsecase(contextFactory),
-		Claim:          NewClaimUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse),
-		Get:            NewGetUsecase(contextFactory),
-		UpdateStatus:   NewUpdateStatusUsecase(contextFactory, calculateDeliveryFeeUse),
-		ListMyOrders:   NewListMyOrdersUsecase(contextFactory),
+		Create:              NewCreateUsecase(contextFactory, calculateDeliveryFeeUse),
+		CreateWithLink:      NewCreateWithLinkUsecase(contextFactory),
+		Claim:               NewClaimUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse),
+		Get:                 NewGetUsecase(contextFactory),
+		GetHistory:          NewGetHistoryUsecase(contextFactory),
+		UpdateStatus:        NewUpdateStatusUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse),
+		ListMyOrders:        NewListMyOrdersUsecase(contextFactory),
+		Cancel:              NewCancelUsecase(contextFactory, notificationSvc),
+		RequestModification: NewRequestModificationUsecase(contextFactory),
+		ListModifications:   NewListModificationsUsecase(contextFactory),
+		Pause:               NewPauseUsecase(contextFactory, notificationSvc),
+		Resume:              NewResumeUsecase(contextFactory, notificationSvc),
+		ResumeDue:           NewResumeDueUsecase(contextFactory, notificationSvc),
+		GetDeliveryCode:     NewGetDeliveryCodeUsecase(contextFactory),
+		UploadAttachment:    NewUploadAttachmentUsecase(contextFactory),
+		ApplyCoupon:         NewApplyCouponUsecase(contextFactory, calculateDeliveryFeeUse),
+		RemoveCoupon:        NewRemoveCouponUsecase(contextFactory, calculateDeliveryFeeUse),
+		TipOrder:            NewTipOrderUsecase(contextFactory),
+		Reorder:             NewReorderUsecase(contextFactory, calculateDeliveryFeeUse),
+		GetDocument:         NewGetDocumentUsecase(contextFactory),
 	}
 }
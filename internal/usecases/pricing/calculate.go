@@ -0,0 +1,162 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"yego/internal/domain"
+)
+
+// Surcharge is an extra charge added on top of products and delivery
+type Surcharge struct {
+	Code        string
+	Description string
+	Amount      domain.Money
+}
+
+// Input is everything needed to price an order. Calculate does no I/O, so the
+// same input always yields the same breakdown.
+type Input struct {
+	Items      []domain.OrderItem
+	Delivery   *domain.DeliveryFeeBreakdown // nil when there is no delivery fee
+	Surcharges []Surcharge
+	Discounts  []domain.OrderDiscount
+	Currency   string              // every amount above is in this currency
+	Tax        *domain.TaxSettings // nil leaves the IVA breakdown out
+	PricedAt   time.Time
+}
+
+// Calculate prices an order line by line: products, delivery, surcharges, then
+// discounts. Amounts are exact cents, so the totals always equal the sum of the
+// lines. Discounts never take the total below zero; one that would is reduced to
+// what is left.
+//
+// Prices include IVA. With tax settings each line carries the tax it includes, and
+// the order gets a net/tax/gross breakdown per rate. Discounts reduce every rate in
+// proportion to its share of the order, as they apply to the order as a whole.
+func Calculate(input Input) *domain.OrderPricing {
+	pricing := &domain.OrderPricing{
+		Lines:     []domain.PricingLine{},
+		Discounts: []domain.OrderDiscount{},
+		Currency:  input.Currency,
+		PricedAt:  input.PricedAt,
+	}
+	tax := domain.DefaultTaxSettings()
+	if input.Tax != nil {
+		tax = *input.Tax
+	}
+	grossByRate := make(map[float64]domain.Money)
+	addLine := func(line domain.PricingLine, rate float64) {
+		if input.Tax != nil {
+			line.TaxRate = rate
+			line.TaxAmount = domain.IncludedTax(line.Amount, rate)
+			grossByRate[rate] += line.Amount
+		}
+		pricing.Lines = append(pricing.Lines, line)
+	}
+
+	for _, item := range input.Items {
+		if item.Quantity <= 0 {
+			continue
+		}
+		amount := item.Price.Mul(item.Quantity)
+		addLine(domain.PricingLine{
+			Kind:        domain.PricingLineProduct,
+			Code:        item.Code,
+			Description: item.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			Amount:      amount,
+		}, tax.RateFor(item))
+		pricing.Subtotal += amount
+	}
+
+	if input.Delivery != nil {
+		delivery := *input.Delivery
+		pricing.Delivery = &delivery
+		if delivery.TotalPrice > 0 {
+			addLine(domain.PricingLine{
+				Kind:        domain.PricingLineDelivery,
+				Description: "Envío",
+				Quantity:    1,
+				UnitPrice:   delivery.TotalPrice,
+				Amount:      delivery.TotalPrice,
+			}, tax.DeliveryRate)
+		}
+	}
+
+	for _, surcharge := range input.Surcharges {
+		if surcharge.Amount <= 0 {
+			continue
+		}
+		addLine(domain.PricingLine{
+			Kind:        domain.PricingLineSurcharge,
+			Code:        surcharge.Code,
+			Description: surcharge.Description,
+			Quantity:    1,
+			UnitPrice:   surcharge.Amount,
+			Amount:      surcharge.Amount,
+		}, tax.DefaultRate)
+		pricing.SurchargeTotal += surcharge.Amount
+	}
+
+	remaining := pricing.Subtotal + pricing.DeliveryFee() + pricing.SurchargeTotal
+	for _, discount := range input.Discounts {
+		amount := domain.MinMoney(discount.Amount, remaining)
+		if amount <= 0 {
+			continue
+		}
+		discount.Amount = amount
+		pricing.Discounts = append(pricing.Discounts, discount)
+		line := domain.PricingLine{
+			Kind:        domain.PricingLineDiscount,
+			Code:        discount.Code,
+			Description: discount.Description,
+			Quantity:    1,
+			UnitPrice:   -amount,
+			Amount:      -amount,
+		}
+		if input.Tax != nil {
+			for rate, share := range allocateDiscount(amount, grossByRate) {
+				grossByRate[rate] -= share
+				line.TaxAmount -= domain.IncludedTax(share, rate)
+			}
+		}
+		pricing.Lines = append(pricing.Lines, line)
+		pricing.DiscountTotal += amount
+		remaining -= amount
+	}
+
+	pricing.Total = remaining
+	if input.Tax != nil {
+		pricing.Tax = domain.NewTaxBreakdown(grossByRate)
+	}
+	return pricing
+}
+
+// allocateDiscount splits a discount across the rates in proportion to the gross
+// left at each one. Rounding cents go to the rate with the largest gross, so the
+// shares always add up to the discount.
+func allocateDiscount(amount domain.Money, grossByRate map[float64]domain.Money) map[float64]domain.Money {
+	var total domain.Money
+	var largest float64
+	for rate, gross := range grossByRate {
+		total += gross
+		if gross > grossByRate[largest] || (gross == grossByRate[largest] && rate > largest) {
+			largest = rate
+		}
+	}
+	shares := make(map[float64]domain.Money)
+	if total <= 0 {
+		return shares
+	}
+
+	var allocated domain.Money
+	for rate, gross := range grossByRate {
+		share := domain.Money(math.Floor(float64(amount) * float64(gross) / float64(total)))
+		shares[rate] = share
+		allocated += share
+	}
+	shares[largest] += amount - allocated
+	return shares
+}
@@ -0,0 +1,185 @@
+package pricing
+
+import (
+	"reflect"
+	"testing"
+
+	"yego/internal/domain"
+)
+
+func rate(r float64) *float64 {
+	return &r
+}
+
+func TestCalculate(t *testing.T) {
+	tax := domain.DefaultTaxSettings()
+	mixedItems := []domain.OrderItem{
+		{Code: "VINO", Name: "Vino", Price: 1210, Quantity: 1, TaxRate: rate(domain.TaxRateGeneral)},
+		{Code: "PAN", Name: "Pan", Price: 1105, Quantity: 1, TaxRate: rate(domain.TaxRateReduced)},
+	}
+
+	tests := []struct {
+		name          string
+		input         Input
+		wantSubtotal  domain.Money
+		wantDiscount  domain.Money
+		wantTotal     domain.Money
+		wantLineTaxes []domain.Money
+		wantTax       *domain.TaxBreakdown
+	}{
+		{
+			name: "tax rounded to the cent",
+			input: Input{
+				Items: []domain.OrderItem{{Name: "Yerba", Price: 333, Quantity: 3}},
+				Tax:   &tax,
+			},
+			wantSubtotal:  999,
+			wantTotal:     999,
+			wantLineTaxes: []domain.Money{173},
+			wantTax: &domain.TaxBreakdown{Net: 826, Tax: 173, Gross: 999, Rates: []domain.TaxRateBreakdown{
+				{Rate: 21, Net: 826, Tax: 173, Gross: 999},
+			}},
+		},
+		{
+			name: "mixed tax rates with delivery",
+			input: Input{
+				Items:    mixedItems,
+				Delivery: &domain.DeliveryFeeBreakdown{TotalPrice: 500},
+				Tax:      &tax,
+			},
+			wantSubtotal:  2315,
+			wantTotal:     2815,
+			wantLineTaxes: []domain.Money{210, 105, 87},
+			wantTax: &domain.TaxBreakdown{Net: 2413, Tax: 402, Gross: 2815, Rates: []domain.TaxRateBreakdown{
+				{Rate: 21, Net: 1413, Tax: 297, Gross: 1710},
+				{Rate: 10.5, Net: 1000, Tax: 105, Gross: 1105},
+			}},
+		},
+		{
+			name: "discount allocated across tax rates",
+			input: Input{
+				Items:     mixedItems,
+				Discounts: []domain.OrderDiscount{{Code: "PROMO", Amount: 500}},
+				Tax:       &tax,
+			},
+			wantSubtotal: 2315,
+			wantDiscount: 500,
+			wantTotal:    1815,
+			// 262 off the 21% gross and 238 off the 10.5% gross
+			wantLineTaxes: []domain.Money{210, 105, -68},
+			wantTax: &domain.TaxBreakdown{Net: 1568, Tax: 247, Gross: 1815, Rates: []domain.TaxRateBreakdown{
+				{Rate: 21, Net: 783, Tax: 165, Gross: 948},
+				{Rate: 10.5, Net: 785, Tax: 82, Gross: 867},
+			}},
+		},
+		{
+			name: "discount larger than the subtotal",
+			input: Input{
+				Items: []domain.OrderItem{{Name: "Yerba", Price: 1000, Quantity: 1}},
+				Discounts: []domain.OrderDiscount{
+					{Code: "BIG", Amount: 1500},
+					{Code: "EXTRA", Amount: 200},
+				},
+				Tax: &tax,
+			},
+			wantSubtotal:  1000,
+			wantDiscount:  1000,
+			wantTotal:     0,
+			wantLineTaxes: []domain.Money{174, -174},
+			wantTax:       &domain.TaxBreakdown{Rates: []domain.TaxRateBreakdown{}},
+		},
+		{
+			name: "without tax settings",
+			input: Input{
+				Items:      []domain.OrderItem{{Name: "Yerba", Price: 333, Quantity: 3}, {Name: "Nada", Price: 100, Quantity: 0}},
+				Surcharges: []Surcharge{{Code: "FRIO", Amount: 150}, {Code: "NADA", Amount: 0}},
+				Discounts:  []domain.OrderDiscount{{Code: "PROMO", Amount: 49}},
+			},
+			wantSubtotal:  999,
+			wantDiscount:  49,
+			wantTotal:     1100,
+			wantLineTaxes: []domain.Money{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing := Calculate(tt.input)
+
+			if pricing.Subtotal != tt.wantSubtotal {
+				t.Errorf("Subtotal = %s, want %s", pricing.Subtotal, tt.wantSubtotal)
+			}
+			if pricing.DiscountTotal != tt.wantDiscount {
+				t.Errorf("DiscountTotal = %s, want %s", pricing.DiscountTotal, tt.wantDiscount)
+			}
+			if pricing.Total != tt.wantTotal {
+				t.Errorf("Total = %s, want %s", pricing.Total, tt.wantTotal)
+			}
+
+			var lineTotal domain.Money
+			lineTaxes := make([]domain.Money, len(pricing.Lines))
+			for i, line := range pricing.Lines {
+				lineTotal += line.Amount
+				lineTaxes[i] = line.TaxAmount
+			}
+			if lineTotal != pricing.Total {
+				t.Errorf("lines add up to %s, total is %s", lineTotal, pricing.Total)
+			}
+			if !reflect.DeepEqual(lineTaxes, tt.wantLineTaxes) {
+				t.Errorf("line taxes = %v, want %v", lineTaxes, tt.wantLineTaxes)
+			}
+			if !reflect.DeepEqual(pricing.Tax, tt.wantTax) {
+				t.Errorf("Tax = %+v, want %+v", pricing.Tax, tt.wantTax)
+			}
+		})
+	}
+}
+
+func TestAllocateDiscount(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      domain.Money
+		grossByRate map[float64]domain.Money
+		want        map[float64]domain.Money
+	}{
+		{
+			name:        "in proportion to the gross",
+			amount:      1000,
+			grossByRate: map[float64]domain.Money{21: 3000, 10.5: 1000},
+			want:        map[float64]domain.Money{21: 750, 10.5: 250},
+		},
+		{
+			name:        "rounding cents go to the largest gross",
+			amount:      500,
+			grossByRate: map[float64]domain.Money{21: 1210, 10.5: 1105},
+			want:        map[float64]domain.Money{21: 262, 10.5: 238},
+		},
+		{
+			name:        "ties go to the highest rate",
+			amount:      100,
+			grossByRate: map[float64]domain.Money{21: 1, 10.5: 1, 0: 1},
+			want:        map[float64]domain.Money{21: 34, 10.5: 33, 0: 33},
+		},
+		{
+			name:        "single rate",
+			amount:      999,
+			grossByRate: map[float64]domain.Money{10.5: 5000},
+			want:        map[float64]domain.Money{10.5: 999},
+		},
+		{
+			name:        "nothing left to discount",
+			amount:      100,
+			grossByRate: map[float64]domain.Money{21: 0},
+			want:        map[float64]domain.Money{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocateDiscount(tt.amount, tt.grossByRate)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocateDiscount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// PriceOrder prices an order from the live settings: its items, the delivery fee to
+// the profile's location and the discounts of its redeemed coupons, with IVA broken
+// down at the configured rates. Without a profile location no delivery fee is added.
+// The fee is quoted with the delivery prices of the order currency; a fee in any
+// other currency is rejected with domain.ErrCurrencyMismatch.
+func PriceOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, profile *domain.Profile, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (*domain.OrderPricing, error) {
+	input := Input{Currency: order.Currency, PricedAt: time.Now()}
+	if input.Currency == "" {
+		input.Currency = domain.DefaultCurrency
+	}
+	settings, settingsErr := app.Repositories.Settings.Get(ctx)
+	if settingsErr != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", settingsErr)
+	}
+	input.Tax = &settings.Tax
+	if order.Data == nil || len(order.Data.Items) == 0 {
+		return Calculate(input), nil
+	}
+	input.Items = order.Data.Items
+
+	var location *domain.ProfileLocation
+	if profile != nil && profile.LocationID != nil {
+		loc, err := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
+		if err == nil {
+			location = loc
+		}
+	}
+
+	delivery, err := QuoteDelivery(ctx, order.Data.Items, location, input.Currency, calculateDeliveryFeeUse)
+	if err != nil {
+		return nil, err
+	}
+	if delivery != nil && delivery.TotalPrice > 0 && delivery.Currency != input.Currency {
+		return nil, fmt.Errorf("delivery fee in %s for an order in %s: %w", delivery.Currency, input.Currency, domain.ErrCurrencyMismatch)
+	}
+	input.Delivery = delivery
+
+	discounts, err := promotionDiscounts(ctx, app, order, delivery, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+	input.Discounts = discounts
+
+	return Calculate(input), nil
+}
+
+// promotionDiscounts computes the discounts of the coupons redeemed on an order.
+// Coupons that no longer apply, e.g. after items were removed, are left out.
+func promotionDiscounts(ctx context.Context, app *appcontext.Context, order *domain.Order, delivery *domain.DeliveryFeeBreakdown, currency string) ([]domain.OrderDiscount, error) {
+	if order.ID == "" {
+		return nil, nil
+	}
+	promotions, listErr := app.Repositories.Promotion.ListByOrderID(ctx, order.ID)
+	if listErr != nil {
+		return nil, fmt.Errorf("failed to list order promotions: %w", listErr)
+	}
+
+	var discounts []domain.OrderDiscount
+	for _, promotion := range promotions {
+		discount, err := PromotionDiscount(promotion, order.Data.Items, delivery, currency)
+		if errors.Is(err, ErrPromotionNotApplicable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, discount)
+	}
+	return discounts, nil
+}
+
+// QuoteDelivery quotes the delivery fee of items to a location, in the delivery
+// prices of currency. It returns nil without a location.
+func QuoteDelivery(ctx context.Context, items []domain.OrderItem, location *domain.ProfileLocation, currency string, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (*domain.DeliveryFeeBreakdown, error) {
+	if location == nil {
+		return nil, nil
+	}
+
+	deliveryFeeInput := settingsUsecase.CalculateDeliveryFeeInput{
+		UserLatitude:  location.Latitude,
+		UserLongitude: location.Longitude,
+		Currency:      currency,
+		Items: make([]struct {
+			Quantity int  `json:"quantity"`
+			Weight   *int `json:"weight,omitempty"`
+		}, len(items)),
+	}
+	for i, item := range items {
+		deliveryFeeInput.Items[i].Quantity = item.Quantity
+		deliveryFeeInput.Items[i].Weight = item.Weight
+	}
+
+	deliveryFeeOutput, feeErr := calculateDeliveryFeeUse.Execute(ctx, deliveryFeeInput)
+	if feeErr != nil {
+		return nil, fmt.Errorf("failed to calculate delivery fee: %w", feeErr)
+	}
+
+	return &domain.DeliveryFeeBreakdown{
+		DistanceKm:    deliveryFeeOutput.DistanceKm,
+		TotalWeightG:  deliveryFeeOutput.TotalWeightG,
+		TotalWeightKg: deliveryFeeOutput.TotalWeightKg,
+		BasePrice:     deliveryFeeOutput.BasePrice,
+		DistancePrice: deliveryFeeOutput.DistancePrice,
+		WeightPrice:   deliveryFeeOutput.WeightPrice,
+		TotalPrice:    deliveryFeeOutput.TotalPrice,
+		Currency:      deliveryFeeOutput.Currency,
+	}, nil
+}
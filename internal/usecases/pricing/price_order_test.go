@@ -0,0 +1,106 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+
+	"yego/internal/adapters/datasources/repositories"
+	"yego/internal/adapters/datasources/repositories/profile"
+	settingsRepository "yego/internal/adapters/datasources/repositories/settings"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+type fakeSettings struct {
+	settingsRepository.Repository
+	settings *domain.Settings
+}
+
+func (f *fakeSettings) Get(context.Context) (*domain.Settings, apperrors.ApplicationError) {
+	return f.settings, nil
+}
+
+type fakeProfiles struct {
+	profile.Repository
+	location *domain.ProfileLocation
+}
+
+func (f *fakeProfiles) GetLocationByID(context.Context, string) (*domain.ProfileLocation, apperrors.ApplicationError) {
+	return f.location, nil
+}
+
+func TestPriceOrderDeliveryCurrency(t *testing.T) {
+	locationID := "location-1"
+	customer := &domain.Profile{ID: "profile-1", LocationID: &locationID}
+
+	tests := []struct {
+		name         string
+		currency     string
+		usdPrices    map[string]domain.DeliveryPrices
+		wantDelivery domain.Money
+		wantTotal    domain.Money
+		wantErr      bool
+	}{
+		{
+			name:         "default currency",
+			currency:     domain.CurrencyARS,
+			wantDelivery: 60000, // 500 base and 1 kg at 100
+			wantTotal:    61000,
+		},
+		{
+			name:     "other currency with its own delivery prices",
+			currency: domain.CurrencyUSD,
+			usdPrices: map[string]domain.DeliveryPrices{
+				domain.CurrencyUSD: {BasePrice: 500, PricePerKm: 100, PricePerKg: 50},
+			},
+			wantDelivery: 550,
+			wantTotal:    1550,
+		},
+		{
+			name:     "other currency without delivery prices",
+			currency: domain.CurrencyUSD,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &domain.Settings{
+				DefaultItemWeight:      1000,
+				DeliveryBasePrice:      domain.MoneyFromUnits(500),
+				DeliveryPricePerKm:     domain.MoneyFromUnits(200),
+				DeliveryPricePerKg:     domain.MoneyFromUnits(100),
+				DefaultCurrency:        domain.CurrencyARS,
+				CurrencyDeliveryPrices: tt.usdPrices,
+				Tax:                    domain.DefaultTaxSettings(),
+			}
+			app := &appcontext.Context{
+				Repositories: &repositories.Repositories{
+					Settings: &fakeSettings{settings: settings},
+					Profile:  &fakeProfiles{location: &domain.ProfileLocation{ID: locationID}},
+				},
+			}
+			calculateDeliveryFee := settingsUsecase.NewCalculateDeliveryFeeUsecase(func(...appcontext.Option) *appcontext.Context { return app })
+			order := &domain.Order{
+				Currency: tt.currency,
+				Data:     &domain.OrderData{Items: []domain.OrderItem{{Name: "Yerba", Price: 1000, Quantity: 1}}},
+			}
+
+			pricing, err := PriceOrder(context.Background(), app, order, customer, calculateDeliveryFee)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PriceOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if pricing.Delivery == nil || pricing.Delivery.TotalPrice != tt.wantDelivery || pricing.Delivery.Currency != tt.currency {
+				t.Errorf("Delivery = %+v, want %s in %s", pricing.Delivery, tt.wantDelivery, tt.currency)
+			}
+			if pricing.Total != tt.wantTotal || pricing.Currency != tt.currency {
+				t.Errorf("Total = %s %s, want %s %s", pricing.Total, pricing.Currency, tt.wantTotal, tt.currency)
+			}
+		})
+	}
+}
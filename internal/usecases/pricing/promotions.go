@@ -0,0 +1,74 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+
+	"yego/internal/domain"
+)
+
+// ErrPromotionNotApplicable is returned when a promotion gives no discount on an order,
+// e.g. below its minimum amount or without the items it rewards
+var ErrPromotionNotApplicable = errors.New("promotion does not apply")
+
+// PromotionDiscount computes the discount a promotion gives on items priced in
+// currency, with delivery as quoted (nil when unknown). A free delivery promotion
+// without a quoted fee is applicable but discounts nothing yet. Promotions whose
+// amounts are in another currency fail with domain.ErrCurrencyMismatch.
+func PromotionDiscount(promotion *domain.Promotion, items []domain.OrderItem, delivery *domain.DeliveryFeeBreakdown, currency string) (domain.OrderDiscount, error) {
+	discount := domain.OrderDiscount{
+		Code:        promotion.Code,
+		Description: promotion.Description,
+	}
+	if discount.Description == "" {
+		discount.Description = fmt.Sprintf("Cupón %s", promotion.Code)
+	}
+
+	if (promotion.AmountOff > 0 || promotion.MinOrderAmount > 0) && promotion.Currency != currency {
+		return discount, fmt.Errorf("promotion %s in %s for an order in %s: %w", promotion.Code, promotion.Currency, currency, domain.ErrCurrencyMismatch)
+	}
+
+	var subtotal domain.Money
+	for _, item := range items {
+		if item.Quantity > 0 {
+			subtotal += item.Price.Mul(item.Quantity)
+		}
+	}
+	if subtotal <= 0 {
+		return discount, fmt.Errorf("order has no products: %w", ErrPromotionNotApplicable)
+	}
+	if subtotal < promotion.MinOrderAmount {
+		return discount, fmt.Errorf("products add up to %s, below the minimum of %s: %w", subtotal, promotion.MinOrderAmount, ErrPromotionNotApplicable)
+	}
+
+	switch promotion.Kind {
+	case domain.PromotionPercentage:
+		discount.Amount = subtotal.MulFloat(promotion.PercentOff / 100)
+	case domain.PromotionFixed:
+		discount.Amount = promotion.AmountOff
+	case domain.PromotionFreeDelivery:
+		if delivery != nil {
+			discount.Amount = delivery.TotalPrice
+		}
+	case domain.PromotionBuyXGetY:
+		if promotion.BuyCode == nil {
+			return discount, fmt.Errorf("promotion %s has no buy_code: %w", promotion.Code, ErrPromotionNotApplicable)
+		}
+		// Every BuyQuantity+GetQuantity units of the code, GetQuantity are free
+		group := promotion.BuyQuantity + promotion.GetQuantity
+		for _, item := range items {
+			if item.Code != *promotion.BuyCode || item.Quantity <= 0 || group <= 0 {
+				continue
+			}
+			free := (item.Quantity / group) * promotion.GetQuantity
+			discount.Amount += item.Price.Mul(free)
+		}
+		if discount.Amount <= 0 {
+			return discount, fmt.Errorf("not enough units of %s: %w", *promotion.BuyCode, ErrPromotionNotApplicable)
+		}
+	default:
+		return discount, fmt.Errorf("unknown promotion kind %q: %w", promotion.Kind, ErrPromotionNotApplicable)
+	}
+
+	return discount, nil
+}
@@ -0,0 +1,105 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+
+	"yego/internal/domain"
+)
+
+func TestPromotionDiscount(t *testing.T) {
+	buyCode := "MATE"
+	items := []domain.OrderItem{
+		{Code: "YERBA", Name: "Yerba", Price: 333, Quantity: 3},
+		{Code: "MATE", Name: "Mate", Price: 300, Quantity: 7},
+	}
+
+	tests := []struct {
+		name      string
+		promotion domain.Promotion
+		items     []domain.OrderItem
+		delivery  *domain.DeliveryFeeBreakdown
+		want      domain.Money
+		wantErr   error
+	}{
+		{
+			name:      "percentage rounded to the cent",
+			promotion: domain.Promotion{Code: "QUINCE", Kind: domain.PromotionPercentage, PercentOff: 15},
+			items:     items[:1],
+			want:      150, // 15% of 9.99 is 1.4985
+		},
+		{
+			name:      "percentage half a cent rounds up",
+			promotion: domain.Promotion{Code: "DOCE", Kind: domain.PromotionPercentage, PercentOff: 12.5},
+			items:     []domain.OrderItem{{Name: "Yerba", Price: 1004, Quantity: 1}},
+			want:      126, // 12.5% of 10.04 is 1.255
+		},
+		{
+			name:      "fixed larger than the subtotal is left to Calculate",
+			promotion: domain.Promotion{Code: "MIL", Kind: domain.PromotionFixed, AmountOff: 5000, Currency: domain.CurrencyARS},
+			items:     items[:1],
+			want:      5000,
+		},
+		{
+			name:      "fixed in another currency",
+			promotion: domain.Promotion{Code: "USD", Kind: domain.PromotionFixed, AmountOff: 500, Currency: domain.CurrencyUSD},
+			items:     items,
+			wantErr:   domain.ErrCurrencyMismatch,
+		},
+		{
+			name:      "below the minimum",
+			promotion: domain.Promotion{Code: "MIN", Kind: domain.PromotionPercentage, PercentOff: 10, MinOrderAmount: 5000, Currency: domain.CurrencyARS},
+			items:     items,
+			wantErr:   ErrPromotionNotApplicable,
+		},
+		{
+			name:      "free delivery",
+			promotion: domain.Promotion{Code: "ENVIO", Kind: domain.PromotionFreeDelivery},
+			items:     items,
+			delivery:  &domain.DeliveryFeeBreakdown{TotalPrice: 700},
+			want:      700,
+		},
+		{
+			name:      "free delivery before the fee is quoted",
+			promotion: domain.Promotion{Code: "ENVIO", Kind: domain.PromotionFreeDelivery},
+			items:     items,
+			want:      0,
+		},
+		{
+			name:      "buy two get one",
+			promotion: domain.Promotion{Code: "3X2", Kind: domain.PromotionBuyXGetY, BuyCode: &buyCode, BuyQuantity: 2, GetQuantity: 1},
+			items:     items,
+			want:      600, // 7 mates are two full groups of three
+		},
+		{
+			name:      "buy two get one without enough units",
+			promotion: domain.Promotion{Code: "3X2", Kind: domain.PromotionBuyXGetY, BuyCode: &buyCode, BuyQuantity: 2, GetQuantity: 1},
+			items:     []domain.OrderItem{{Code: "MATE", Name: "Mate", Price: 300, Quantity: 2}},
+			wantErr:   ErrPromotionNotApplicable,
+		},
+		{
+			name:      "no products",
+			promotion: domain.Promotion{Code: "QUINCE", Kind: domain.PromotionPercentage, PercentOff: 15},
+			items:     []domain.OrderItem{{Name: "Yerba", Price: 333, Quantity: 0}},
+			wantErr:   ErrPromotionNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discount, err := PromotionDiscount(&tt.promotion, tt.items, tt.delivery, domain.CurrencyARS)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PromotionDiscount() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if discount.Amount != tt.want {
+				t.Errorf("PromotionDiscount() = %s, want %s", discount.Amount, tt.want)
+			}
+			if discount.Code != tt.promotion.Code || discount.Description == "" {
+				t.Errorf("discount = %+v, want code %s and a description", discount, tt.promotion.Code)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package settings
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"time"
 
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
 )
 
 // Usecases contains all settings-related use cases
@@ -56,17 +59,24 @@ func (u *getUsecase) Execute(ctx context.Context) (*GetOutput, apperrors.Applica
 // --- Update Usecase ---
 
 type UpdateInput struct {
-	BusinessName        *string  `json:"business_name,omitempty"`
-	BusinessLatitude    *float64 `json:"business_latitude,omitempty"`
-	BusinessLongitude   *float64 `json:"business_longitude,omitempty"`
-	DefaultMapLatitude  *float64 `json:"default_map_latitude,omitempty"`
-	DefaultMapLongitude *float64 `json:"default_map_longitude,omitempty"`
-	DefaultMapZoom      *int     `json:"default_map_zoom,omitempty"`
-	DefaultItemWeight   *int     `json:"default_item_weight,omitempty"`
-	DeliveryBasePrice   *float64 `json:"delivery_base_price,omitempty"`
-	DeliveryPricePerKm  *float64 `json:"delivery_price_per_km,omitempty"`
-	DeliveryPricePerKg  *float64 `json:"delivery_price_per_kg,omitempty"`
-	ManagerCollectorID  *string  `json:"manager_collector_id,omitempty"`
+	BusinessName        *string       `json:"business_name,omitempty"`
+	BusinessLatitude    *float64      `json:"business_latitude,omitempty"`
+	BusinessLongitude   *float64      `json:"business_longitude,omitempty"`
+	DefaultMapLatitude  *float64      `json:"default_map_latitude,omitempty"`
+	DefaultMapLongitude *float64      `json:"default_map_longitude,omitempty"`
+	DefaultMapZoom      *int          `json:"default_map_zoom,omitempty"`
+	DefaultItemWeight   *int          `json:"default_item_weight,omitempty"`
+	DeliveryBasePrice   *domain.Money `json:"delivery_base_price,omitempty"`
+	DeliveryPricePerKm  *domain.Money `json:"delivery_price_per_km,omitempty"`
+	DeliveryPricePerKg  *domain.Money `json:"delivery_price_per_kg,omitempty"`
+	ManagerCollectorID  *string       `json:"manager_collector_id,omitempty"`
+
+	Timezone      *string                          `json:"timezone,omitempty"`
+	DeliverySlots *[]domain.DeliverySlotDefinition `json:"delivery_slots,omitempty"`
+
+	DefaultCurrency        *string                           `json:"default_currency,omitempty"`
+	CurrencyDeliveryPrices *map[string]domain.DeliveryPrices `json:"currency_delivery_prices,omitempty"`
+	Tax                    *domain.TaxSettings               `json:"tax,omitempty"`
 }
 
 type UpdateOutput struct {
@@ -128,6 +138,41 @@ func (u *updateUsecase) Execute(ctx context.Context, input UpdateInput) (*Update
 	if input.ManagerCollectorID != nil {
 		current.ManagerCollectorID = input.ManagerCollectorID
 	}
+	if input.Timezone != nil {
+		if _, tzErr := time.LoadLocation(*input.Timezone); tzErr != nil || *input.Timezone == "" {
+			return nil, apperrors.NewApplicationError(mappings.SettingsInvalidTimezoneError, tzErr)
+		}
+		current.Timezone = *input.Timezone
+	}
+	if input.DeliverySlots != nil {
+		if slotsErr := domain.ValidateDeliverySlots(*input.DeliverySlots); slotsErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.SettingsInvalidDeliverySlotsError, slotsErr)
+		}
+		current.DeliverySlots = *input.DeliverySlots
+	}
+	if input.DefaultCurrency != nil {
+		currency := domain.NormalizeCurrency(*input.DefaultCurrency)
+		if !domain.IsValidCurrency(currency) {
+			return nil, apperrors.NewApplicationError(mappings.SettingsInvalidCurrencyError, nil)
+		}
+		current.DefaultCurrency = currency
+	}
+	if input.CurrencyDeliveryPrices != nil {
+		prices := make(map[string]domain.DeliveryPrices, len(*input.CurrencyDeliveryPrices))
+		for currency, p := range *input.CurrencyDeliveryPrices {
+			prices[domain.NormalizeCurrency(currency)] = p
+		}
+		if pricesErr := domain.ValidateCurrencyDeliveryPrices(prices); pricesErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.SettingsInvalidDeliveryPricesError, pricesErr)
+		}
+		current.CurrencyDeliveryPrices = prices
+	}
+	if input.Tax != nil {
+		if taxErr := input.Tax.Validate(); taxErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.SettingsInvalidTaxRatesError, taxErr)
+		}
+		current.Tax = *input.Tax
+	}
 
 	// Save
 	updated, err := app.Repositories.Settings.Upsert(ctx, current)
@@ -147,16 +192,18 @@ type CalculateDeliveryFeeInput struct {
 		Quantity int  `json:"quantity"`
 		Weight   *int `json:"weight,omitempty"` // in grams, optional
 	} `json:"items"`
+	Currency string `json:"currency"` // empty uses the business default
 }
 
 type CalculateDeliveryFeeOutput struct {
-	DistanceKm    float64 `json:"distance_km"`
-	TotalWeightG  int     `json:"total_weight_g"`
-	TotalWeightKg float64 `json:"total_weight_kg"`
-	BasePrice     float64 `json:"base_price"`
-	DistancePrice float64 `json:"distance_price"`
-	WeightPrice   float64 `json:"weight_price"`
-	TotalPrice    float64 `json:"total_price"`
+	DistanceKm    float64      `json:"distance_km"`
+	TotalWeightG  int          `json:"total_weight_g"`
+	TotalWeightKg float64      `json:"total_weight_kg"`
+	BasePrice     domain.Money `json:"base_price"`
+	DistancePrice domain.Money `json:"distance_price"`
+	WeightPrice   domain.Money `json:"weight_price"`
+	TotalPrice    domain.Money `json:"total_price"`
+	Currency      string       `json:"currency"`
 }
 
 type CalculateDeliveryFeeUsecase interface {
@@ -179,6 +226,16 @@ func (u *calculateDeliveryFeeUsecase) Execute(ctx context.Context, input Calcula
 		return nil, err
 	}
 
+	currency := settings.Currency()
+	if input.Currency != "" {
+		currency = domain.NormalizeCurrency(input.Currency)
+	}
+	prices, ok := settings.DeliveryPricesIn(currency)
+	if !ok {
+		return nil, apperrors.NewApplicationError(mappings.SettingsDeliveryPricesMissingError,
+			fmt.Errorf("no delivery prices in %s", currency))
+	}
+
 	// Calculate distance using Haversine formula
 	distanceKm := haversineDistance(
 		settings.BusinessLatitude, settings.BusinessLongitude,
@@ -196,10 +253,10 @@ func (u *calculateDeliveryFeeUsecase) Execute(ctx context.Context, input Calcula
 	}
 	totalWeightKg := float64(totalWeightG) / 1000.0
 
-	// Calculate prices
-	basePrice := settings.DeliveryBasePrice
-	distancePrice := distanceKm * settings.DeliveryPricePerKm
-	weightPrice := totalWeightKg * settings.DeliveryPricePerKg
+	// Calculate prices; each part is rounded to the cent so the total adds up
+	basePrice := prices.BasePrice
+	distancePrice := prices.PricePerKm.MulFloat(distanceKm)
+	weightPrice := prices.PricePerKg.MulFloat(totalWeightKg)
 	totalPrice := basePrice + distancePrice + weightPrice
 
 	return &CalculateDeliveryFeeOutput{
@@ -207,9 +264,10 @@ func (u *calculateDeliveryFeeUsecase) Execute(ctx context.Context, input Calcula
 		TotalWeightG:  totalWeightG,
 		TotalWeightKg: math.Round(totalWeightKg*100) / 100,
 		BasePrice:     basePrice,
-		DistancePrice: math.Round(distancePrice*100) / 100,
-		WeightPrice:   math.Round(weightPrice*100) / 100,
-		TotalPrice:    math.Round(totalPrice*100) / 100,
+		DistancePrice: distancePrice,
+		WeightPrice:   weightPrice,
+		TotalPrice:    totalPrice,
+		Currency:      currency,
 	}, nil
 }
 
@@ -0,0 +1,103 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// CreateInput represents the input for creating a subscription. The basket comes
+// either from Items or from one of the user's past orders (FromOrderID).
+type CreateInput struct {
+	UserID      string
+	Items       []domain.OrderItem
+	FromOrderID *string
+	ETA         string
+	Recurrence  domain.RecurrenceRule
+	FirstRunAt  *time.Time // defaults to one period from now
+}
+
+// CreateUsecase defines the interface for creating subscriptions
+type CreateUsecase interface {
+	Execute(ctx context.Context, input CreateInput) (*SubscriptionOutput, apperrors.ApplicationError)
+}
+
+type createUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewCreateUsecase creates a new instance of CreateUsecase
+func NewCreateUsecase(contextFactory appcontext.Factory) CreateUsecase {
+	return &createUsecase{contextFactory: contextFactory}
+}
+
+// Execute creates an active subscription for the user's profile
+func (u *createUsecase) Execute(ctx context.Context, input CreateInput) (*SubscriptionOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if validationErr := input.Recurrence.Validate(); validationErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidRecurrenceError, validationErr)
+	}
+
+	now := time.Now()
+	nextRunAt := input.Recurrence.Next(now)
+	if input.FirstRunAt != nil {
+		if !input.FirstRunAt.After(now) {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidStartError, nil)
+		}
+		nextRunAt = *input.FirstRunAt
+	}
+
+	items := input.Items
+	if input.FromOrderID != nil {
+		if _, err := uuid.Parse(*input.FromOrderID); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+		}
+		order, err := app.Repositories.Order.GetByID(ctx, *input.FromOrderID)
+		if err != nil {
+			return nil, err
+		}
+		if order.UserID == nil || *order.UserID != input.UserID {
+			return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+		}
+		if order.Data != nil {
+			items = order.Data.Items
+		}
+	}
+	if len(items) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionEmptyItemsError, nil)
+	}
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionEmptyItemsError,
+				errors.New("item quantities must be positive"))
+		}
+	}
+
+	profile, err := app.Repositories.Profile.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := app.Repositories.Subscription.Create(ctx, &domain.Subscription{
+		UserID:     input.UserID,
+		ProfileID:  profile.ID,
+		Data:       &domain.OrderData{Items: items},
+		ETA:        input.ETA,
+		Recurrence: input.Recurrence,
+		NextRunAt:  nextRunAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := toSubscriptionOutput(created)
+	return &output, nil
+}
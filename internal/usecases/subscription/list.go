@@ -0,0 +1,38 @@
+package subscription
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// ListUsecase defines the interface for listing a user's subscriptions
+type ListUsecase interface {
+	Execute(ctx context.Context, userID string) ([]SubscriptionOutput, apperrors.ApplicationError)
+}
+
+type listUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListUsecase creates a new instance of ListUsecase
+func NewListUsecase(contextFactory appcontext.Factory) ListUsecase {
+	return &listUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists the user's subscriptions, newest first
+func (u *listUsecase) Execute(ctx context.Context, userID string) ([]SubscriptionOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	subscriptions, err := app.Repositories.Subscription.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]SubscriptionOutput, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		outputs = append(outputs, toSubscriptionOutput(subscription))
+	}
+	return outputs, nil
+}
@@ -0,0 +1,95 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// Subscription actions a customer can take
+const (
+	ActionPause    = "pause"
+	ActionResume   = "resume"
+	ActionSkipNext = "skip-next"
+	ActionCancel   = "cancel"
+)
+
+// ManageInput represents a customer action on one of their subscriptions
+type ManageInput struct {
+	SubscriptionID string
+	UserID         string
+	Action         string
+}
+
+// ManageUsecase defines the interface for pausing, resuming, skipping and cancelling subscriptions
+type ManageUsecase interface {
+	Execute(ctx context.Context, input ManageInput) (*SubscriptionOutput, apperrors.ApplicationError)
+}
+
+type manageUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewManageUsecase creates a new instance of ManageUsecase
+func NewManageUsecase(contextFactory appcontext.Factory) ManageUsecase {
+	return &manageUsecase{contextFactory: contextFactory}
+}
+
+// Execute applies the action to the user's subscription
+func (u *manageUsecase) Execute(ctx context.Context, input ManageInput) (*SubscriptionOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.SubscriptionID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidIDError, err)
+	}
+
+	subscription, err := app.Repositories.Subscription.GetByID(ctx, input.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("subscription does not belong to this user"))
+	}
+	if subscription.Status == domain.SubscriptionCancelled {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionAlreadyCancelledError, nil)
+	}
+
+	now := time.Now()
+	switch input.Action {
+	case ActionPause:
+		if subscription.Status != domain.SubscriptionActive {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionNotActiveError, nil)
+		}
+		subscription.Status = domain.SubscriptionPaused
+	case ActionResume:
+		if subscription.Status != domain.SubscriptionPaused {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionNotPausedError, nil)
+		}
+		subscription.Resume(now)
+	case ActionSkipNext:
+		if subscription.Status != domain.SubscriptionActive {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionNotActiveError, nil)
+		}
+		subscription.SkipNext()
+	case ActionCancel:
+		subscription.Status = domain.SubscriptionCancelled
+		subscription.CancelledAt = &now
+	default:
+		return nil, apperrors.NewApplicationError(mappings.RequestBodyParsingError, errors.New("unknown subscription action "+input.Action))
+	}
+
+	updated, err := app.Repositories.Subscription.Update(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toSubscriptionOutput(updated)
+	return &output, nil
+}
@@ -0,0 +1,57 @@
+package subscription
+
+import (
+	"time"
+
+	"yego/internal/domain"
+)
+
+// SubscriptionOutput represents a subscription in API responses
+type SubscriptionOutput struct {
+	ID          string             `json:"id"`
+	ProfileID   string             `json:"profile_id"`
+	Items       []domain.OrderItem `json:"items"`
+	ETA         string             `json:"eta"`
+	Frequency   string             `json:"frequency"`
+	Interval    int                `json:"interval"`
+	Status      string             `json:"status"`
+	NextRunAt   string             `json:"next_run_at"`
+	LastRunAt   *string            `json:"last_run_at,omitempty"`
+	LastOrderID *string            `json:"last_order_id,omitempty"`
+	LastError   *string            `json:"last_error,omitempty"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+	CancelledAt *string            `json:"cancelled_at,omitempty"`
+}
+
+// toSubscriptionOutput converts a domain subscription to output
+func toSubscriptionOutput(subscription *domain.Subscription) SubscriptionOutput {
+	items := []domain.OrderItem{}
+	if subscription.Data != nil && subscription.Data.Items != nil {
+		items = subscription.Data.Items
+	}
+	return SubscriptionOutput{
+		ID:          subscription.ID,
+		ProfileID:   subscription.ProfileID,
+		Items:       items,
+		ETA:         subscription.ETA,
+		Frequency:   string(subscription.Recurrence.Frequency),
+		Interval:    subscription.Recurrence.Interval,
+		Status:      string(subscription.Status),
+		NextRunAt:   subscription.NextRunAt.UTC().Format("2006-01-02T15:04:05Z"),
+		LastRunAt:   formatOptionalTime(subscription.LastRunAt),
+		LastOrderID: subscription.LastOrderID,
+		LastError:   subscription.LastError,
+		CreatedAt:   subscription.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:   subscription.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		CancelledAt: formatOptionalTime(subscription.CancelledAt),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &formatted
+}
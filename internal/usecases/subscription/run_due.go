@@ -0,0 +1,107 @@
+package subscription
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// RunDueUsecase defines the interface for materializing orders from due subscriptions
+type RunDueUsecase interface {
+	Execute(ctx context.Context) (int, apperrors.ApplicationError)
+}
+
+type runDueUsecase struct {
+	contextFactory appcontext.Factory
+	createUse      orderUsecase.CreateUsecase
+}
+
+// NewRunDueUsecase creates a new instance of RunDueUsecase. Orders go through
+// createUse so they are priced and paid exactly like customer-placed orders.
+func NewRunDueUsecase(contextFactory appcontext.Factory, createUse orderUsecase.CreateUsecase) RunDueUsecase {
+	return &runDueUsecase{
+		contextFactory: contextFactory,
+		createUse:      createUse,
+	}
+}
+
+// Execute creates one order for every active subscription whose next run has passed
+// and returns how many were created. Each subscription is advanced before its order
+// is created, so a failed run is recorded and retried at the next occurrence rather
+// than repeated on every tick.
+func (u *runDueUsecase) Execute(ctx context.Context) (int, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	now := time.Now()
+	due, err := app.Repositories.Subscription.ListDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	createdCount := 0
+	for _, subscription := range due {
+		claimed, claimErr := app.Repositories.Subscription.ClaimRun(ctx, subscription,
+			subscription.Recurrence.NextAfter(subscription.NextRunAt, now), now)
+		if claimErr != nil {
+			log.Printf("RunDue: failed to claim subscription %s: %v", subscription.ID, claimErr)
+			continue
+		}
+		if !claimed {
+			// Paused, cancelled, skipped or run by another instance since it was listed
+			continue
+		}
+
+		orderID, runErr := u.materialize(ctx, app, subscription)
+		var runErrMessage *string
+		if runErr != nil {
+			message := runErr.Error()
+			if original := runErr.OriginalError(); original != nil {
+				message += ": " + original.Error()
+			}
+			runErrMessage = &message
+			log.Printf("RunDue: subscription %s failed: %s", subscription.ID, message)
+		} else {
+			createdCount++
+		}
+
+		if recordErr := app.Repositories.Subscription.RecordRun(ctx, subscription.ID, orderID, runErrMessage); recordErr != nil {
+			log.Printf("RunDue: failed to record run of subscription %s: %v", subscription.ID, recordErr)
+		}
+	}
+
+	return createdCount, nil
+}
+
+// materialize places the subscription's order, charging the saved payment method
+// when the customer has one
+func (u *runDueUsecase) materialize(ctx context.Context, app *appcontext.Context, subscription *domain.Subscription) (*string, apperrors.ApplicationError) {
+	hasPaymentMethod := false
+	profile, profileErr := app.Repositories.Profile.GetByID(ctx, subscription.ProfileID)
+	if profileErr == nil {
+		var checkErr error
+		hasPaymentMethod, checkErr = app.Integrations.Payments.HasPaymentMethod(profile.UserID)
+		if checkErr != nil {
+			log.Printf("RunDue: could not check payment method for subscription %s: %v", subscription.ID, checkErr)
+		}
+	}
+
+	subscriptionID := subscription.ID
+	userID := subscription.UserID
+	output, err := u.createUse.Execute(ctx, orderUsecase.CreateInput{
+		ProfileID:         subscription.ProfileID,
+		ETA:               subscription.ETA,
+		UserID:            &userID,
+		Data:              subscription.Data,
+		SubscriptionID:    &subscriptionID,
+		ChargeSavedMethod: hasPaymentMethod,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &output.Data.ID, nil
+}
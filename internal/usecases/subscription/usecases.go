@@ -0,0 +1,24 @@
+package subscription
+
+import (
+	"yego/internal/platform/appcontext"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// Usecases aggregates all subscription use cases
+type Usecases struct {
+	Create CreateUsecase
+	List   ListUsecase
+	Manage ManageUsecase
+	RunDue RunDueUsecase
+}
+
+// NewUsecases creates all subscription use cases
+func NewUsecases(contextFactory appcontext.Factory, createOrderUse orderUsecase.CreateUsecase) *Usecases {
+	return &Usecases{
+		Create: NewCreateUsecase(contextFactory),
+		List:   NewListUsecase(contextFactory),
+		Manage: NewManageUsecase(contextFactory),
+		RunDue: NewRunDueUsecase(contextFactory, createOrderUse),
+	}
+}
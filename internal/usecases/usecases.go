@@ -4,16 +4,22 @@ import (
 	"yego/internal/adapters/web/websocket"
 	"yego/internal/platform/appcontext"
 	"yego/internal/usecases/admin"
+	"yego/internal/usecases/cart"
+	"yego/internal/usecases/deliveryslot"
 	"yego/internal/usecases/order"
 	"yego/internal/usecases/profile"
 	"yego/internal/usecases/settings"
+	"yego/internal/usecases/subscription"
 )
 
 type Usecases struct {
-	Order    Order
-	Profile  Profile
-	Admin    Admin
-	Settings Settings
+	Order        Order
+	Profile      Profile
+	Admin        Admin
+	Settings     Settings
+	DeliverySlot DeliverySlot
+	Subscription Subscription
+	Cart         Cart
 }
 
 type Order struct {
@@ -21,12 +27,26 @@ type Order struct {
 	CreateWithLinkUsecase       order.CreateWithLinkUsecase
 	ClaimUsecase                order.ClaimUsecase
 	GetUsecase                  order.GetUsecase
+	GetHistoryUsecase           order.GetHistoryUsecase
 	GetClaimInfoUsecase         order.GetClaimInfoUsecase
 	PayForOrderUsecase          order.PayForOrderUsecase
 	CreatePaymentLinkUsecase    order.CreatePaymentLinkUsecase
 	HandlePaymentWebhookUsecase order.HandlePaymentWebhookUsecase
 	UpdateStatusUsecase         order.UpdateStatusUsecase
 	ListMyOrdersUsecase         order.ListMyOrdersUsecase
+	CancelUsecase               order.CancelUsecase
+	RequestModificationUsecase  order.RequestModificationUsecase
+	ListModificationsUsecase    order.ListModificationsUsecase
+	PauseUsecase                order.PauseUsecase
+	ResumeUsecase               order.ResumeUsecase
+	ResumeDueUsecase            order.ResumeDueUsecase
+	GetDeliveryCodeUsecase      order.GetDeliveryCodeUsecase
+	UploadAttachmentUsecase     order.UploadAttachmentUsecase
+	ApplyCouponUsecase          order.ApplyCouponUsecase
+	RemoveCouponUsecase         order.RemoveCouponUsecase
+	TipOrderUsecase             order.TipOrderUsecase
+	ReorderUsecase              order.ReorderUsecase
+	GetDocumentUsecase          order.GetDocumentUsecase
 }
 
 type Profile struct {
@@ -40,16 +60,26 @@ type Profile struct {
 }
 
 type Admin struct {
-	ListProfilesUsecase     admin.ListProfilesUsecase
-	ListOrdersUsecase       admin.ListOrdersUsecase
-	ListTransactionsUsecase admin.ListTransactionsUsecase
-	UpdateOrderUsecase      admin.UpdateOrderUsecase
-	UploadImport            admin.UploadImportUsecase
-	ListImports             admin.ListImportsUsecase
-	CreateImport            admin.CreateImportUsecase
-	UpdateImport            admin.UpdateImportUsecase
-	DeleteImport            admin.DeleteImportUsecase
-	ClearImports            admin.ClearImportsUsecase
+	ListProfilesUsecase       admin.ListProfilesUsecase
+	ListOrdersUsecase         admin.ListOrdersUsecase
+	ListTransactionsUsecase   admin.ListTransactionsUsecase
+	ExportOrders              admin.ExportOrdersUsecase
+	ExportTransactions        admin.ExportTransactionsUsecase
+	UpdateOrderUsecase        admin.UpdateOrderUsecase
+	BulkUpdateOrdersUsecase   admin.BulkUpdateOrdersUsecase
+	GetModificationUsecase    admin.GetModificationRequestUsecase
+	ReviewModificationUsecase admin.ReviewModificationUsecase
+	UploadImport              admin.UploadImportUsecase
+	ListImports               admin.ListImportsUsecase
+	CreateImport              admin.CreateImportUsecase
+	UpdateImport              admin.UpdateImportUsecase
+	DeleteImport              admin.DeleteImportUsecase
+	ClearImports              admin.ClearImportsUsecase
+	GetAttachmentUsecase      admin.GetAttachmentUsecase
+	ListPromotions            admin.ListPromotionsUsecase
+	CreatePromotion           admin.CreatePromotionUsecase
+	UpdatePromotion           admin.UpdatePromotionUsecase
+	DeletePromotion           admin.DeletePromotionUsecase
 }
 
 type Settings struct {
@@ -58,6 +88,24 @@ type Settings struct {
 	CalculateDeliveryFeeUsecase settings.CalculateDeliveryFeeUsecase
 }
 
+type DeliverySlot struct {
+	ListAvailableUsecase deliveryslot.ListAvailableUsecase
+}
+
+type Subscription struct {
+	CreateUsecase subscription.CreateUsecase
+	ListUsecase   subscription.ListUsecase
+	ManageUsecase subscription.ManageUsecase
+	RunDueUsecase subscription.RunDueUsecase
+}
+
+type Cart struct {
+	GetUsecase         cart.GetUsecase
+	ManageItemsUsecase cart.ManageItemsUsecase
+	SubmitUsecase      cart.SubmitUsecase
+	ExpireStaleUsecase cart.ExpireStaleUsecase
+}
+
 func CreateUsecases(contextFactory appcontext.Factory) *Usecases {
 	app := contextFactory()
 	hub := app.Integrations.WebSocket.GetHub()
@@ -69,18 +117,34 @@ func CreateUsecases(contextFactory appcontext.Factory) *Usecases {
 		CalculateDeliveryFeeUsecase: settings.NewCalculateDeliveryFeeUsecase(contextFactory),
 	}
 
+	createOrderUsecase := order.NewCreateUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase)
+
 	return &Usecases{
 		Order: Order{
-			CreateUsecase:               order.NewCreateUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			CreateUsecase:               createOrderUsecase,
 			CreateWithLinkUsecase:       order.NewCreateWithLinkUsecase(contextFactory),
 			ClaimUsecase:                order.NewClaimUsecase(contextFactory, notifier, settingsUsecases.CalculateDeliveryFeeUsecase),
 			GetUsecase:                  order.NewGetUsecase(contextFactory),
+			GetHistoryUsecase:           order.NewGetHistoryUsecase(contextFactory),
 			GetClaimInfoUsecase:         order.NewGetClaimInfoUsecase(contextFactory),
 			PayForOrderUsecase:          order.NewPayForOrderUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
 			CreatePaymentLinkUsecase:    order.NewCreatePaymentLinkUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
 			HandlePaymentWebhookUsecase: order.NewHandlePaymentWebhookUsecase(contextFactory),
-			UpdateStatusUsecase:         order.NewUpdateStatusUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			UpdateStatusUsecase:         order.NewUpdateStatusUsecase(contextFactory, notifier, settingsUsecases.CalculateDeliveryFeeUsecase),
 			ListMyOrdersUsecase:         order.NewListMyOrdersUsecase(contextFactory),
+			CancelUsecase:               order.NewCancelUsecase(contextFactory, notifier),
+			RequestModificationUsecase:  order.NewRequestModificationUsecase(contextFactory),
+			ListModificationsUsecase:    order.NewListModificationsUsecase(contextFactory),
+			PauseUsecase:                order.NewPauseUsecase(contextFactory, notifier),
+			ResumeUsecase:               order.NewResumeUsecase(contextFactory, notifier),
+			ResumeDueUsecase:            order.NewResumeDueUsecase(contextFactory, notifier),
+			GetDeliveryCodeUsecase:      order.NewGetDeliveryCodeUsecase(contextFactory),
+			UploadAttachmentUsecase:     order.NewUploadAttachmentUsecase(contextFactory),
+			ApplyCouponUsecase:          order.NewApplyCouponUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			RemoveCouponUsecase:         order.NewRemoveCouponUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			TipOrderUsecase:             order.NewTipOrderUsecase(contextFactory),
+			ReorderUsecase:              order.NewReorderUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			GetDocumentUsecase:          order.NewGetDocumentUsecase(contextFactory),
 		},
 		Profile: Profile{
 			GenerateLinkUsecase:    profile.NewGenerateLinkUsecase(contextFactory),
@@ -92,17 +156,42 @@ func CreateUsecases(contextFactory appcontext.Factory) *Usecases {
 			CheckCompletedUsecase:  profile.NewCheckCompletedUsecase(contextFactory),
 		},
 		Admin: Admin{
-			ListProfilesUsecase:     admin.NewListProfilesUsecase(contextFactory),
-			ListOrdersUsecase:       admin.NewListOrdersUsecase(contextFactory),
-			ListTransactionsUsecase: admin.NewListTransactionsUsecase(contextFactory),
-			UpdateOrderUsecase:      admin.NewUpdateOrderUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
-			UploadImport:            admin.NewUploadImportUsecase(contextFactory),
-			ListImports:             admin.NewListImportsUsecase(contextFactory),
-			CreateImport:            admin.NewCreateImportUsecase(contextFactory),
-			UpdateImport:            admin.NewUpdateImportUsecase(contextFactory),
-			DeleteImport:            admin.NewDeleteImportUsecase(contextFactory),
-			ClearImports:            admin.NewClearImportsUsecase(contextFactory),
+			ListProfilesUsecase:       admin.NewListProfilesUsecase(contextFactory),
+			ListOrdersUsecase:         admin.NewListOrdersUsecase(contextFactory),
+			ListTransactionsUsecase:   admin.NewListTransactionsUsecase(contextFactory),
+			ExportOrders:              admin.NewExportOrdersUsecase(contextFactory),
+			ExportTransactions:        admin.NewExportTransactionsUsecase(contextFactory),
+			UpdateOrderUsecase:        admin.NewUpdateOrderUsecase(contextFactory, notifier, settingsUsecases.CalculateDeliveryFeeUsecase),
+			BulkUpdateOrdersUsecase:   admin.NewBulkUpdateOrdersUsecase(contextFactory, notifier, settingsUsecases.CalculateDeliveryFeeUsecase),
+			GetModificationUsecase:    admin.NewGetModificationRequestUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			ReviewModificationUsecase: admin.NewReviewModificationUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			UploadImport:              admin.NewUploadImportUsecase(contextFactory),
+			ListImports:               admin.NewListImportsUsecase(contextFactory),
+			CreateImport:              admin.NewCreateImportUsecase(contextFactory),
+			UpdateImport:              admin.NewUpdateImportUsecase(contextFactory),
+			DeleteImport:              admin.NewDeleteImportUsecase(contextFactory),
+			ClearImports:              admin.NewClearImportsUsecase(contextFactory),
+			GetAttachmentUsecase:      admin.NewGetAttachmentUsecase(contextFactory),
+			ListPromotions:            admin.NewListPromotionsUsecase(contextFactory),
+			CreatePromotion:           admin.NewCreatePromotionUsecase(contextFactory),
+			UpdatePromotion:           admin.NewUpdatePromotionUsecase(contextFactory),
+			DeletePromotion:           admin.NewDeletePromotionUsecase(contextFactory),
 		},
 		Settings: settingsUsecases,
+		DeliverySlot: DeliverySlot{
+			ListAvailableUsecase: deliveryslot.NewListAvailableUsecase(contextFactory),
+		},
+		Subscription: Subscription{
+			CreateUsecase: subscription.NewCreateUsecase(contextFactory),
+			ListUsecase:   subscription.NewListUsecase(contextFactory),
+			ManageUsecase: subscription.NewManageUsecase(contextFactory),
+			RunDueUsecase: subscription.NewRunDueUsecase(contextFactory, createOrderUsecase),
+		},
+		Cart: Cart{
+			GetUsecase:         cart.NewGetUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			ManageItemsUsecase: cart.NewManageItemsUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			SubmitUsecase:      cart.NewSubmitUsecase(contextFactory, createOrderUsecase),
+			ExpireStaleUsecase: cart.NewExpireStaleUsecase(contextFactory),
+		},
 	}
 }
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS order_status_events (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    previous_status VARCHAR(50),
+    new_status VARCHAR(50) NOT NULL,
+    actor_user_id VARCHAR(255),
+    message VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_events_order_id ON order_status_events(order_id, created_at);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS order_modification_requests (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    previous_status VARCHAR(50) NOT NULL,
+    original_items JSONB NOT NULL,
+    items JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    reviewed_by VARCHAR(255),
+    review_note VARCHAR(500),
+    price_difference DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_modification_requests_order_id ON order_modification_requests(order_id);
+-- Only one open proposal per order
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_modification_requests_pending
+    ON order_modification_requests(order_id) WHERE status = 'PENDING';
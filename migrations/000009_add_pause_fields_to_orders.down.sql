@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_orders_resume_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS resume_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS pause_reason;
+ALTER TABLE orders DROP COLUMN IF EXISTS paused_from_status;
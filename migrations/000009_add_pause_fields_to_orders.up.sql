@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS paused_from_status VARCHAR(50);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pause_reason VARCHAR(500);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS resume_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_resume_at ON orders(resume_at) WHERE resume_at IS NOT NULL;
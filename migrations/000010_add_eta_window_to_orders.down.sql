@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_eta_to;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS eta_to;
+ALTER TABLE orders DROP COLUMN IF EXISTS eta_from;
@@ -0,0 +1,62 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS eta_from TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS eta_to TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_eta_to ON orders(eta_to);
+
+-- Best-effort conversion of the legacy free-text ETA. Local times are read in
+-- Buenos Aires time on the day the order was created; anything that cannot be
+-- parsed keeps only its label.
+CREATE OR REPLACE FUNCTION parse_legacy_eta(label TEXT, created TIMESTAMPTZ, OUT from_ts TIMESTAMPTZ, OUT to_ts TIMESTAMPTZ) AS $$
+DECLARE
+    value TEXT := lower(trim(label));
+    local_zone CONSTANT TEXT := 'America/Argentina/Buenos_Aires';
+    local_day DATE := (created AT TIME ZONE 'America/Argentina/Buenos_Aires')::date;
+    m TEXT[];
+BEGIN
+    -- Time ranges: "14:00 - 16:00", "14 a 16 hs"
+    m := regexp_match(value, '^(\d{1,2})(?::(\d{2}))?\s*(?:hs?)?\s*(?:-|a)\s*(\d{1,2})(?::(\d{2}))?\s*(?:h|hs)?$');
+    IF m IS NOT NULL THEN
+        from_ts := (local_day + make_time(m[1]::int, coalesce(m[2], '0')::int, 0)) AT TIME ZONE local_zone;
+        to_ts := (local_day + make_time(m[3]::int, coalesce(m[4], '0')::int, 0)) AT TIME ZONE local_zone;
+        IF to_ts < from_ts THEN
+            to_ts := to_ts + INTERVAL '1 day';
+        END IF;
+        RETURN;
+    END IF;
+
+    -- Single time of day: "14:30", "14:30 hs", "14 hs"
+    m := regexp_match(value, '^(\d{1,2})(?::(\d{2}))?\s*(hs?)?$');
+    IF m IS NOT NULL AND (m[2] IS NOT NULL OR m[3] IS NOT NULL) THEN
+        from_ts := (local_day + make_time(m[1]::int, coalesce(m[2], '0')::int, 0)) AT TIME ZONE local_zone;
+        to_ts := from_ts;
+        RETURN;
+    END IF;
+
+    -- Relative to creation: "30 min", "45 minutos", "2 horas"
+    m := regexp_match(value, '^(\d{1,4})\s*(min|mins|minuto|minutos|hora|horas)$');
+    IF m IS NOT NULL THEN
+        IF m[2] LIKE 'h%' THEN
+            from_ts := created + make_interval(hours => m[1]::int);
+        ELSE
+            from_ts := created + make_interval(mins => m[1]::int);
+        END IF;
+        to_ts := from_ts;
+        RETURN;
+    END IF;
+
+    -- Full timestamps: "2024-05-01T14:30:00Z", "2024-05-01 14:30"
+    IF value ~ '^\d{4}-\d{2}-\d{2}[t ]\d{2}:\d{2}' THEN
+        from_ts := trim(label)::timestamptz;
+        to_ts := from_ts;
+    END IF;
+EXCEPTION WHEN others THEN
+    from_ts := NULL;
+    to_ts := NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE orders
+SET (eta_from, eta_to) = (SELECT from_ts, to_ts FROM parse_legacy_eta(eta, created_at))
+WHERE eta_from IS NULL AND coalesce(eta, '') <> '';
+
+DROP FUNCTION IF EXISTS parse_legacy_eta(TEXT, TIMESTAMPTZ);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_delivery_slot_reservations_slot;
+DROP TABLE IF EXISTS delivery_slot_reservations;
+DROP TABLE IF EXISTS delivery_slot_usage;
+
+ALTER TABLE settings DROP COLUMN IF EXISTS timezone;
+ALTER TABLE settings DROP COLUMN IF EXISTS delivery_slots;
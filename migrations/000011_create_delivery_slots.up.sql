@@ -0,0 +1,25 @@
+-- Weekly slot definitions live in settings; times are local to the business timezone
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS delivery_slots JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'America/Argentina/Buenos_Aires';
+
+-- One counter row per concrete slot; reservations increment it only while below capacity
+CREATE TABLE IF NOT EXISTS delivery_slot_usage (
+    slot_date DATE NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    end_time VARCHAR(5) NOT NULL,
+    reserved INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    PRIMARY KEY (slot_date, start_time, end_time)
+);
+
+CREATE TABLE IF NOT EXISTS delivery_slot_reservations (
+    id UUID PRIMARY KEY,
+    order_id UUID UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    slot_date DATE NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    end_time VARCHAR(5) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_slot_reservations_slot ON delivery_slot_reservations(slot_date, start_time, end_time);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_subscription_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS subscription_id;
+
+DROP INDEX IF EXISTS idx_subscriptions_next_run_at;
+DROP INDEX IF EXISTS idx_subscriptions_user_id;
+DROP TABLE IF EXISTS subscriptions;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    profile_id UUID NOT NULL REFERENCES profiles(id),
+    data JSONB NOT NULL,
+    eta VARCHAR(255) NOT NULL DEFAULT '',
+    frequency VARCHAR(20) NOT NULL,
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    last_error VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+-- The scheduler only scans active subscriptions that are due
+CREATE INDEX IF NOT EXISTS idx_subscriptions_next_run_at ON subscriptions(next_run_at) WHERE status = 'ACTIVE';
+
+-- Orders materialized from a subscription point back to it
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subscription_id UUID REFERENCES subscriptions(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_subscription_id ON orders(subscription_id) WHERE subscription_id IS NOT NULL;
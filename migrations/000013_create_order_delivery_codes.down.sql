@@ -0,0 +1 @@
+DROP TABLE IF EXISTS order_delivery_codes;
@@ -0,0 +1,12 @@
+-- One proof-of-delivery code per order, issued when it goes ON_THE_WAY
+CREATE TABLE IF NOT EXISTS order_delivery_codes (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    code VARCHAR(10) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    total_failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    override_by VARCHAR(255),
+    override_reason VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
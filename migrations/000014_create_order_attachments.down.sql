@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_attachments_order_id;
+DROP TABLE IF EXISTS order_attachments;
@@ -0,0 +1,2 @@
+-- Priced snapshot taken when the order is claimed or confirmed; payments charge this
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pricing JSONB;
@@ -0,0 +1,10 @@
+ALTER TABLE order_modification_requests
+    ALTER COLUMN price_difference TYPE DOUBLE PRECISION USING price_difference::double precision;
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount::double precision;
+
+ALTER TABLE settings
+    ALTER COLUMN delivery_base_price TYPE DOUBLE PRECISION USING delivery_base_price::double precision,
+    ALTER COLUMN delivery_price_per_km TYPE DOUBLE PRECISION USING delivery_price_per_km::double precision,
+    ALTER COLUMN delivery_price_per_kg TYPE DOUBLE PRECISION USING delivery_price_per_kg::double precision;
@@ -0,0 +1,11 @@
+-- Amounts are exact cents from here on; existing values are rounded to the cent
+ALTER TABLE settings
+    ALTER COLUMN delivery_base_price TYPE NUMERIC(12, 2) USING ROUND(delivery_base_price::numeric, 2),
+    ALTER COLUMN delivery_price_per_km TYPE NUMERIC(12, 2) USING ROUND(delivery_price_per_km::numeric, 2),
+    ALTER COLUMN delivery_price_per_kg TYPE NUMERIC(12, 2) USING ROUND(delivery_price_per_kg::numeric, 2);
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE NUMERIC(12, 2) USING ROUND(amount::numeric, 2);
+
+ALTER TABLE order_modification_requests
+    ALTER COLUMN price_difference TYPE NUMERIC(12, 2) USING ROUND(price_difference::numeric, 2);
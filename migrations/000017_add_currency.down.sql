@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+ALTER TABLE settings DROP COLUMN IF EXISTS default_currency;
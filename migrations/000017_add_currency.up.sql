@@ -0,0 +1,4 @@
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS default_currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
+
+-- Existing orders were all priced and paid in pesos
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
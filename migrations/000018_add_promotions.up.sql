@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL,
+    percent_off NUMERIC(5,2) NOT NULL DEFAULT 0,
+    amount_off NUMERIC(12,2) NOT NULL DEFAULT 0,
+    buy_code VARCHAR(100),
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    get_quantity INTEGER NOT NULL DEFAULT 0,
+    min_order_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'ARS',
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    max_uses_per_customer INTEGER,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    id UUID PRIMARY KEY,
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (promotion_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_order_id ON promotion_redemptions(order_id);
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_promotion_user ON promotion_redemptions(promotion_id, user_id);
@@ -0,0 +1,3 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS net_amount;
+ALTER TABLE settings DROP COLUMN IF EXISTS tax_rates;
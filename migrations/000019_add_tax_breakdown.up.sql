@@ -0,0 +1,7 @@
+-- IVA rates in percent; prices stay tax-included
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS tax_rates JSONB NOT NULL
+    DEFAULT '{"default_rate": 21, "delivery_rate": 21, "category_rates": {}}';
+
+-- Fiscal split of each transaction; NULL on transactions recorded before taxes
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS net_amount NUMERIC(12,2);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tax_amount NUMERIC(12,2);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_kind;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS kind;
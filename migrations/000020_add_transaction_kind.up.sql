@@ -0,0 +1,4 @@
+-- Tips are recorded apart from order payments so they can be paid out to drivers
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'payment';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_kind ON transactions(kind);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS carts (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    data JSONB NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'ARS',
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A customer has at most one open cart
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_open_user_id ON carts(user_id) WHERE status = 'OPEN';
+-- The expiry worker only scans open carts by inactivity
+CREATE INDEX IF NOT EXISTS idx_carts_open_updated_at ON carts(updated_at) WHERE status = 'OPEN';
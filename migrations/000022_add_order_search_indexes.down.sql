@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_orders_data;
+DROP INDEX IF EXISTS idx_orders_total;
+DROP INDEX IF EXISTS idx_orders_status_created_at;
+DROP INDEX IF EXISTS idx_orders_updated_at_id;
+DROP INDEX IF EXISTS idx_orders_created_at_id;
@@ -0,0 +1,8 @@
+-- Admin order search: keyset pagination on each sort field, with id as tie-breaker
+CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at_id ON orders(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_total ON orders((COALESCE((pricing->>'total')::numeric, 0)), id);
+
+-- Exact item code lookups use JSONB containment on data
+CREATE INDEX IF NOT EXISTS idx_orders_data ON orders USING GIN (data jsonb_path_ops);
@@ -0,0 +1,3 @@
+-- Delivery prices of orders not in the default currency, e.g.
+-- {"USD": {"base_price": 5, "price_per_km": 1, "price_per_kg": 0.5}}
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS currency_delivery_prices JSONB NOT NULL DEFAULT '{}';